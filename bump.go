@@ -2,6 +2,9 @@
 package bump
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -18,14 +21,199 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	format "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 // execCommand is a variable to hold the exec.Command function for easier testing and mocking.
 var execCommand = exec.Command
 
-// semanticVersionRegex is a regular expression for semantic versioning.
-var semanticVersionRegex = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)(-[0-9A-Za-z-.]+)?$`)
+// defaultVersionRegex matches version tags under the default TagScheme, including
+// optional build metadata ("+build.42").
+var defaultVersionRegex = semanticVersionPattern(DefaultTagPrefix, DefaultSuffixSeparator)
+
+// DefaultTagPrefix is the prefix placed before the version in a tag name.
+const DefaultTagPrefix = "v"
+
+// DefaultSuffixSeparator is the SemVer separator between a version and its pre-release suffix.
+const DefaultSuffixSeparator = "-"
+
+// TagScheme holds the settings that decide which tags are versions and how they
+// sort: the tag prefix, the pre-release separator, the tag pattern, and the
+// channel order. The zero value is bump's default, with tags such as v1.2.3-rc.1,
+// every version tag counted, and suffixes compared as SemVer does. Use
+// NewTagScheme to build another.
+type TagScheme struct {
+	prefix       string         // prefix is placed before the version in tag names; empty means "v"
+	separator    string         // separator is placed before a pre-release suffix in new tags; empty means "-"
+	versionRegex *regexp.Regexp // versionRegex matches version tags; nil means defaultVersionRegex
+	pattern      *regexp.Regexp // pattern restricts which tags count as releases; nil accepts every version tag
+	channelRanks map[string]int // channelRanks orders pre-release channels; nil compares suffixes lexically
+}
+
+// TagSchemeOptions are the settings a TagScheme is built from. The zero value
+// gives the default scheme.
+type TagSchemeOptions struct {
+	// Prefix is placed before the version in tag names, e.g. "api/v" for tags such
+	// as api/v1.2.3 in a repository holding several modules. Only tags with the
+	// prefix are versions. Surrounding whitespace is ignored; empty means
+	// DefaultTagPrefix.
+	Prefix string
+	// SuffixSeparator is placed between the version and a pre-release suffix, for
+	// projects with legacy tags such as v1.2.3_beta.1. Only "-" is SemVer-compliant;
+	// "_" and "." are also accepted. Tags using either "-" or the configured
+	// separator are parsed. Empty means DefaultSuffixSeparator.
+	SuffixSeparator string
+	// TagPattern restricts the tags considered as releases to those matching the
+	// regular expression, e.g. `^v\d+\.\d+\.\d+$` to ignore pre-releases or `^v2\.`
+	// to follow a single major line. Tags must still be semantic versions. Empty
+	// removes the restriction.
+	TagPattern string
+	// ChannelOrder ranks pre-release channels from lowest to highest precedence, so
+	// an order such as dev,alpha,beta,rc sorts v1.0.0-dev.1 before v1.0.0-alpha.1.
+	// Only the first suffix identifier is ranked; suffixes whose channels are not
+	// both listed are compared lexically. Empty removes the ranking.
+	ChannelOrder []string
+}
+
+// NewTagScheme validates opts and returns the TagScheme they describe.
+func NewTagScheme(opts TagSchemeOptions) (TagScheme, error) {
+	var scheme TagScheme
+	prefix, err := normalizeTagPrefix(opts.Prefix)
+	if err != nil {
+		return TagScheme{}, err
+	}
+	if prefix != "" && prefix != DefaultTagPrefix {
+		scheme.prefix = prefix
+	}
+	switch sep := opts.SuffixSeparator; sep {
+	case "", DefaultSuffixSeparator:
+	case "_", ".":
+		scheme.separator = sep
+	default:
+		return TagScheme{}, fmt.Errorf("invalid suffix separator %q: expected -, _, or .", sep)
+	}
+	if scheme.prefix != "" || scheme.separator != "" {
+		scheme.versionRegex = semanticVersionPattern(scheme.Prefix(), scheme.SuffixSeparator())
+	}
+
+	if opts.TagPattern != "" {
+		re, err := regexp.Compile(opts.TagPattern)
+		if err != nil {
+			return TagScheme{}, fmt.Errorf("invalid tag pattern %q: %w", opts.TagPattern, err)
+		}
+		scheme.pattern = re
+	}
+
+	if len(opts.ChannelOrder) > 0 {
+		scheme.channelRanks = make(map[string]int, len(opts.ChannelOrder))
+		for i, channel := range opts.ChannelOrder {
+			channel = strings.TrimSpace(channel)
+			if channel == "" {
+				return TagScheme{}, fmt.Errorf("invalid channel order %q: channel names cannot be empty", strings.Join(opts.ChannelOrder, ","))
+			}
+			if _, isNum := parseNumericIdentifier(channel); isNum || strings.ContainsAny(channel, ".+") {
+				return TagScheme{}, fmt.Errorf("invalid channel %q in channel order: expected a single non-numeric identifier", channel)
+			}
+			if _, dup := scheme.channelRanks[channel]; dup {
+				return TagScheme{}, fmt.Errorf("channel %q is listed more than once in channel order", channel)
+			}
+			scheme.channelRanks[channel] = i
+		}
+	}
+	return scheme, nil
+}
+
+// ReadTagSchemeOptions reads the prefix, suffixSeparator, tagPattern, and
+// comma-separated channelOrder settings of the repository at repoPath. A setting
+// that cannot be read is an error.
+func ReadTagSchemeOptions(repoPath string) (TagSchemeOptions, error) {
+	var opts TagSchemeOptions
+	var err error
+	if opts.Prefix, _, err = GetConfigString(repoPath, "prefix"); err != nil {
+		return TagSchemeOptions{}, fmt.Errorf("failed to read the prefix setting: %w", err)
+	}
+	if opts.SuffixSeparator, _, err = GetConfigString(repoPath, "suffixSeparator"); err != nil {
+		return TagSchemeOptions{}, fmt.Errorf("failed to read the suffixSeparator setting: %w", err)
+	}
+	if opts.TagPattern, _, err = GetConfigString(repoPath, "tagPattern"); err != nil {
+		return TagSchemeOptions{}, fmt.Errorf("failed to read the tagPattern setting: %w", err)
+	}
+	order, _, err := GetConfigString(repoPath, "channelOrder")
+	if err != nil {
+		return TagSchemeOptions{}, fmt.Errorf("failed to read the channelOrder setting: %w", err)
+	}
+	if strings.TrimSpace(order) != "" {
+		opts.ChannelOrder = strings.Split(order, ",")
+	}
+	return opts, nil
+}
+
+// ReadTagScheme returns the TagScheme described by the settings of the repository
+// at repoPath.
+func ReadTagScheme(repoPath string) (TagScheme, error) {
+	opts, err := ReadTagSchemeOptions(repoPath)
+	if err != nil {
+		return TagScheme{}, err
+	}
+	return NewTagScheme(opts)
+}
+
+// Prefix returns the prefix placed before the version in tag names.
+func (s TagScheme) Prefix() string {
+	if s.prefix == "" {
+		return DefaultTagPrefix
+	}
+	return s.prefix
+}
+
+// StripPrefix returns tag without the scheme's prefix, as in v1.2.3 to 1.2.3. A
+// tag without the prefix is returned unchanged.
+func (s TagScheme) StripPrefix(tag string) string {
+	return strings.TrimPrefix(tag, s.Prefix())
+}
+
+// SuffixSeparator returns the separator placed between the version and a pre-release suffix.
+func (s TagScheme) SuffixSeparator() string {
+	if s.separator == "" {
+		return DefaultSuffixSeparator
+	}
+	return s.separator
+}
+
+// versionPattern returns the regular expression matching the scheme's version tags.
+func (s TagScheme) versionPattern() *regexp.Regexp {
+	if s.versionRegex == nil {
+		return defaultVersionRegex
+	}
+	return s.versionRegex
+}
+
+// semanticVersionPattern compiles the strict version pattern for tags starting
+// with prefix, accepting sep as well as "-" before the pre-release suffix.
+func semanticVersionPattern(prefix, sep string) *regexp.Regexp {
+	separators := "-"
+	if sep != "-" {
+		separators += regexp.QuoteMeta(sep)
+	}
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(prefix) + `(\d+)\.(\d+)\.(\d+)([` + separators + `][0-9A-Za-z-.]+)?(\+[0-9A-Za-z-.]+)?$`)
+}
+
+// suffixIdentifiers returns a pre-release suffix without its leading separator.
+func suffixIdentifiers(suffix string) string {
+	if suffix == "" {
+		return ""
+	}
+	return suffix[1:]
+}
+
+// lenientVersionRegex matches version tags written without the canonical form,
+// such as "1.2.3", "release-1.2.3", or "release/v1.2.3".
+var lenientVersionRegex = regexp.MustCompile(`^(?:[A-Za-z][0-9A-Za-z_./-]*?[-_/])?[vV]?(\d+)\.(\d+)\.(\d+)(-[0-9A-Za-z-.]+)?(\+[0-9A-Za-z-.]+)?$`)
+
+// peeledRefSuffix marks a peeled reference to the object an annotated tag points at.
+const peeledRefSuffix = "^{}"
 
 // gitLocks stores file-based locks per repository to prevent concurrent git operations.
 var gitLocks = make(map[string]*sync.Mutex)
@@ -35,16 +223,46 @@ var gitLocksMutex sync.RWMutex
 
 // GitLock represents a file-based lock for git operations.
 type GitLock struct {
-	lockFile string       // lockFile is the path to the lock file
-	acquired bool         // acquired indicates whether the lock has been successfully acquired
+	lockFile string      // lockFile is the path to the lock file
+	acquired bool        // acquired indicates whether the lock has been successfully acquired
 	mutex    *sync.Mutex // mutex is the in-process mutex for this repository
+	flock    *os.File    // flock is the open lock file holding an advisory lock; nil for the file backend
 }
 
+// Lock backends selectable with the lockBackend setting.
+const (
+	// LockBackendFile creates the lock file exclusively and removes it on release.
+	LockBackendFile = "file"
+
+	// LockBackendFlock holds an advisory flock on the lock file, which stays in
+	// place. It is reliable on network filesystems where exclusive create is not,
+	// and the lock is dropped by the OS if the process dies, so it never goes stale.
+	LockBackendFlock = "flock"
+)
+
+// ErrLockBusy is returned when a lock is still held by another process after
+// waiting for it, so callers can tell contention apart from other lock failures.
+var ErrLockBusy = errors.New("another bump may be running")
+
+// errFlockUnsupported is returned by the flock backend on platforms without flock.
+var errFlockUnsupported = errors.New("flock is not supported on this platform")
+
+// lockAttempts and lockRetryInterval bound how long acquiring a lock waits for
+// another process to release it.
+const (
+	lockAttempts      = 30
+	lockRetryInterval = 100 * time.Millisecond
+)
+
 // acquireGitLock acquires a file-based lock for git operations on the specified repository.
 // This prevents concurrent git operations that could corrupt the repository state.
 func acquireGitLock(repoPath string) (*GitLock, error) {
 	// Validate repository path first
-	if err := validateRepositoryPath(repoPath); err != nil {
+	if repoPath == "" {
+		return nil, fmt.Errorf("invalid repository for git lock: repository path cannot be empty")
+	}
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
 		return nil, fmt.Errorf("invalid repository for git lock: %w", err)
 	}
 
@@ -53,25 +271,231 @@ func acquireGitLock(repoPath string) (*GitLock, error) {
 		return nil, fmt.Errorf("failed to resolve repository path: %w", err)
 	}
 
-	// Get or create a mutex for this repository
+	lockFile, err := gitLockPath(absRepoPath, gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := lockSetting(absRepoPath, "lockBackend")
+	if err != nil {
+		return nil, err
+	}
+	switch backend {
+	case "", LockBackendFile:
+		return acquireFileLock(absRepoPath, lockFile)
+	case LockBackendFlock:
+		return acquireFlock(absRepoPath, lockFile)
+	default:
+		return nil, fmt.Errorf("invalid lockBackend %q: expected %s or %s", backend, LockBackendFile, LockBackendFlock)
+	}
+}
+
+// LockFileEnv names the environment variable that relocates the git lock from
+// <gitdir>/bump.lock, for example when the git directory is read-only. It takes
+// precedence over the lockFile setting.
+const LockFileEnv = "BUMP_LOCK_FILE"
+
+// gitLockPath returns the lock file for the repository at repoPath: the path in
+// LockFileEnv, else the lockFile setting, else bump.lock in gitDir. Relative
+// paths are resolved against repoPath. A relocated lock must be in an existing
+// directory that bump can create files in.
+func gitLockPath(repoPath, gitDir string) (string, error) {
+	path := strings.TrimSpace(os.Getenv(LockFileEnv))
+	if path == "" {
+		value, err := lockSetting(repoPath, "lockFile")
+		if err != nil {
+			return "", err
+		}
+		path = value
+	}
+	if path == "" {
+		return filepath.Join(gitDir, "bump.lock"), nil
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid lock file %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("invalid lock file %s: %s is not a directory", path, dir)
+	}
+	if err := probeWritable(dir); err != nil {
+		return "", fmt.Errorf("invalid lock file %s: cannot create files in %s: %w", path, dir, err)
+	}
+	return path, nil
+}
+
+// lockSetting returns the trimmed value of a lock setting. A repository without a
+// config file has none set; any other failure to read the setting is an error.
+func lockSetting(repoPath, key string) (string, error) {
+	value, _, err := lookupConfig(repoPath, key)
+	if errors.Is(err, errConfigNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the %s setting: %w", key, err)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// probeWritable checks that files can be created in dir by creating and removing
+// a temporary file there.
+func probeWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".bump-lock-probe-*")
+	if err != nil {
+		return err
+	}
+	if err := probe.Close(); err != nil {
+		return err
+	}
+	return os.Remove(probe.Name())
+}
+
+// LockHolder describes the process recorded in a git lock file.
+type LockHolder struct {
+	Path  string    // Lock file
+	PID   int       // Process that took the lock; 0 if not recorded
+	Time  time.Time // When the lock was taken; zero if not recorded
+	Alive bool      // Whether a process with PID is still running
+}
+
+// ReadLock returns the holder recorded in the git lock file of the repository at
+// repoPath, or nil when there is no lock file.
+func ReadLock(repoPath string) (*LockHolder, error) {
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository for git lock: %w", err)
+	}
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository path: %w", err)
+	}
+	lockFile, err := gitLockPath(absRepoPath, gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(lockFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file %s: %w", lockFile, err)
+	}
+	holder := parseLockFile(string(content))
+	holder.Path = lockFile
+	holder.Alive = processAlive(holder.PID)
+	return &holder, nil
+}
+
+// ProbeLockInRepo acquires and immediately releases the git lock of the repository
+// at repoPath. It returns an error wrapping ErrLockBusy when another process still
+// holds the lock after waiting for it.
+func ProbeLockInRepo(repoPath string) error {
+	lock, err := acquireGitLock(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to acquire git lock: %w", err)
+	}
+	return lock.Release()
+}
+
+// RemoveLock deletes the lock file of holder. It does not check whether the
+// holder is still running; callers decide whether the lock is stale.
+func RemoveLock(holder *LockHolder) error {
+	if err := os.Remove(holder.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove lock file %s: %w", holder.Path, err)
+	}
+	return nil
+}
+
+// parseLockFile reads the "pid:" and "time:" lines written when a lock is taken.
+// Missing or malformed values are left zero.
+func parseLockFile(content string) LockHolder {
+	var holder LockHolder
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "pid":
+			holder.PID, _ = strconv.Atoi(value)
+		case "time":
+			holder.Time, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+	return holder
+}
+
+// lockMutex returns the in-process mutex for key, creating it on first use.
+func lockMutex(key string) *sync.Mutex {
 	gitLocksMutex.Lock()
-	if gitLocks[absRepoPath] == nil {
-		gitLocks[absRepoPath] = &sync.Mutex{}
+	defer gitLocksMutex.Unlock()
+	if gitLocks[key] == nil {
+		gitLocks[key] = &sync.Mutex{}
 	}
-	repoMutex := gitLocks[absRepoPath]
-	gitLocksMutex.Unlock()
+	return gitLocks[key]
+}
 
-	// Acquire the in-process mutex first
+// acquireFlock acquires the in-process mutex for key and then an advisory flock on
+// lockFile, waiting for another process holding it. The lock file is left in place
+// on release so every process locks the same file.
+func acquireFlock(key, lockFile string) (*GitLock, error) {
+	repoMutex := lockMutex(key)
 	repoMutex.Lock()
 
-	lockFile := filepath.Join(absRepoPath, ".git", "bump.lock")
+	file, err := os.OpenFile(lockFile, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		repoMutex.Unlock()
+		return nil, fmt.Errorf("failed to open lock file %s: %w", lockFile, err)
+	}
+
+	for i := 0; i < lockAttempts; i++ {
+		locked, err := tryFlock(file)
+		if err != nil {
+			_ = file.Close()
+			repoMutex.Unlock()
+			return nil, fmt.Errorf("failed to lock %s: %w", lockFile, err)
+		}
+		if locked {
+			// Record the holder for anyone inspecting the lock file
+			if err := file.Truncate(0); err == nil {
+				if _, err := fmt.Fprintf(file, "pid: %d\ntime: %s\n", os.Getpid(), time.Now().Format(time.RFC3339)); err != nil {
+					log.Error("failed to write to lock file", "lockFile", lockFile, "err", err)
+				}
+			}
+			lock := &GitLock{
+				lockFile: lockFile,
+				acquired: true,
+				mutex:    repoMutex,
+				flock:    file,
+			}
+			trackLock(lock)
+			return lock, nil
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+	_ = file.Close()
+	repoMutex.Unlock()
+	return nil, fmt.Errorf("failed to acquire lock %s after %d attempts: %w", lockFile, lockAttempts, ErrLockBusy)
+}
 
-	// Try to acquire file-based lock with timeout
-	const maxAttempts = 30
-	const lockTimeout = 100 * time.Millisecond
+// acquireFileLock acquires the in-process mutex for key and then the lock file at
+// lockFile, waiting for another process holding it and clearing stale locks.
+func acquireFileLock(key, lockFile string) (*GitLock, error) {
+	// Acquire the in-process mutex first
+	repoMutex := lockMutex(key)
+	repoMutex.Lock()
 
 	var lockFileHandle *os.File
-	for i := 0; i < maxAttempts; i++ {
+	var err error
+	for i := 0; i < lockAttempts; i++ {
 		lockFileHandle, err = os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
 		if err == nil {
 			break
@@ -79,7 +503,7 @@ func acquireGitLock(repoPath string) (*GitLock, error) {
 
 		if !os.IsExist(err) {
 			repoMutex.Unlock()
-			return nil, fmt.Errorf("failed to create lock file: %w", err)
+			return nil, fmt.Errorf("failed to create lock file %s: %w", lockFile, err)
 		}
 
 		// Check if existing lock file is stale (older than 5 minutes)
@@ -93,12 +517,12 @@ func acquireGitLock(repoPath string) (*GitLock, error) {
 			}
 		}
 
-		time.Sleep(lockTimeout)
+		time.Sleep(lockRetryInterval)
 	}
 
 	if lockFileHandle == nil {
 		repoMutex.Unlock()
-		return nil, fmt.Errorf("failed to acquire git lock after %d attempts: repository may be busy", maxAttempts)
+		return nil, fmt.Errorf("failed to acquire lock %s after %d attempts: %w", lockFile, lockAttempts, ErrLockBusy)
 	}
 
 	// Write process info to lock file
@@ -109,21 +533,65 @@ func acquireGitLock(repoPath string) (*GitLock, error) {
 		log.Error("failed to close lock file", "lockFile", lockFile, "err", err)
 	}
 
-	return &GitLock{
+	lock := &GitLock{
 		lockFile: lockFile,
 		acquired: true,
 		mutex:    repoMutex,
-	}, nil
+	}
+	trackLock(lock)
+	return lock, nil
+}
+
+// AppendLocked appends data to the file at path, creating it if needed. A lock file
+// next to it serializes writers, so concurrent bumps never interleave their lines.
+func AppendLocked(path string, data []byte) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	lock, err := acquireFileLock(absPath, absPath+".lock")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			log.Error("failed to release lock", "err", releaseErr)
+		}
+	}()
+
+	file, err := os.OpenFile(absPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
 }
 
-// Release releases the git lock, removing the lock file and releasing the mutex.
+// Release releases the git lock, removing the lock file (or dropping the flock)
+// and releasing the mutex.
+// Releasing a lock that is not held, or was already released by the interrupt
+// handler, does nothing.
 func (lock *GitLock) Release() error {
-	if !lock.acquired {
+	if !untrackLock(lock) {
 		return nil
 	}
 
-	// Remove lock file
-	if err := os.Remove(lock.lockFile); err != nil && !os.IsNotExist(err) {
+	if lock.flock != nil {
+		// Unlock but keep the file, which other processes may already have open
+		if err := unflock(lock.flock); err != nil {
+			log.Error("failed to unlock lock file", "lockFile", lock.lockFile, "err", err)
+		}
+		if err := lock.flock.Close(); err != nil {
+			log.Error("failed to close lock file", "lockFile", lock.lockFile, "err", err)
+		}
+		lock.flock = nil
+	} else if err := os.Remove(lock.lockFile); err != nil && !os.IsNotExist(err) {
 		log.Error("failed to remove lock file", "lockFile", lock.lockFile, "err", err)
 	}
 
@@ -140,6 +608,7 @@ type tagVersion struct {
 	Minor  int    // Minor is the minor version number
 	Patch  int    // Patch is the patch version number
 	Suffix string // Suffix is the optional pre-release suffix (e.g., "-alpha", "-beta.1")
+	Build  string // Build is the optional build metadata (e.g., "+build.42")
 	Tag    string // Tag is the original git tag string
 }
 
@@ -162,6 +631,177 @@ func NewGitInfo(path string) ([]string, error) {
 	return getVersions(tagRefs), nil
 }
 
+// SuggestNextTags returns the tags a patch, minor, and major bump of the latest
+// semantic version tag in the repository at repoPath would create, following the
+// repository's tag settings. A repository without version tags starts at v0.1.0
+// for every bump type, as bump does.
+// It is meant for shell completion, previews, and editor integrations.
+func SuggestNextTags(repoPath string) (patch, minor, major string, err error) {
+	scheme, err := ReadTagScheme(repoPath)
+	if err != nil {
+		return "", "", "", err
+	}
+	r, err := openGitRepo(repoPath)
+	if err != nil {
+		return "", "", "", err
+	}
+	tagRefs, err := getTags(r)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer tagRefs.Close()
+
+	latestTag, err := scheme.GetLatestTag(tagRefs)
+	if err != nil {
+		return "", "", "", err
+	}
+	if latestTag == "" {
+		first := scheme.Prefix() + "0.1.0"
+		return first, first, first, nil
+	}
+
+	var suggestions [3]string
+	for i, bumpType := range []string{"patch", "minor", "major"} {
+		if suggestions[i], err = scheme.GetNextTag(latestTag, bumpType, ""); err != nil {
+			return "", "", "", err
+		}
+	}
+	return suggestions[0], suggestions[1], suggestions[2], nil
+}
+
+// PeelTagRef returns the hash of the commit a tag reference points at. A
+// lightweight tag references the commit directly; an annotated tag references a
+// tag object, which is followed (through any tags of tags) to its commit.
+func PeelTagRef(r *git.Repository, ref *plumbing.Reference) (plumbing.Hash, error) {
+	hash := ref.Hash()
+	for {
+		tagObj, err := r.TagObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			break // not a tag object, so hash names the target itself
+		}
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to read tag object %s: %w", hash, err)
+		}
+		hash = tagObj.Target
+	}
+
+	if _, err := r.CommitObject(hash); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("tag %s does not point at a commit: %w", ref.Name().Short(), err)
+	}
+	return hash, nil
+}
+
+// CommitsBetween returns the commits reachable from toRef but not from fromRef,
+// like "git log fromRef..toRef", in topological order: every commit is listed
+// before its parents, newest first. Either ref may be a tag, a branch, HEAD, or a
+// commit hash. An empty fromRef returns the whole history of toRef.
+func CommitsBetween(r *git.Repository, fromRef, toRef string) ([]*object.Commit, error) {
+	excluded := make(map[plumbing.Hash]bool)
+	if fromRef != "" {
+		from, err := r.ResolveRevision(plumbing.Revision(fromRef))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", fromRef, err)
+		}
+		iter, err := r.Log(&git.LogOptions{From: *from})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history of %s: %w", fromRef, err)
+		}
+		if err := iter.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to read history of %s: %w", fromRef, err)
+		}
+	}
+
+	to, err := r.ResolveRevision(plumbing.Revision(toRef))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", toRef, err)
+	}
+	iter, err := r.Log(&git.LogOptions{From: *to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", toRef, err)
+	}
+	inRange := make(map[plumbing.Hash]*object.Commit)
+	if err := iter.ForEach(func(c *object.Commit) error {
+		if !excluded[c.Hash] {
+			inRange[c.Hash] = c
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", toRef, err)
+	}
+	if len(inRange) == 0 {
+		return nil, nil
+	}
+
+	// Count the children of each commit within the range; a commit is listed once
+	// all of them have been, walking first parents first to keep branches together.
+	children := make(map[plumbing.Hash]int)
+	for _, c := range inRange {
+		for _, parent := range c.ParentHashes {
+			if inRange[parent] != nil {
+				children[parent]++
+			}
+		}
+	}
+	commits := make([]*object.Commit, 0, len(inRange))
+	stack := []*object.Commit{inRange[*to]}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		commits = append(commits, c)
+		for i := len(c.ParentHashes) - 1; i >= 0; i-- {
+			parent := inRange[c.ParentHashes[i]]
+			if parent == nil {
+				continue
+			}
+			children[parent.Hash]--
+			if children[parent.Hash] == 0 {
+				stack = append(stack, parent)
+			}
+		}
+	}
+	return commits, nil
+}
+
+// Repo is an opened git repository together with the root of its working tree.
+type Repo struct {
+	path       string
+	repository *git.Repository
+}
+
+// OpenRepo finds the root of the git repository containing path, validates it, and
+// opens it with go-git. path may be the root itself or any directory inside it.
+func OpenRepo(path string) (*Repo, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	root, err := findGitRepoRoot(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryPath(root); err != nil {
+		return nil, err
+	}
+	r, err := openGitRepo(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	return &Repo{path: root, repository: r}, nil
+}
+
+// Path returns the absolute path of the repository's working tree root.
+func (r *Repo) Path() string {
+	return r.path
+}
+
+// Repository returns the go-git handle for the repository.
+func (r *Repo) Repository() *git.Repository {
+	return r.repository
+}
+
 // openGitRepo opens a git repository at the given path.
 func openGitRepo(path string) (*git.Repository, error) {
 	r, err := git.PlainOpen(path)
@@ -197,9 +837,17 @@ func getVersions(tagRefs storer.ReferenceIter) []string {
 	return versions
 }
 
-// ParseTagVersion parses a git tag into a semantic version.
+// ParseTagVersion parses a git tag into a semantic version under the default
+// TagScheme.
 func ParseTagVersion(tag string) (*tagVersion, bool) {
-	matches := semanticVersionRegex.FindStringSubmatch(tag)
+	return TagScheme{}.ParseTagVersion(tag)
+}
+
+// ParseTagVersion parses a git tag into a semantic version. Surrounding whitespace,
+// as in a tag pasted from a web UI, is ignored; whitespace inside the tag is not.
+func (s TagScheme) ParseTagVersion(tag string) (*tagVersion, bool) {
+	tag = strings.TrimSpace(tag)
+	matches := s.versionPattern().FindStringSubmatch(tag)
 	if matches == nil {
 		return nil, false
 	}
@@ -208,46 +856,241 @@ func ParseTagVersion(tag string) (*tagVersion, bool) {
 		Minor:  parseInt(matches[2]),
 		Patch:  parseInt(matches[3]),
 		Suffix: matches[4],
+		Build:  matches[5],
 		Tag:    tag,
 	}, true
 }
 
-// sortVersions sorts a slice of semantic versions in descending order.
-func sortVersions(versions []*tagVersion) {
-	sort.Slice(versions, func(i, j int) bool {
-		return compareVersions(versions[i], versions[j])
-	})
+// ParseLenientTagVersion parses a git tag that may use a non-canonical form, such as
+// a missing "v" or a "release-" prefix. The returned version keeps the original tag.
+func ParseLenientTagVersion(tag string) (*tagVersion, bool) {
+	matches := lenientVersionRegex.FindStringSubmatch(tag)
+	if matches == nil {
+		return nil, false
+	}
+	return &tagVersion{
+		Major:  parseInt(matches[1]),
+		Minor:  parseInt(matches[2]),
+		Patch:  parseInt(matches[3]),
+		Suffix: matches[4],
+		Build:  matches[5],
+		Tag:    tag,
+	}, true
 }
 
-// compareVersions compares two semantic versions.
-func compareVersions(version1, version2 *tagVersion) bool {
-	if version1.Major != version2.Major {
-		return version1.Major > version2.Major
-	}
-	if version1.Minor != version2.Minor {
-		return version1.Minor > version2.Minor
-	}
-	if version1.Patch != version2.Patch {
-		return version1.Patch > version2.Patch
+// CanonicalTagName returns the canonical "vMAJOR.MINOR.PATCH[-suffix]" name for a tag
+// accepted by ParseLenientTagVersion.
+func CanonicalTagName(tag string) (string, bool) {
+	version, ok := ParseLenientTagVersion(tag)
+	if !ok {
+		return "", false
 	}
-	return compareSuffixes(version1.Suffix, version2.Suffix)
+	return fmt.Sprintf("v%d.%d.%d%s%s", version.Major, version.Minor, version.Patch, version.Suffix, version.Build), true
 }
 
-// compareSuffixes compares two suffixes in semantic versions according to SemVer 2.0 spec.
-// Returns true if suffix1 > suffix2 (for descending sort order).
-func compareSuffixes(suffix1, suffix2 string) bool {
-	// Per SemVer 2.0: stable version (no suffix) > any pre-release version
-	if suffix1 == "" && suffix2 != "" {
-		return true
+// versionLikeRegex matches tags that look like an attempt at a version, with an
+// optional "v" followed by a number and a dot, separator, or nothing.
+var versionLikeRegex = regexp.MustCompile(`^[vV]?\d+(?:$|[-_.+])`)
+
+// TagLint describes the ways a tag that looks like a version violates strict SemVer.
+type TagLint struct {
+	Tag      string   // Tag name as found in the repository
+	Problems []string // Each violation, e.g. "leading zero in minor version \"02\""
+}
+
+// LintTagNames checks every tag that looks like a version against strict SemVer
+// and returns those with problems, sorted by name. Tags that do not look like
+// versions, such as "nightly", are not reported.
+func LintTagNames(names []string) []TagLint {
+	seen := make(map[string]bool)
+	var lints []TagLint
+	for _, name := range names {
+		name = strings.TrimSuffix(name, peeledRefSuffix)
+		if seen[name] || !versionLikeRegex.MatchString(name) {
+			continue
+		}
+		seen[name] = true
+		if problems := StrictTagProblems(name); len(problems) > 0 {
+			lints = append(lints, TagLint{Tag: name, Problems: problems})
+		}
+	}
+	sort.Slice(lints, func(i, j int) bool { return lints[i].Tag < lints[j].Tag })
+	return lints
+}
+
+// StrictTagProblems is a stricter variant of ParseTagVersion: it checks a tag
+// against the SemVer 2.0 grammar with bump's "v" prefix and returns every
+// violation found, or nil for a valid tag. Unlike ParseTagVersion it rejects
+// leading zeros and any pre-release separator other than "-".
+func StrictTagProblems(tag string) []string {
+	var problems []string
+	rest, hasPrefix := strings.CutPrefix(tag, "v")
+	if !hasPrefix {
+		if upper, ok := strings.CutPrefix(tag, "V"); ok {
+			rest = upper
+			problems = append(problems, `uppercase "V" prefix`)
+		} else {
+			problems = append(problems, `missing "v" prefix`)
+		}
+	}
+
+	rest, build, hasBuild := strings.Cut(rest, "+")
+	core, prerelease := rest, ""
+	end := strings.IndexFunc(rest, func(r rune) bool { return r != '.' && (r < '0' || r > '9') })
+	switch {
+	case end < 0:
+	case rest[end] == '-':
+		core, prerelease = rest[:end], rest[end+1:]
+	case rest[end] == '_':
+		core, prerelease = rest[:end], rest[end+1:]
+		problems = append(problems, `pre-release separator "_" is not "-"`)
+	case end > 0 && rest[end-1] == '.':
+		core, prerelease = rest[:end-1], rest[end:]
+		problems = append(problems, `pre-release separator "." is not "-"`)
+	default:
+		core, prerelease = rest[:end], rest[end:]
+		problems = append(problems, `missing "-" before pre-release`)
+	}
+
+	components := strings.Split(core, ".")
+	switch {
+	case len(components) < 3:
+		problems = append(problems, fmt.Sprintf("missing components: %q is not MAJOR.MINOR.PATCH", core))
+	case len(components) > 3:
+		problems = append(problems, fmt.Sprintf("too many components: %q is not MAJOR.MINOR.PATCH", core))
+	}
+	for i, component := range components {
+		if i > 2 {
+			break
+		}
+		name := [...]string{"major", "minor", "patch"}[i]
+		if component == "" {
+			problems = append(problems, fmt.Sprintf("empty %s version", name))
+		} else if len(component) > 1 && component[0] == '0' {
+			problems = append(problems, fmt.Sprintf("leading zero in %s version %q", name, component))
+		}
+	}
+
+	if end >= 0 {
+		problems = append(problems, identifierProblems("pre-release", prerelease, true)...)
+	}
+	if hasBuild {
+		problems = append(problems, identifierProblems("build metadata", build, false)...)
+	}
+	return problems
+}
+
+// identifierProblems checks dot-separated SemVer identifiers: each must be a
+// non-empty run of ASCII letters, digits, and dashes, and with numericNoZero,
+// numeric identifiers must not have leading zeros, as SemVer requires of pre-releases.
+func identifierProblems(kind, ids string, numericNoZero bool) []string {
+	var problems []string
+	for _, id := range strings.Split(ids, ".") {
+		switch {
+		case id == "":
+			problems = append(problems, fmt.Sprintf("empty %s identifier in %q", kind, ids))
+		case strings.IndexFunc(id, func(r rune) bool {
+			return r != '-' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+		}) >= 0:
+			problems = append(problems, fmt.Sprintf("invalid character in %s identifier %q", kind, id))
+		case numericNoZero && len(id) > 1 && id[0] == '0' && strings.Trim(id, "0123456789") == "":
+			problems = append(problems, fmt.Sprintf("leading zero in %s identifier %q", kind, id))
+		}
+	}
+	return problems
+}
+
+// TagGreater reports whether tag sorts strictly after other under the default
+// TagScheme.
+func TagGreater(tag, other string) (bool, error) {
+	return TagScheme{}.TagGreater(tag, other)
+}
+
+// TagGreater reports whether tag sorts strictly after other in bump's version
+// order: SemVer precedence, with build metadata as a tie-breaker. Both must be
+// semantic version tags.
+func (s TagScheme) TagGreater(tag, other string) (bool, error) {
+	version, ok := s.ParseTagVersion(tag)
+	if !ok {
+		return false, fmt.Errorf("%s is not a semantic version tag", tag)
+	}
+	otherVersion, ok := s.ParseTagVersion(other)
+	if !ok {
+		return false, fmt.Errorf("%s is not a semantic version tag", other)
+	}
+	return compareVersions(version, otherVersion, s.channelRanks), nil
+}
+
+// sortVersions sorts a slice of semantic versions in descending order, ranking
+// pre-release channels by ranks.
+func sortVersions(versions []*tagVersion, ranks map[string]int) {
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j], ranks)
+	})
+}
+
+// compareVersions compares two semantic versions, ranking pre-release channels by
+// ranks.
+func compareVersions(version1, version2 *tagVersion, ranks map[string]int) bool {
+	if version1.Major != version2.Major {
+		return version1.Major > version2.Major
+	}
+	if version1.Minor != version2.Minor {
+		return version1.Minor > version2.Minor
+	}
+	if version1.Patch != version2.Patch {
+		return version1.Patch > version2.Patch
+	}
+	if version1.Suffix != version2.Suffix {
+		return compareSuffixes(version1.Suffix, version2.Suffix, ranks)
+	}
+	return compareBuilds(version1.Build, version2.Build)
+}
+
+// compareBuilds orders otherwise equal versions by build metadata, so the most
+// recent re-tag of a release (+build.43 over +build.42) is treated as the latest.
+// SemVer gives build metadata no precedence; this is only a tie-breaker.
+// Returns true if build1 > build2 (for descending sort order).
+func compareBuilds(build1, build2 string) bool {
+	if build1 == build2 || build1 == "" {
+		return false
+	}
+	if build2 == "" {
+		return true
+	}
+	return compareIdentifiers(strings.Split(strings.TrimPrefix(build1, "+"), "."), strings.Split(strings.TrimPrefix(build2, "+"), "."), nil)
+}
+
+// compareSuffixes compares two suffixes in semantic versions according to SemVer 2.0 spec,
+// except that channels listed in ranks are ordered by rank (see compareIdentifiers).
+// Returns true if suffix1 > suffix2 (for descending sort order).
+func compareSuffixes(suffix1, suffix2 string, ranks map[string]int) bool {
+	// Per SemVer 2.0: stable version (no suffix) > any pre-release version
+	if suffix1 == "" && suffix2 != "" {
+		return true
 	}
 	if suffix1 != "" && suffix2 == "" {
 		return false
 	}
 
 	// Both have suffixes - compare according to SemVer 2.0 rules
-	// Strip leading dashes and split by dots
-	ids1 := strings.Split(strings.TrimPrefix(suffix1, "-"), ".")
-	ids2 := strings.Split(strings.TrimPrefix(suffix2, "-"), ".")
+	// Strip the leading separators and split by dots
+	ids1 := strings.Split(suffixIdentifiers(suffix1), ".")
+	ids2 := strings.Split(suffixIdentifiers(suffix2), ".")
+	return compareIdentifiers(ids1, ids2, ranks)
+}
+
+// compareIdentifiers compares dot-separated identifiers according to SemVer 2.0,
+// except that a first identifier listed in ranks is ordered by its rank when the
+// other first identifier is listed too.
+// Returns true if ids1 > ids2 (for descending sort order).
+func compareIdentifiers(ids1, ids2 []string, ranks map[string]int) bool {
+	// A configured channel order takes precedence over lexical order
+	rank1, ranked1 := ranks[ids1[0]]
+	rank2, ranked2 := ranks[ids2[0]]
+	if ranked1 && ranked2 && rank1 != rank2 {
+		return rank1 > rank2
+	}
 
 	// Compare identifiers left to right
 	for i := 0; i < len(ids1) && i < len(ids2); i++ {
@@ -304,55 +1147,450 @@ func parseNumericIdentifier(id string) (int, bool) {
 	return num, true
 }
 
-// GetLatestTag returns the latest semantic version tag in the given git tags.
+// GetLatestTag returns the latest semantic version tag in the given git tags under
+// the default TagScheme.
 func GetLatestTag(tagRefs storer.ReferenceIter) (string, error) {
-	versions, err := getTagVersions(tagRefs)
+	return TagScheme{}.GetLatestTag(tagRefs)
+}
+
+// GetLatestTag returns the latest semantic version tag in the given git tags.
+// Only the newest version seen so far is kept while iterating, so repositories
+// with tens of thousands of tags are not parsed into a list and sorted just to
+// find its first entry. A tag listed more than once, as a peeled reference is, is
+// counted once.
+func (s TagScheme) GetLatestTag(tagRefs storer.ReferenceIter) (string, error) {
+	scanner := s.NewTagScanner(nil)
+	err := tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		scanner.Add(ref.Name().Short())
+		return nil
+	})
+	scanner.Done()
 	if err != nil {
 		return "", err
 	}
 
-	sortVersions(versions)
+	latest := scanner.Latest()
+	if latest == "" {
+		log.Debug("No semantic version tags found")
+	}
+	return latest, nil
+}
+
+// TagScanner finds the latest semantic version among tag names added one at a
+// time. Only the newest version seen so far is kept, so tags can be scanned as
+// they are read instead of being collected and sorted. Each tag is counted once,
+// however often and in whatever order it is added, as with ScanTagNames.
+type TagScanner struct {
+	scheme   TagScheme             // scheme decides which tags are versions and how they sort
+	exclude  func(tag string) bool // exclude reports versions left out of LatestIncluded
+	scan     TagScan               // scan counts the names added so far
+	seen     map[string]bool       // seen holds the tags added so far, for skipping duplicates
+	latest   *tagVersion           // latest is the newest version added
+	included *tagVersion           // included is the newest version exclude did not reject
+}
+
+// NewTagScanner returns an empty TagScanner using the default TagScheme.
+func NewTagScanner(exclude func(tag string) bool) *TagScanner {
+	return TagScheme{}.NewTagScanner(exclude)
+}
+
+// NewTagScanner returns an empty TagScanner. When exclude is set, the scanner also
+// tracks the latest version among the tags exclude returns false for.
+func (s TagScheme) NewTagScanner(exclude func(tag string) bool) *TagScanner {
+	return &TagScanner{scheme: s, exclude: exclude, seen: make(map[string]bool)}
+}
+
+// Add classifies one tag name and keeps it if it is the newest version so far.
+func (s *TagScanner) Add(name string) {
+	s.scan.Scanned++
+	tag := strings.TrimSuffix(name, peeledRefSuffix)
+	if s.seen[tag] {
+		log.Debug("skipping duplicate tag", "tag", tag)
+		return
+	}
+	s.seen[tag] = true
+	version, ok := s.scheme.classifyTag(tag, &s.scan)
+	if !ok {
+		return
+	}
+	if s.latest == nil || compareVersions(version, s.latest, s.scheme.channelRanks) {
+		s.latest = version
+	}
+	if s.exclude != nil && s.exclude(tag) {
+		return
+	}
+	if s.included == nil || compareVersions(version, s.included, s.scheme.channelRanks) {
+		s.included = version
+	}
+}
+
+// Latest returns the latest version added, or an empty string if there is none.
+func (s *TagScanner) Latest() string {
+	if s.latest == nil {
+		return ""
+	}
+	return s.latest.Tag
+}
+
+// LatestIncluded returns the latest version added that the exclude function did
+// not reject, or an empty string if there is none. Without an exclude function it
+// is the same as Latest.
+func (s *TagScanner) LatestIncluded() string {
+	if s.included == nil {
+		return ""
+	}
+	return s.included.Tag
+}
+
+// Done sorts the skipped tags, logs the counts at debug level, and returns them.
+// Call it after the last Add.
+func (s *TagScanner) Done() TagScan {
+	sort.Strings(s.scan.Skipped)
+	logTagScan(s.scan)
+	return s.scan
+}
 
-	if len(versions) > 0 {
-		return versions[0].Tag, nil
+// logTagScan logs the counts of a tag scan at debug level, naming a few of the
+// skipped tags so a prefix mismatch is easy to spot.
+func logTagScan(scan TagScan) {
+	skipped := scan.Skipped
+	if len(skipped) > 5 {
+		skipped = skipped[:5]
 	}
+	log.Debug("scanned tags", "scanned", scan.Scanned, "versions", scan.Versions, "skipped", len(scan.Skipped), "examples", skipped)
+}
+
+// TagScan counts the tag references examined when looking for release versions,
+// so tags that are silently ignored (for example because of a prefix mismatch)
+// can be noticed.
+type TagScan struct {
+	Scanned  int      // References examined, including peeled "^{}" duplicates
+	Versions int      // Distinct tags recognized as release versions
+	Skipped  []string // Distinct tags ignored as not semantic versions or not matching the tag pattern, sorted
+}
+
+// ScanTagNames reports how the given tag names were classified under the default
+// TagScheme.
+func ScanTagNames(names []string) TagScan {
+	return TagScheme{}.ScanTagNames(names)
+}
+
+// ScanTagNames reports how the given tag names were classified when looking for
+// release versions.
+func (s TagScheme) ScanTagNames(names []string) TagScan {
+	_, scan := s.scanTagNames(names)
+	return scan
+}
 
-	log.Debug("No semantic version tags found")
-	return "", nil
+// parseTagNames parses tag names into semantic versions, skipping names that are
+// not semantic versions or do not match the tag pattern, and counting each tag once.
+func (s TagScheme) parseTagNames(names []string) []*tagVersion {
+	versions, _ := s.scanTagNames(names)
+	return versions
 }
 
-// getTagVersions returns the semantic versions of the given git tags.
-func getTagVersions(tagRefs storer.ReferenceIter) ([]*tagVersion, error) {
+// scanTagNames parses tag names into semantic versions like parseTagNames and
+// counts what was examined and skipped.
+func (s TagScheme) scanTagNames(names []string) ([]*tagVersion, TagScan) {
 	var versions []*tagVersion
-	err := tagRefs.ForEach(func(ref *plumbing.Reference) error {
-		tag := ref.Name().Short()
-		if version, ok := ParseTagVersion(tag); ok {
+	scan := TagScan{Scanned: len(names)}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tag := strings.TrimSuffix(name, peeledRefSuffix)
+		if seen[tag] {
+			log.Debug("skipping duplicate tag", "tag", tag)
+			continue
+		}
+		seen[tag] = true
+		if version, ok := s.classifyTag(tag, &scan); ok {
 			versions = append(versions, version)
 		}
-		return nil
-	})
-	return versions, err
+	}
+	sort.Strings(scan.Skipped)
+	return versions, scan
 }
 
-// GetNextTag returns the next semantic version tag based on the given current tag and bump type.
+// classifyTag parses tag as a release version and records it in scan, either as
+// a version or as skipped because it does not match the tag pattern or is not a
+// semantic version.
+func (s TagScheme) classifyTag(tag string, scan *TagScan) (*tagVersion, bool) {
+	if s.pattern != nil && !s.pattern.MatchString(tag) {
+		log.Debug("skipping tag not matching tag pattern", "tag", tag, "pattern", s.pattern)
+		scan.Skipped = append(scan.Skipped, tag)
+		return nil, false
+	}
+	version, ok := s.ParseTagVersion(tag)
+	if !ok {
+		log.Debug("skipping tag that is not a semantic version", "tag", tag)
+		scan.Skipped = append(scan.Skipped, tag)
+		return nil, false
+	}
+	scan.Versions++
+	return version, true
+}
+
+// LatestTagName returns the latest semantic version among the given tag names
+// under the default TagScheme.
+func LatestTagName(names []string) string {
+	return TagScheme{}.LatestTagName(names)
+}
+
+// LatestTagName returns the latest semantic version among the given tag names,
+// or an empty string if none of them is a semantic version.
+func (s TagScheme) LatestTagName(names []string) string {
+	scanner := s.NewTagScanner(nil)
+	for _, name := range names {
+		scanner.Add(name)
+	}
+	scanner.Done()
+	return scanner.Latest()
+}
+
+// SortTagNames returns the semantic version tags among names, newest first, under
+// the default TagScheme.
+func SortTagNames(names []string) []string {
+	return TagScheme{}.SortTagNames(names)
+}
+
+// SortTagNames returns the semantic version tags among names, newest first.
+// Names that are not semantic versions are dropped and duplicates are listed once.
+func (s TagScheme) SortTagNames(names []string) []string {
+	versions := s.parseTagNames(names)
+	sortVersions(versions, s.channelRanks)
+	sorted := make([]string, len(versions))
+	for i, version := range versions {
+		sorted[i] = version.Tag
+	}
+	return sorted
+}
+
+// ReleaseLine is a major.minor release line and the newest tag in it.
+type ReleaseLine struct {
+	Major  int    // Major version of the line
+	Minor  int    // Minor version of the line
+	Latest string // Newest tag in the line
+}
+
+// LatestPerReleaseLine groups the semantic version tags among names by major.minor
+// under the default TagScheme.
+func LatestPerReleaseLine(names []string) []ReleaseLine {
+	return TagScheme{}.LatestPerReleaseLine(names)
+}
+
+// LatestPerReleaseLine groups the semantic version tags among names by major.minor
+// and returns the newest tag of each line, newest line first.
+func (s TagScheme) LatestPerReleaseLine(names []string) []ReleaseLine {
+	type lineKey struct{ major, minor int }
+	newest := make(map[lineKey]*tagVersion)
+	for _, version := range s.parseTagNames(names) {
+		key := lineKey{version.Major, version.Minor}
+		if current, ok := newest[key]; !ok || compareVersions(version, current, s.channelRanks) {
+			newest[key] = version
+		}
+	}
+
+	versions := make([]*tagVersion, 0, len(newest))
+	for _, version := range newest {
+		versions = append(versions, version)
+	}
+	sortVersions(versions, s.channelRanks)
+
+	lines := make([]ReleaseLine, len(versions))
+	for i, version := range versions {
+		lines[i] = ReleaseLine{Major: version.Major, Minor: version.Minor, Latest: version.Tag}
+	}
+	return lines
+}
+
+// ListRemoteTags returns the names of the tags published on the given remote of the
+// repository at repoPath, as reported by git ls-remote.
+func ListRemoteTags(repoPath, remote string) ([]string, error) {
+	cmdLsRemote := execCommand("git", "ls-remote", "--tags", remote)
+	cmdLsRemote.Dir = repoPath
+	output, err := runGitCommand(cmdLsRemote)
+	if err != nil {
+		log.Error("failed to list remote tags", "remote", remote, "err", err)
+		return nil, fmt.Errorf("failed to list remote tags: %w", err)
+	}
+	return parseLsRemoteTags(output), nil
+}
+
+// FetchTagsInRepo fetches the tags published on the given remote into the
+// repository at repoPath. Local tags that already exist are left as they are.
+// Uses concurrency protection to prevent concurrent git operations.
+func FetchTagsInRepo(repoPath, remote string) error {
+	lock, err := acquireGitLock(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to acquire git lock: %w", err)
+	}
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			log.Error("failed to release git lock", "err", releaseErr)
+		}
+	}()
+
+	cmdFetch := execCommand("git", "fetch", "--tags", remote)
+	cmdFetch.Dir = repoPath
+	if _, err := runGitCommand(cmdFetch); err != nil {
+		log.Error("failed to fetch tags", "remote", remote, "err", err)
+		return fmt.Errorf("failed to fetch tags from %s: %w", remote, err)
+	}
+	return nil
+}
+
+// parseLsRemoteTags extracts tag names from git ls-remote output. Peeled "^{}"
+// entries for annotated tags are folded into the tag they belong to.
+func parseLsRemoteTags(output string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "refs/tags/") {
+			continue
+		}
+		tag := strings.TrimSuffix(strings.TrimPrefix(fields[1], "refs/tags/"), peeledRefSuffix)
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// NextTagOptions controls how GetNextTagWithOptions computes the next tag.
+type NextTagOptions struct {
+	Suffix              string // Suffix is the pre-release suffix for the new tag, without the leading dash
+	PreserveSuffix      bool   // PreserveSuffix keeps the current tag's suffix when Suffix is empty
+	Channel             string // Channel is a pre-release channel such as "alpha"; the suffix becomes "<channel>.1"
+	IncrementPrerelease bool   // IncrementPrerelease advances a pre-release tag's counter instead of bumping the core version
+	IncrementBuild      bool   // IncrementBuild advances the build metadata counter, keeping the version and suffix
+}
+
+// GetNextTag returns the next semantic version tag based on the given current tag
+// and bump type, under the default TagScheme.
 func GetNextTag(currentTag, bumpType, suffix string) (string, error) {
-	version, ok := ParseTagVersion(currentTag)
+	return TagScheme{}.GetNextTag(currentTag, bumpType, suffix)
+}
+
+// GetNextTag returns the next semantic version tag based on the given current tag and bump type.
+func (s TagScheme) GetNextTag(currentTag, bumpType, suffix string) (string, error) {
+	return s.GetNextTagWithOptions(currentTag, bumpType, NextTagOptions{Suffix: suffix})
+}
+
+// GetNextTagWithOptions returns the next semantic version tag under the default
+// TagScheme.
+func GetNextTagWithOptions(currentTag, bumpType string, opts NextTagOptions) (string, error) {
+	return TagScheme{}.GetNextTagWithOptions(currentTag, bumpType, opts)
+}
+
+// GetNextTagWithOptions returns the next semantic version tag based on the given current tag,
+// bump type, and options.
+//
+// By default the suffix is reset on every bump, so v1.0.0-rc.1 patch becomes v1.0.1.
+// With PreserveSuffix the existing suffix is carried over unchanged (v1.0.1-rc.1);
+// the suffix identifiers themselves are never incremented.
+//
+// A Channel starts a pre-release series on the bumped version (v1.0.0 minor with
+// channel "beta" becomes v1.1.0-beta.1). With IncrementPrerelease and a pre-release
+// current tag, the core version is kept and the pre-release advances instead:
+// within the same channel the counter is incremented (-alpha.1 to -alpha.2), and a
+// different channel starts at 1 (-alpha.3 to -beta.1) provided that does not lower
+// the SemVer precedence.
+//
+// The "prerelease" bump type is IncrementPrerelease with the suffix naming the
+// channel. A release version has no counter to advance, so a series must be named,
+// and it starts on the next patch version (v1.2.0 with "rc" becomes v1.2.1-rc.1)
+// because a pre-release of v1.2.0 would sort before v1.2.0 itself.
+// IncrementBuild only advances the build metadata; see nextBuildTag. Other bumps
+// drop any build metadata. New tags follow the scheme's prefix and separator.
+func (s TagScheme) GetNextTagWithOptions(currentTag, bumpType string, opts NextTagOptions) (string, error) {
+	version, ok := s.ParseTagVersion(currentTag)
 	if !ok {
 		log.Error("invalid current tag", "currentTag", currentTag)
 		return "", fmt.Errorf("invalid current tag format: %s", currentTag)
 	}
 
-	err := updateVersion(version, bumpType, suffix)
+	if bumpType == "prerelease" {
+		if opts.Channel == "" {
+			opts.Channel = opts.Suffix
+		}
+		if version.Suffix == "" && opts.Channel == "" {
+			return "", fmt.Errorf("%s%d.%d.%d has no pre-release suffix to increment; give a suffix to start a series", s.Prefix(), version.Major, version.Minor, version.Patch)
+		}
+		bumpType, opts.Suffix, opts.IncrementPrerelease = "patch", "", true
+	}
+
+	if opts.IncrementBuild {
+		return s.nextBuildTag(version), nil
+	}
+
+	if opts.IncrementPrerelease && version.Suffix != "" {
+		current := suffixIdentifiers(version.Suffix)
+		suffix := incrementPrereleaseSuffix(current)
+		if opts.Channel != "" {
+			var err error
+			if suffix, err = s.nextChannelSuffix(current, opts.Channel); err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("%s%d.%d.%d%s%s", s.Prefix(), version.Major, version.Minor, version.Patch, s.SuffixSeparator(), suffix), nil
+	}
+
+	suffix := opts.Suffix
+	if suffix == "" && opts.Channel != "" {
+		suffix = opts.Channel + ".1"
+	}
+	if suffix == "" && opts.PreserveSuffix {
+		suffix = suffixIdentifiers(version.Suffix)
+	}
+
+	err := s.updateVersion(version, bumpType, suffix)
 	if err != nil {
 		return "", err
 	}
 
-	nextTag := fmt.Sprintf("v%d.%d.%d%s", version.Major, version.Minor, version.Patch, version.Suffix)
+	nextTag := fmt.Sprintf("%s%d.%d.%d%s", s.Prefix(), version.Major, version.Minor, version.Patch, version.Suffix)
 	return nextTag, nil
 }
 
+// nextBuildTag re-tags version with its build metadata counter advanced, keeping
+// the core version and pre-release suffix (+build.42 becomes +build.43). A tag
+// without build metadata starts at +build.1.
+func (s TagScheme) nextBuildTag(version *tagVersion) string {
+	build := "build.1"
+	if current := strings.TrimPrefix(version.Build, "+"); current != "" {
+		build = incrementPrereleaseSuffix(current)
+	}
+	return fmt.Sprintf("%s%d.%d.%d%s+%s", s.Prefix(), version.Major, version.Minor, version.Patch, version.Suffix, build)
+}
+
+// incrementPrereleaseSuffix increments the trailing numeric identifier of a
+// pre-release suffix, appending ".1" when the suffix does not end in a number.
+func incrementPrereleaseSuffix(suffix string) string {
+	ids := strings.Split(suffix, ".")
+	if num, ok := parseNumericIdentifier(ids[len(ids)-1]); ok {
+		ids[len(ids)-1] = strconv.Itoa(num + 1)
+		return strings.Join(ids, ".")
+	}
+	return suffix + ".1"
+}
+
+// nextChannelSuffix returns the suffix that follows current in the given channel.
+// Staying in the same channel increments the counter; switching channels starts
+// at 1 and is rejected if the result would sort before the current suffix.
+func (s TagScheme) nextChannelSuffix(current, channel string) (string, error) {
+	if strings.Split(current, ".")[0] == channel {
+		return incrementPrereleaseSuffix(current), nil
+	}
+	next := channel + ".1"
+	if !compareSuffixes("-"+next, "-"+current, s.channelRanks) {
+		return "", fmt.Errorf("cannot move pre-release -%s back to the %s channel", current, channel)
+	}
+	return next, nil
+}
+
 // updateVersion updates a semantic version based on the given bump type and suffix.
-func updateVersion(version *tagVersion, bumpType, suffix string) error {
+func (s TagScheme) updateVersion(version *tagVersion, bumpType, suffix string) error {
 	switch bumpType {
 	case "major":
 		version.Major++
@@ -369,7 +1607,7 @@ func updateVersion(version *tagVersion, bumpType, suffix string) error {
 	}
 
 	if suffix != "" {
-		version.Suffix = "-" + suffix
+		version.Suffix = s.SuffixSeparator() + suffix
 	} else {
 		version.Suffix = ""
 	}
@@ -386,15 +1624,41 @@ func parseInt(s string) int {
 	return i
 }
 
+// TagOptions controls how a new git tag is created.
+type TagOptions struct {
+	Message     string // Message is the tag annotation; the tag name is used when empty
+	Target      string // Target is the commit-ish to tag; HEAD is used when empty
+	Sign        bool   // Sign creates a signed tag using the format configured in gpg.format
+	Lightweight bool   // Lightweight creates a plain ref to the commit instead of a tag object
+	Force       bool   // Force replaces an existing tag of the same name (git tag -f)
+
+	// Aliases are moving tags such as "v1" or "latest" that CreateTagInRepo points
+	// at the same commit, replacing any existing tags of those names.
+	Aliases []string
+}
+
 // CreateTag creates a new git tag with the given tag.
 // Uses concurrency protection to prevent concurrent git operations.
 func CreateTag(tag string) error {
+	return CreateTagWithOptions(tag, TagOptions{})
+}
+
+// CreateTagWithOptions creates a new git tag using the given options. The tag is
+// annotated unless opts.Lightweight is set.
+// Uses concurrency protection to prevent concurrent git operations.
+func CreateTagWithOptions(tag string, opts TagOptions) error {
 	repoPath, err := findGitRepoRoot(".")
 	if err != nil {
 		return fmt.Errorf("failed to find git repository: %w", err)
 	}
 
-	return createTagWithLock(repoPath, tag)
+	return createTagWithLock(repoPath, tag, opts)
+}
+
+// CreateTagInRepo creates a new annotated git tag in the repository at repoPath.
+// Uses concurrency protection to prevent concurrent git operations.
+func CreateTagInRepo(repoPath, tag string, opts TagOptions) error {
+	return createTagWithLock(repoPath, tag, opts)
 }
 
 // PushTag pushes the latest git tag to the remote repository.
@@ -405,11 +1669,18 @@ func PushTag() error {
 		return fmt.Errorf("failed to find git repository: %w", err)
 	}
 
-	return pushTagWithLock(repoPath)
+	return pushTagWithLock(repoPath, "")
+}
+
+// PushTagInRepo pushes tags from the repository at repoPath to the given remote,
+// or to git's default remote when remote is empty.
+// Uses concurrency protection to prevent concurrent git operations.
+func PushTagInRepo(repoPath, remote string) error {
+	return pushTagWithLock(repoPath, remote)
 }
 
 // createTagWithLock creates a new git tag with the given tag using git operation locking.
-func createTagWithLock(repoPath, tag string) error {
+func createTagWithLock(repoPath, tag string, opts TagOptions) error {
 	lock, err := acquireGitLock(repoPath)
 	if err != nil {
 		return fmt.Errorf("failed to acquire git lock: %w", err)
@@ -420,11 +1691,64 @@ func createTagWithLock(repoPath, tag string) error {
 		}
 	}()
 
-	return createTag(tag)
+	if err := createTag(repoPath, tag, opts); err != nil {
+		return err
+	}
+	return createTagAliases(repoPath, tag, opts)
+}
+
+// createTagAliases points each of opts.Aliases at the commit of tag. If one fails,
+// the aliases already moved are restored and tag is deleted, so the release gets
+// every name or none of them.
+func createTagAliases(repoPath, tag string, opts TagOptions) error {
+	previous := make(map[string]string)
+	var created []string
+	for _, alias := range opts.Aliases {
+		cmdRevParse := execCommand("git", "rev-parse", "--verify", "--quiet", "refs/tags/"+alias)
+		cmdRevParse.Dir = repoPath
+		if oldRef, err := runGitCommand(cmdRevParse); err == nil {
+			previous[alias] = strings.TrimSpace(oldRef)
+		}
+
+		if err := createTag(repoPath, alias, aliasTagOptions(tag, opts)); err != nil {
+			restoreTagAliases(repoPath, created, previous)
+			if deleteErr := deleteTag(repoPath, tag); deleteErr != nil {
+				log.Error("failed to remove tag after alias failure", "tag", tag, "err", deleteErr)
+			}
+			return fmt.Errorf("failed to create alias %s: %w", alias, err)
+		}
+		created = append(created, alias)
+	}
+	return nil
+}
+
+// aliasTagOptions returns the options that force an alias onto the commit of tag,
+// signed or lightweight like tag itself.
+func aliasTagOptions(tag string, opts TagOptions) TagOptions {
+	return TagOptions{Target: tag + "^{commit}", Sign: opts.Sign, Lightweight: opts.Lightweight, Force: true}
+}
+
+// restoreTagAliases moves each alias back to its previous ref, or deletes it when
+// it did not exist before.
+func restoreTagAliases(repoPath string, aliases []string, previous map[string]string) {
+	for _, alias := range aliases {
+		oldRef, existed := previous[alias]
+		if !existed {
+			if err := deleteTag(repoPath, alias); err != nil {
+				log.Error("failed to remove alias", "alias", alias, "err", err)
+			}
+			continue
+		}
+		cmdRestore := execCommand("git", "update-ref", "refs/tags/"+alias, oldRef)
+		cmdRestore.Dir = repoPath
+		if _, err := runGitCommand(cmdRestore); err != nil {
+			log.Error("failed to restore alias", "alias", alias, "ref", oldRef, "err", err)
+		}
+	}
 }
 
 // pushTagWithLock pushes tags to remote using git operation locking.
-func pushTagWithLock(repoPath string) error {
+func pushTagWithLock(repoPath, remote string) error {
 	lock, err := acquireGitLock(repoPath)
 	if err != nil {
 		return fmt.Errorf("failed to acquire git lock: %w", err)
@@ -435,144 +1759,945 @@ func pushTagWithLock(repoPath string) error {
 		}
 	}()
 
-	return pushTag()
+	return pushTag(repoPath, remote)
 }
 
-// createTag creates a new git tag with the given tag.
-func createTag(tag string) error {
-	cmdTag := execCommand("git", "tag", "-m", tag, tag)
-	if output, err := cmdTag.CombinedOutput(); err != nil {
-		log.Error("failed to create tag", "err", err, "output", string(output))
-		return fmt.Errorf("failed to create tag: %w; %s", err, strings.TrimSpace(string(output)))
+// TagCommand returns the git command that creates tag with opts, e.g.
+// ["git", "tag", "-m", "v1.2.3", "v1.2.3"]. A custom message is read from stdin
+// ("-F -"). createTag runs exactly this command, so it can be shown in a dry run.
+func TagCommand(tag string, opts TagOptions) []string {
+	args := []string{"git", "tag"}
+	if opts.Force {
+		args = append(args, "-f")
 	}
-	return nil
+	if opts.Sign && !opts.Lightweight {
+		args = append(args, "-s")
+	}
+	switch {
+	case opts.Lightweight:
+		args = append(args, tag)
+	case opts.Message != "":
+		args = append(args, "-F", "-", tag)
+	default:
+		args = append(args, "-m", tag, tag)
+	}
+	if opts.Target != "" {
+		args = append(args, opts.Target)
+	}
+	return args
 }
 
-// pushTag pushes the latest git tag to the remote repository.
-func pushTag() error {
-	cmdPush := execCommand("git", "push", "--tags")
-	if output, err := cmdPush.CombinedOutput(); err != nil {
-		log.Error("failed to push tag", "err", err, "output", string(output))
-		return fmt.Errorf("failed to push tag: %w; %s", err, strings.TrimSpace(string(output)))
+// TagCommands returns the git commands that create tag and then each of
+// opts.Aliases, in the order CreateTagInRepo runs them.
+func TagCommands(tag string, opts TagOptions) [][]string {
+	commands := [][]string{TagCommand(tag, opts)}
+	for _, alias := range opts.Aliases {
+		commands = append(commands, TagCommand(alias, aliasTagOptions(tag, opts)))
 	}
-	return nil
+	return commands
 }
 
-// findGitRepoRoot finds the root directory of the git repository.
-func findGitRepoRoot(startPath string) (string, error) {
-	currentPath := startPath
-	for {
-		if _, err := os.Stat(filepath.Join(currentPath, ".git")); err == nil {
-			return currentPath, nil
-		}
-
-		parentPath := filepath.Dir(currentPath)
-		if parentPath == currentPath {
-			return "", fmt.Errorf("not inside a git repository")
-		}
-		currentPath = parentPath
+// PushTagsCommand returns the git command that pushes all tags to remote, or to
+// git's default remote when remote is empty.
+func PushTagsCommand(remote string) []string {
+	if remote == "" {
+		return []string{"git", "push", "--tags"}
 	}
+	return []string{"git", "push", remote, "--tags"}
 }
 
-// GetDefaultPushPreference reads the [bump] defaultPush value from .git/config in the given repo path.
-// Returns (value, isSet, error) where isSet indicates if the preference was explicitly configured.
-func GetDefaultPushPreference(repoPath string) (bool, bool, error) {
-	// Validate repository path
-	if err := validateRepositoryPath(repoPath); err != nil {
-		return false, false, fmt.Errorf("invalid repository path: %w", err)
+// PushSingleTagCommand returns the git command that pushes only tag to remote,
+// overwriting the remote tag when force is set.
+func PushSingleTagCommand(remote, tag string, force bool) []string {
+	args := []string{"git", "push"}
+	if force {
+		args = append(args, "--force")
 	}
+	return append(args, remote, TagRefspec(tag))
+}
 
-	configPath := filepath.Join(repoPath, ".git", "config")
-
-	// Check if config file exists and is readable
-	if _, err := os.Stat(configPath); err != nil {
-		if os.IsNotExist(err) {
-			return false, false, fmt.Errorf("git config file not found: %s", configPath)
+// createTag creates a new git tag with the given tag.
+// A custom annotation is piped to git on stdin so multi-line messages are preserved.
+// Lightweight tags have no tag object, so they cannot carry a message or signature.
+func createTag(repoPath, tag string, opts TagOptions) error {
+	if opts.Lightweight {
+		if opts.Message != "" || opts.Sign {
+			return fmt.Errorf("failed to create tag: lightweight tags cannot have a message or signature")
+		}
+	} else if opts.Sign {
+		format, err := SigningFormat(repoPath)
+		if err != nil {
+			return err
 		}
-		return false, false, fmt.Errorf("cannot access git config file: %w", err)
+		log.Debug("signing tag", "tag", tag, "format", format)
 	}
 
-	cfg, err := ini.Load(configPath)
+	args := TagCommand(tag, opts)
+	cmdTag := execCommand(args[0], args[1:]...)
+	if !opts.Lightweight && opts.Message != "" {
+		cmdTag.Stdin = strings.NewReader(opts.Message)
+	}
+	cmdTag.Dir = repoPath
+	if _, err := runGitCommand(cmdTag); err != nil {
+		log.Error("failed to create tag", "err", err)
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	return nil
+}
+
+// DeleteTagInRepo deletes a local git tag in the repository at repoPath.
+// Uses concurrency protection to prevent concurrent git operations.
+func DeleteTagInRepo(repoPath, tag string) error {
+	lock, err := acquireGitLock(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to acquire git lock: %w", err)
+	}
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			log.Error("failed to release git lock", "err", releaseErr)
+		}
+	}()
+
+	return deleteTag(repoPath, tag)
+}
+
+// ReplaceTagInRepo moves an existing tag in the repository at repoPath to the
+// target in opts (HEAD when empty) by deleting and recreating it. Both steps run
+// under a single git lock, and the original tag is restored if recreating fails.
+func ReplaceTagInRepo(repoPath, tag string, opts TagOptions) error {
+	lock, err := acquireGitLock(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to acquire git lock: %w", err)
+	}
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			log.Error("failed to release git lock", "err", releaseErr)
+		}
+	}()
+
+	cmdRevParse := execCommand("git", "rev-parse", "--verify", "refs/tags/"+tag)
+	cmdRevParse.Dir = repoPath
+	oldRef, err := runGitCommand(cmdRevParse)
+	if err != nil {
+		return fmt.Errorf("tag %s does not exist: %w", tag, err)
+	}
+	oldRef = strings.TrimSpace(oldRef)
+
+	if err := deleteTag(repoPath, tag); err != nil {
+		return err
+	}
+	if err := createTag(repoPath, tag, opts); err != nil {
+		cmdRestore := execCommand("git", "update-ref", "refs/tags/"+tag, oldRef)
+		cmdRestore.Dir = repoPath
+		if _, restoreErr := runGitCommand(cmdRestore); restoreErr != nil {
+			log.Error("failed to restore tag", "tag", tag, "ref", oldRef, "err", restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// TagRefspec returns the refspec that pushes exactly one tag to the same name on
+// the remote, e.g. "refs/tags/api/v1.2.3:refs/tags/api/v1.2.3". Spelling out both
+// sides keeps tags whose names contain slashes from being resolved as branches or
+// other refs.
+func TagRefspec(tag string) string {
+	ref := "refs/tags/" + tag
+	return ref + ":" + ref
+}
+
+// PushSingleTagInRepo pushes only the given tag to the remote, leaving any other
+// local tags unpublished.
+// Uses concurrency protection to prevent concurrent git operations.
+func PushSingleTagInRepo(repoPath, remote, tag string) error {
+	return pushSingleTagWithLock(repoPath, remote, tag, false)
+}
+
+// ForcePushTagInRepo pushes a single tag to the given remote, overwriting the
+// remote tag if it points elsewhere.
+// Uses concurrency protection to prevent concurrent git operations.
+func ForcePushTagInRepo(repoPath, remote, tag string) error {
+	return pushSingleTagWithLock(repoPath, remote, tag, true)
+}
+
+// pushSingleTagWithLock pushes one tag by its refspec using git operation locking.
+func pushSingleTagWithLock(repoPath, remote, tag string, force bool) error {
+	lock, err := acquireGitLock(repoPath)
 	if err != nil {
-		return false, false, fmt.Errorf("failed to load git config: %w", err)
+		return fmt.Errorf("failed to acquire git lock: %w", err)
 	}
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			log.Error("failed to release git lock", "err", releaseErr)
+		}
+	}()
 
-	section := cfg.Section("bump")
-	if !section.HasKey("defaultPush") {
-		// Return false, false (not set) when preference is not configured
+	args := PushSingleTagCommand(remote, tag, force)
+	cmdPush := execCommand(args[0], args[1:]...)
+	cmdPush.Dir = repoPath
+	if _, err := runGitCommand(cmdPush); err != nil {
+		log.Error("failed to push tag", "tag", tag, "remote", remote, "err", err)
+		return fmt.Errorf("failed to push tag %s to %s: %w", tag, remote, err)
+	}
+	return nil
+}
+
+// deleteTag deletes a local git tag.
+func deleteTag(repoPath, tag string) error {
+	cmdDelete := execCommand("git", "tag", "-d", tag)
+	cmdDelete.Dir = repoPath
+	if _, err := runGitCommand(cmdDelete); err != nil {
+		log.Error("failed to delete tag", "err", err)
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	return nil
+}
+
+// pushTag pushes the latest git tag to the remote repository.
+// An empty remote leaves the choice of remote to git.
+func pushTag(repoPath, remote string) error {
+	args := PushTagsCommand(remote)
+	cmdPush := execCommand(args[0], args[1:]...)
+	cmdPush.Dir = repoPath
+	if _, err := runGitCommand(cmdPush); err != nil {
+		log.Error("failed to push tag", "err", err)
+		return fmt.Errorf("failed to push tag: %w", err)
+	}
+	return nil
+}
+
+// GitConfigValue returns the value of a git config key as git resolves it for the
+// repository at repoPath, including global and system config.
+// Returns an empty string when the key is not set.
+func GitConfigValue(repoPath, key string) (string, error) {
+	cmdConfig := execCommand("git", "config", "--get", key)
+	cmdConfig.Dir = repoPath
+	output, err := runGitCommand(cmdConfig)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			// git config exits with status 1 when the key is not set
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read git config %s: %w", key, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// GitPath returns where git keeps path from the git directory of the repository at
+// repoPath, as "git rev-parse --git-path" reports it. This follows worktrees and
+// submodules to the directory git actually uses and honors settings such as
+// core.hooksPath. A relative result is resolved against repoPath.
+func GitPath(repoPath, path string) (string, error) {
+	cmdGitPath := execCommand("git", "rev-parse", "--git-path", path)
+	cmdGitPath.Dir = repoPath
+	output, err := runGitCommand(cmdGitPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git path %s: %w", path, err)
+	}
+	resolved := strings.TrimSpace(output)
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(repoPath, resolved)
+	}
+	return resolved, nil
+}
+
+// SigningFormat returns the tag signing format git is configured to use in the
+// repository at repoPath: the value of gpg.format, or "openpgp" when it is unset.
+// SSH signing has no default key, so "ssh" without user.signingkey is an error.
+func SigningFormat(repoPath string) (string, error) {
+	format, err := GitConfigValue(repoPath, "gpg.format")
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		format = "openpgp"
+	}
+	if format == "ssh" {
+		key, err := GitConfigValue(repoPath, "user.signingkey")
+		if err != nil {
+			return "", err
+		}
+		if key == "" {
+			return "", fmt.Errorf("gpg.format is ssh but user.signingkey is not set; point it at your SSH public key (git config user.signingkey ~/.ssh/id_ed25519.pub)")
+		}
+	}
+	return format, nil
+}
+
+// GitCommandError describes a git command that exited unsuccessfully.
+// Stderr holds only what git wrote to standard error, so callers can inspect
+// the failure reason (for example a rejected push or an authentication error)
+// without stdout noise mixed in.
+type GitCommandError struct {
+	Args   []string
+	Stderr string
+	Err    error
+}
+
+// Error returns the underlying error followed by git's stderr output.
+func (e *GitCommandError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v; %s", e.Err, e.Stderr)
+}
+
+// Unwrap returns the underlying error, typically an *exec.ExitError.
+func (e *GitCommandError) Unwrap() error {
+	return e.Err
+}
+
+// runGitCommand runs cmd with stdout and stderr captured separately.
+// It returns stdout on success and a *GitCommandError carrying stderr on failure.
+func runGitCommand(cmd *exec.Cmd) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), &GitCommandError{
+			Args:   cmd.Args,
+			Stderr: strings.TrimSpace(stderr.String()),
+			Err:    err,
+		}
+	}
+	return stdout.String(), nil
+}
+
+// findGitRepoRoot finds the root directory of the git repository.
+func findGitRepoRoot(startPath string) (string, error) {
+	currentPath := startPath
+	for {
+		if _, err := os.Stat(filepath.Join(currentPath, ".git")); err == nil {
+			return currentPath, nil
+		}
+
+		parentPath := filepath.Dir(currentPath)
+		if parentPath == currentPath {
+			return "", fmt.Errorf("not inside a git repository")
+		}
+		currentPath = parentPath
+	}
+}
+
+// DefaultConfigSection is the .git/config section bump stores its settings in.
+const DefaultConfigSection = "bump"
+
+// configSection is the section used by all config functions. It may name a git
+// subsection using either dotted ("tool.bump") or bracket (`tool "bump"`) form.
+var configSection = DefaultConfigSection
+
+// SetConfigSection changes the .git/config section bump reads and writes settings in.
+// An empty name restores DefaultConfigSection.
+func SetConfigSection(name string) {
+	if strings.TrimSpace(name) == "" {
+		configSection = DefaultConfigSection
+		return
+	}
+	configSection = strings.TrimSpace(name)
+}
+
+// ConfigSection returns the .git/config section bump reads and writes settings in.
+func ConfigSection() string {
+	return configSection
+}
+
+// ConfigPath returns the path of the git config file bump reads and writes for the
+// repository at repoPath. A ".git" file is followed to its git directory, and a
+// linked worktree resolves to the config of the main repository it shares.
+func ConfigPath(repoPath string) (string, error) {
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return "", err
+	}
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		gitDir = filepath.Clean(commonDir)
+	}
+	return filepath.Join(gitDir, "config"), nil
+}
+
+// ConfigFile returns the settings file set with SetConfigFile, or an empty string.
+func ConfigFile() string {
+	return configFile
+}
+
+// configFile is an optional settings file, in git config syntax, whose bump section
+// takes precedence over the repository's .git/config when reading settings.
+var configFile string
+
+// SetConfigFile makes bump read settings from the file at path before falling back
+// to .git/config. Settings are still written to .git/config. An empty path disables it.
+func SetConfigFile(path string) {
+	configFile = strings.TrimSpace(path)
+}
+
+// configSectionParts splits the configured section into a git section and optional
+// subsection, accepting both dotted ("tool.bump") and bracket (`tool "bump"`) forms.
+func configSectionParts() (string, string) {
+	if name, rest, ok := strings.Cut(configSection, " "); ok && strings.Contains(rest, "\"") {
+		return name, strings.Trim(strings.TrimSpace(rest), "\"")
+	}
+	section, subsection, _ := strings.Cut(configSection, ".")
+	return section, subsection
+}
+
+// configSectionName returns the configured section as it appears in a git config
+// header, mapping a dotted subsection such as "tool.bump" to git's `tool "bump"` syntax.
+func configSectionName() string {
+	section, subsection := configSectionParts()
+	if subsection == "" {
+		return section
+	}
+	return fmt.Sprintf("%s %q", section, subsection)
+}
+
+// errConfigNotFound is returned by loadGitConfig when the repository has no config file.
+var errConfigNotFound = errors.New("git config file not found")
+
+// loadGitConfig reads the .git/config of the repository at repoPath with go-git's
+// git config parser, which understands git-specific syntax such as
+// [includeIf "gitdir:..."] sections and quoted subsections.
+func loadGitConfig(repoPath string) (*format.Config, string, error) {
+	// Validate repository path
+	if err := validateRepositoryPath(repoPath); err != nil {
+		return nil, "", fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	configPath, err := ConfigPath(repoPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Check if config file exists and is readable
+	if _, err := os.Stat(configPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("%w: %s", errConfigNotFound, configPath)
+		}
+		return nil, "", fmt.Errorf("cannot access git config file: %w", err)
+	}
+
+	cfg, err := decodeConfigFile(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return cfg, configPath, nil
+}
+
+// decodeConfigFile parses the file at path in git config syntax.
+func decodeConfigFile(path string) (*format.Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access config file: %w", err)
+	}
+	defer file.Close()
+
+	cfg := format.New()
+	if err := format.NewDecoder(file).Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to load config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// RCFileNames lists the JSON settings files looked for at the repository root, in
+// order; the first one found is used.
+var RCFileNames = []string{".bumprc", ".versionrc"}
+
+// rcFile holds the settings read from a .bumprc or .versionrc JSON file. Keys
+// used by other tools sharing the file are ignored.
+type rcFile struct {
+	Prefix         *string  `json:"prefix"`         // Tag prefix, the prefix setting
+	Suffix         *string  `json:"suffix"`         // Default pre-release suffix, the suffix setting
+	Push           *bool    `json:"push"`           // Push after bumping, the defaultPush setting
+	UpdateFiles    []string `json:"updateFiles"`    // Version file to update, the updateFile setting
+	CommitTemplate *string  `json:"commitTemplate"` // Release commit message, the releaseCommitMessage setting
+}
+
+// RCFilePath returns the path of the JSON settings file at the root of the
+// repository at repoPath, or an empty string if there is none.
+func RCFilePath(repoPath string) string {
+	for _, name := range RCFileNames {
+		path := filepath.Join(repoPath, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// rcCache holds the parsed settings file at each path, so a .bumprc is read and
+// parsed once rather than on every setting lookup. An entry is reused while the
+// file's size and modification time are unchanged.
+var rcCache = make(map[string]rcCacheEntry)
+
+// rcCacheMutex protects concurrent access to the rcCache map.
+var rcCacheMutex sync.Mutex
+
+// rcCacheEntry is the result of parsing a settings file, with the file's size and
+// modification time at the time it was parsed.
+type rcCacheEntry struct {
+	size    int64             // size of the file when parsed
+	modTime time.Time         // modTime of the file when parsed
+	values  map[string]string // values are the config keys the file sets
+	err     error             // err is the error reading or parsing the file, if any
+}
+
+// rcSettings returns the config keys set by the .bumprc or .versionrc at the root
+// of the repository at repoPath, or nil if there is none. A file that cannot be
+// parsed is an error every time it is consulted.
+func rcSettings(repoPath string) (map[string]string, error) {
+	rcPath := RCFilePath(repoPath)
+	if rcPath == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(rcPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access settings file: %w", err)
+	}
+
+	rcCacheMutex.Lock()
+	defer rcCacheMutex.Unlock()
+	if entry, ok := rcCache[rcPath]; ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+		return entry.values, entry.err
+	}
+	values, err := loadRCFile(rcPath)
+	rcCache[rcPath] = rcCacheEntry{size: info.Size(), modTime: info.ModTime(), values: values, err: err}
+	return values, err
+}
+
+// loadRCFile reads the JSON settings file at path into the config keys it sets.
+func loadRCFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access settings file: %w", err)
+	}
+	var rc rcFile
+	if err := json.Unmarshal(data, &rc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(rc.UpdateFiles) > 1 {
+		return nil, fmt.Errorf("%s lists %d updateFiles, but bump updates a single version file", path, len(rc.UpdateFiles))
+	}
+
+	values := make(map[string]string)
+	if rc.Prefix != nil {
+		values["prefix"] = *rc.Prefix
+	}
+	if rc.Suffix != nil {
+		values["suffix"] = *rc.Suffix
+	}
+	if rc.Push != nil {
+		values["defaultPush"] = strconv.FormatBool(*rc.Push)
+	}
+	if len(rc.UpdateFiles) == 1 {
+		values["updateFile"] = rc.UpdateFiles[0]
+	}
+	if rc.CommitTemplate != nil {
+		values["releaseCommitMessage"] = *rc.CommitTemplate
+	}
+	return values, nil
+}
+
+// lookupConfig returns the value of a key in the bump section, taking it from the
+// file set with SetConfigFile when present there, then from a .bumprc or .versionrc
+// at the repository root, and from .git/config otherwise.
+func lookupConfig(repoPath, key string) (string, bool, error) {
+	if configFile != "" {
+		cfg, err := decodeConfigFile(configFile)
+		if err != nil {
+			return "", false, err
+		}
+		if options := configOptions(cfg); options.Has(key) {
+			return options.Get(key), true, nil
+		}
+	}
+
+	values, err := rcSettings(repoPath)
+	if err != nil {
+		return "", false, err
+	}
+	if value, isSet := values[key]; isSet {
+		return value, true, nil
+	}
+
+	cfg, _, err := loadGitConfig(repoPath)
+	if err != nil {
+		return "", false, err
+	}
+	options := configOptions(cfg)
+	if !options.Has(key) {
+		return "", false, nil
+	}
+	return options.Get(key), true, nil
+}
+
+// configOptions returns the options of the configured bump section, or nil when
+// the section is not present.
+func configOptions(cfg *format.Config) format.Options {
+	section, subsection := configSectionParts()
+	if !cfg.HasSection(section) {
+		return nil
+	}
+	if subsection == "" {
+		return cfg.Section(section).Options
+	}
+	if !cfg.Section(section).HasSubsection(subsection) {
+		return nil
+	}
+	return cfg.Section(section).Subsection(subsection).Options
+}
+
+// GetDefaultPushPreference reads the defaultPush value from the bump section of .git/config in the given repo path.
+// Returns (value, isSet, error) where isSet indicates if the preference was explicitly configured.
+func GetDefaultPushPreference(repoPath string) (bool, bool, error) {
+	return GetConfigBool(repoPath, "defaultPush")
+}
+
+// GetConfigBool reads a boolean key from the bump section of .git/config in the given repo path.
+// Returns (value, isSet, error) where isSet indicates if the key was explicitly configured.
+func GetConfigBool(repoPath, key string) (bool, bool, error) {
+	val, isSet, err := lookupConfig(repoPath, key)
+	if err != nil {
+		return false, false, err
+	}
+	if !isSet {
+		// Return false, false (not set) when the key is not configured
 		return false, false, nil
 	}
 
-	val := section.Key("defaultPush").String()
 	switch val {
 	case "true":
 		return true, true, nil // value=true, isSet=true
 	case "false":
 		return false, true, nil // value=false, isSet=true (explicitly set to false)
 	default:
-		return false, false, fmt.Errorf("invalid defaultPush value: %s (must be 'true' or 'false')", val)
+		return false, false, fmt.Errorf("invalid %s value: %s (must be 'true' or 'false')", key, val)
 	}
 }
 
-// SetDefaultPushPreference writes the [bump] defaultPush value to .git/config in the given repo path.
-// Uses atomic writes to prevent corruption.
-func SetDefaultPushPreference(repoPath string, value bool) error {
-	// Validate repository path
-	if err := validateRepositoryPath(repoPath); err != nil {
-		return fmt.Errorf("invalid repository path: %w", err)
+// GetConfigString reads a key from the bump section of .git/config in the given repo path.
+// Returns (value, isSet, error) where isSet indicates if the key was explicitly configured.
+func GetConfigString(repoPath, key string) (string, bool, error) {
+	return lookupConfig(repoPath, key)
+}
+
+// SetDefaultPushPreference writes the defaultPush value to the bump section of .git/config in the given repo path.
+// Uses atomic writes to prevent corruption. It reports whether the config changed;
+// the file is not rewritten when it already has the value.
+func SetDefaultPushPreference(repoPath string, value bool) (bool, error) {
+	return SetConfigString(repoPath, "defaultPush", fmt.Sprintf("%v", value))
+}
+
+// SetTagPrefix validates and writes the tag prefix to the bump section of .git/config.
+// Surrounding whitespace is trimmed, and a prefix that would not form a valid tag
+// name in front of a version (checked as prefix + "1.2.3") is rejected, so a bad
+// prefix is caught when it is set rather than when tags are next read.
+// It reports whether the config changed.
+func SetTagPrefix(repoPath, prefix string) (bool, error) {
+	prefix, err := normalizeTagPrefix(prefix)
+	if err != nil {
+		return false, err
+	}
+	return SetConfigString(repoPath, "prefix", prefix)
+}
+
+// PreviewTagPrefix reports how SetTagPrefix would change the bump section of
+// .git/config, without writing anything.
+func PreviewTagPrefix(repoPath, prefix string) ([]ConfigChange, error) {
+	prefix, err := normalizeTagPrefix(prefix)
+	if err != nil {
+		return nil, err
 	}
+	return PreviewConfigString(repoPath, "prefix", prefix)
+}
 
-	configPath := filepath.Join(repoPath, ".git", "config")
+// normalizeTagPrefix trims a tag prefix and checks that tags built from it are valid.
+func normalizeTagPrefix(prefix string) (string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if err := ValidateTagName(prefix + "1.2.3"); err != nil {
+		return "", fmt.Errorf("invalid prefix %q: %w", prefix, err)
+	}
+	return prefix, nil
+}
 
-	// Check if config file exists and is writable
-	if _, err := os.Stat(configPath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("git config file not found: %s", configPath)
+// ValidateTagName checks a tag name against git's reference name rules (see
+// git check-ref-format), so invalid names are caught before git rejects them.
+func ValidateTagName(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag name is empty")
+	}
+	if tag == "@" {
+		return fmt.Errorf("tag name cannot be \"@\"")
+	}
+	for _, ch := range tag {
+		if ch < 0x20 || ch == 0x7f || strings.ContainsRune(" ~^:?*[\\", ch) {
+			return fmt.Errorf("tag name %q contains invalid character %q", tag, ch)
 		}
-		return fmt.Errorf("cannot access git config file: %w", err)
 	}
+	for _, seq := range []string{"..", "@{", "//"} {
+		if strings.Contains(tag, seq) {
+			return fmt.Errorf("tag name %q cannot contain %q", tag, seq)
+		}
+	}
+	if strings.HasPrefix(tag, "/") || strings.HasSuffix(tag, "/") || strings.HasSuffix(tag, ".") {
+		return fmt.Errorf("tag name %q cannot start with \"/\" or end with \"/\" or \".\"", tag)
+	}
+	for _, component := range strings.Split(tag, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("tag name %q has a path component starting with \".\" or ending with \".lock\"", tag)
+		}
+	}
+	return nil
+}
 
-	// Create backup file path for atomic operation
-	backupPath := configPath + ".bump.tmp"
-
+// SetConfigString writes a key to the bump section of .git/config in the given repo path.
+// Uses atomic writes to prevent corruption. It reports whether the config changed:
+// when the key already has the value, the file is left untouched, so its
+// modification time does not change either.
+func SetConfigString(repoPath, key, value string) (bool, error) {
 	// Load current config
-	cfg, err := ini.Load(configPath)
+	cfg, configPath, err := loadGitConfig(repoPath)
 	if err != nil {
-		return fmt.Errorf("failed to load git config: %w", err)
+		return false, err
+	}
+
+	// Update the configuration, skipping the write if nothing changed
+	before := sectionValues(configOptions(cfg))
+	applyOption(cfg, key, value)
+	changes := diffSectionValues(before, sectionValues(configOptions(cfg)))
+	if len(changes) == 0 {
+		return false, nil
 	}
 
-	// Update the configuration
-	section := cfg.Section("bump")
-	section.Key("defaultPush").SetValue(fmt.Sprintf("%v", value))
+	if err := writeConfigChanges(configPath, changes); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// writeConfigChanges atomically applies changes to the config file at configPath.
+// The keys are set with git config on a copy of the file that is then renamed over
+// it, so git edits only those lines and the comments, includes and layout of the
+// rest of the file survive.
+func writeConfigChanges(configPath string, changes []ConfigChange) error {
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return fmt.Errorf("cannot access git config file: %w", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("cannot access git config file: %w", err)
+	}
 
 	// Write to temporary file first (atomic operation)
-	if err := cfg.SaveTo(backupPath); err != nil {
+	backupPath := configPath + ".bump.tmp"
+	if err := os.WriteFile(backupPath, data, info.Mode().Perm()); err != nil {
 		return fmt.Errorf("failed to write temporary config: %w", err)
 	}
+	for _, change := range changes {
+		cmdConfig := execCommand("git", "config", "--file", backupPath, "--replace-all", configKeyName(change.Key), change.NewValue)
+		if _, err := runGitCommand(cmdConfig); err != nil {
+			removeTempConfig(backupPath)
+			return fmt.Errorf("failed to set %s: %w", change.Key, err)
+		}
+	}
 
 	// Atomic rename to replace original file
 	if err := os.Rename(backupPath, configPath); err != nil {
-		// Clean up temporary file on failure
-		if rmErr := os.Remove(backupPath); rmErr != nil {
-			log.Error("failed to clean up temporary config file", "backupPath", backupPath, "err", rmErr)
-		}
+		removeTempConfig(backupPath)
 		return fmt.Errorf("failed to update git config atomically: %w", err)
 	}
-
 	return nil
 }
 
+// removeTempConfig cleans up the temporary config file after a failed write.
+func removeTempConfig(backupPath string) {
+	if err := os.Remove(backupPath); err != nil {
+		log.Error("failed to clean up temporary config file", "backupPath", backupPath, "err", err)
+	}
+}
+
+// configKeyName returns the name git config uses for key in the configured
+// section, such as "bump.tagType" or "tool.bump.tagType".
+func configKeyName(key string) string {
+	section, subsection := configSectionParts()
+	if subsection == "" {
+		return section + "." + key
+	}
+	return section + "." + subsection + "." + key
+}
+
+// ConfigChange describes a single key that a config update would change.
+type ConfigChange struct {
+	Section  string // Section is the config section header, e.g. "bump"
+	Key      string // Key is the name of the changed key
+	OldValue string // OldValue is the current value; empty when WasSet is false
+	NewValue string // NewValue is the value that would be written
+	WasSet   bool   // WasSet reports whether the key is currently set
+}
+
+// PreviewDefaultPushPreference reports how SetDefaultPushPreference would change the
+// bump section of .git/config, without writing anything.
+func PreviewDefaultPushPreference(repoPath string, value bool) ([]ConfigChange, error) {
+	return PreviewConfigString(repoPath, "defaultPush", fmt.Sprintf("%v", value))
+}
+
+// PreviewConfigString reports how SetConfigString would change the bump section of
+// .git/config, without writing anything. The change is applied to the loaded
+// config in memory and the section is compared before and after.
+// An empty result means the config already has the requested value.
+func PreviewConfigString(repoPath, key, value string) ([]ConfigChange, error) {
+	cfg, _, err := loadGitConfig(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	before := sectionValues(configOptions(cfg))
+	applyOption(cfg, key, value)
+	return diffSectionValues(before, sectionValues(configOptions(cfg))), nil
+}
+
+// diffSectionValues lists the keys whose values differ between two snapshots of
+// the bump section, sorted by key.
+func diffSectionValues(before, after map[string]string) []ConfigChange {
+	var changes []ConfigChange
+	for key, newValue := range after {
+		oldValue, wasSet := before[key]
+		if wasSet && oldValue == newValue {
+			continue
+		}
+		changes = append(changes, ConfigChange{
+			Section:  configSectionName(),
+			Key:      key,
+			OldValue: oldValue,
+			NewValue: newValue,
+			WasSet:   wasSet,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// ReadConfigSection returns the keys and values of the bump section in source,
+// which is either a repository, whose git config is read, or a file in git config
+// syntax such as a team template. A source without the section yields an empty map.
+func ReadConfigSection(source string) (map[string]string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access config source: %w", err)
+	}
+	var cfg *format.Config
+	if info.IsDir() {
+		cfg, _, err = loadGitConfig(source)
+	} else {
+		cfg, err = decodeConfigFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sectionValues(configOptions(cfg)), nil
+}
+
+// MigrateConfig copies settings into the bump section of the repository's git
+// config, written atomically. Keys the repository already sets to a different
+// value are kept, and returned as skipped, unless overwrite is set. It returns the
+// changes made; with dryRun they are only computed and nothing is written.
+func MigrateConfig(repoPath string, values map[string]string, overwrite, dryRun bool) ([]ConfigChange, []string, error) {
+	cfg, configPath, err := loadGitConfig(repoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	before := sectionValues(configOptions(cfg))
+	var skipped []string
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if current, isSet := before[key]; isSet && current != values[key] && !overwrite {
+			skipped = append(skipped, key)
+			continue
+		}
+		applyOption(cfg, key, values[key])
+	}
+
+	changes := diffSectionValues(before, sectionValues(configOptions(cfg)))
+	if len(changes) == 0 || dryRun {
+		return changes, skipped, nil
+	}
+	if err := writeConfigChanges(configPath, changes); err != nil {
+		return nil, nil, err
+	}
+	return changes, skipped, nil
+}
+
+// applyOption sets a key in the bump section of a loaded config.
+func applyOption(cfg *format.Config, key, value string) {
+	section, subsection := configSectionParts()
+	cfg.SetOption(section, subsection, key, value)
+}
+
+// sectionValues returns a copy of the keys and values in a config section.
+// For keys set more than once, the last value wins, as in git.
+func sectionValues(options format.Options) map[string]string {
+	values := make(map[string]string)
+	for _, option := range options {
+		values[option.Key] = option.Value
+	}
+	return values
+}
+
+// SubmodulePaths returns the paths of the submodules declared in the .gitmodules
+// file at the root of the repository, in the order they are declared.
+// Returns an empty slice when the repository has no .gitmodules file.
+func SubmodulePaths(repoPath string) ([]string, error) {
+	modulesPath := filepath.Join(repoPath, ".gitmodules")
+	if _, err := os.Stat(modulesPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot access .gitmodules: %w", err)
+	}
+
+	cfg, err := ini.Load(modulesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .gitmodules: %w", err)
+	}
+
+	var paths []string
+	for _, section := range cfg.Sections() {
+		if !strings.HasPrefix(section.Name(), "submodule ") || !section.HasKey("path") {
+			continue
+		}
+		paths = append(paths, section.Key("path").String())
+	}
+	return paths, nil
+}
+
 // validateRepositoryPath validates that the given path is a valid Git repository.
 func validateRepositoryPath(repoPath string) error {
 	if repoPath == "" {
 		return fmt.Errorf("repository path cannot be empty")
 	}
 
+	_, err := resolveGitDir(repoPath)
+	return err
+}
+
+// resolveGitDir returns the absolute path of the git directory for the repository
+// at repoPath. A ".git" file containing a "gitdir:" pointer, as used by submodules
+// and linked worktrees, is followed to the directory it names.
+func resolveGitDir(repoPath string) (string, error) {
 	// Clean and validate the path
 	cleanPath := filepath.Clean(repoPath)
 	absPath, err := filepath.Abs(cleanPath)
 	if err != nil {
-		return fmt.Errorf("invalid path: %w", err)
+		return "", fmt.Errorf("invalid path: %w", err)
 	}
 
 	// Check if it's a valid git repository
@@ -580,14 +2705,32 @@ func validateRepositoryPath(repoPath string) error {
 	stat, err := os.Stat(gitDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("not a git repository: %s", absPath)
+			return "", fmt.Errorf("not a git repository: %s", absPath)
 		}
-		return fmt.Errorf("cannot access .git directory: %w", err)
+		return "", fmt.Errorf("cannot access .git directory: %w", err)
 	}
 
-	if !stat.IsDir() {
-		return fmt.Errorf(".git is not a directory: %s", gitDir)
+	if stat.IsDir() {
+		return gitDir, nil
 	}
 
-	return nil
+	content, err := os.ReadFile(gitDir)
+	if err != nil {
+		return "", fmt.Errorf("cannot read .git file: %w", err)
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf(".git is not a directory: %s", gitDir)
+	}
+	target = strings.TrimSpace(target)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(absPath, target)
+	}
+
+	stat, err = os.Stat(target)
+	if err != nil || !stat.IsDir() {
+		return "", fmt.Errorf("gitdir does not point to a directory: %s", target)
+	}
+
+	return filepath.Clean(target), nil
 }
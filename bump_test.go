@@ -1,11 +1,15 @@
 package bump
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -55,13 +59,22 @@ func TestParseTagVersion(t *testing.T) {
 	}
 }
 
+// TestSortTagNames tests ordering version tag names newest first
+func TestSortTagNames(t *testing.T) {
+	sorted := SortTagNames([]string{"v1.0.0", "latest", "v1.1.0-rc.1", "v1.1.0", "v1.0.0"})
+	expected := []string{"v1.1.0", "v1.1.0-rc.1", "v1.0.0"}
+	if strings.Join(sorted, ",") != strings.Join(expected, ",") {
+		t.Errorf("SortTagNames() = %v, expected %v", sorted, expected)
+	}
+}
+
 func TestSortVersions(t *testing.T) {
 	versions := []*tagVersion{
 		{Major: 1, Minor: 0, Patch: 0, Tag: "v1.0.0"},
 		{Major: 2, Minor: 0, Patch: 0, Tag: "v2.0.0"},
 		{Major: 1, Minor: 1, Patch: 0, Tag: "v1.1.0"},
 	}
-	sortVersions(versions)
+	sortVersions(versions, nil)
 	if versions[0].Tag != "v2.0.0" {
 		t.Errorf("Expected versions[0].Tag to be 'v2.0.0', got '%s'", versions[0].Tag)
 	}
@@ -200,6 +213,324 @@ func TestGetNextTag(t *testing.T) {
 	}
 }
 
+func TestGetNextTagWithOptionsSuffix(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentTag  string
+		bumpType    string
+		opts        NextTagOptions
+		expectedTag string
+	}{
+		{
+			name:        "Reset drops existing suffix",
+			currentTag:  "v1.0.0-rc.1",
+			bumpType:    "patch",
+			opts:        NextTagOptions{},
+			expectedTag: "v1.0.1",
+		},
+		{
+			name:        "Preserve keeps existing suffix unchanged",
+			currentTag:  "v1.0.0-rc.1",
+			bumpType:    "patch",
+			opts:        NextTagOptions{PreserveSuffix: true},
+			expectedTag: "v1.0.1-rc.1",
+		},
+		{
+			name:        "Preserve on minor bump",
+			currentTag:  "v1.0.3-beta",
+			bumpType:    "minor",
+			opts:        NextTagOptions{PreserveSuffix: true},
+			expectedTag: "v1.1.0-beta",
+		},
+		{
+			name:        "Explicit suffix overrides preserved suffix",
+			currentTag:  "v1.0.0-rc.1",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Suffix: "beta.1", PreserveSuffix: true},
+			expectedTag: "v1.0.1-beta.1",
+		},
+		{
+			name:        "Preserve with no existing suffix",
+			currentTag:  "v1.0.0",
+			bumpType:    "patch",
+			opts:        NextTagOptions{PreserveSuffix: true},
+			expectedTag: "v1.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextTag, err := GetNextTagWithOptions(tt.currentTag, tt.bumpType, tt.opts)
+			if err != nil {
+				t.Fatalf("GetNextTagWithOptions() error = %v", err)
+			}
+			if nextTag != tt.expectedTag {
+				t.Errorf("Expected nextTag to be '%s', got '%s'", tt.expectedTag, nextTag)
+			}
+		})
+	}
+}
+
+// TestTagPrefix tests parsing and generating tags with a configured prefix
+func TestTagPrefix(t *testing.T) {
+	for _, prefix := range []string{"", " v ", "v"} {
+		scheme, err := NewTagScheme(TagSchemeOptions{Prefix: prefix})
+		if err != nil || scheme.Prefix() != "v" {
+			t.Errorf("NewTagScheme(%q) = %q, %v; expected the default prefix v", prefix, scheme.Prefix(), err)
+		}
+	}
+
+	scheme, err := NewTagScheme(TagSchemeOptions{Prefix: "api/v"})
+	if err != nil {
+		t.Fatalf("NewTagScheme() error = %v", err)
+	}
+	if version, ok := scheme.ParseTagVersion("api/v1.2.3-rc.1"); !ok || version.Major != 1 || version.Suffix != "-rc.1" {
+		t.Errorf("ParseTagVersion(api/v1.2.3-rc.1) = %+v, %v", version, ok)
+	}
+	for _, tag := range []string{"v1.2.3", "web/v1.2.3", "api/v1.2"} {
+		if _, ok := scheme.ParseTagVersion(tag); ok {
+			t.Errorf("ParseTagVersion(%s) should fail under prefix api/v", tag)
+		}
+	}
+	if got := scheme.LatestTagName([]string{"v9.0.0", "api/v1.2.0", "api/v1.10.0"}); got != "api/v1.10.0" {
+		t.Errorf("LatestTagName() = %s, expected api/v1.10.0", got)
+	}
+	if got := scheme.StripPrefix("api/v1.2.3"); got != "1.2.3" {
+		t.Errorf("StripPrefix() = %s, expected 1.2.3", got)
+	}
+
+	for _, tt := range []struct {
+		bumpType string
+		opts     NextTagOptions
+		expected string
+	}{
+		{"minor", NextTagOptions{}, "api/v1.3.0"},
+		{"patch", NextTagOptions{Channel: "rc"}, "api/v1.2.4-rc.1"},
+		{"patch", NextTagOptions{IncrementBuild: true}, "api/v1.2.3+build.1"},
+	} {
+		if got, err := scheme.GetNextTagWithOptions("api/v1.2.3", tt.bumpType, tt.opts); err != nil || got != tt.expected {
+			t.Errorf("GetNextTagWithOptions(api/v1.2.3, %s, %+v) = %q, %v; expected %s", tt.bumpType, tt.opts, got, err, tt.expected)
+		}
+	}
+	if got, err := scheme.GetNextTagWithOptions("api/v1.2.3-rc.1", "patch", NextTagOptions{IncrementPrerelease: true}); err != nil || got != "api/v1.2.3-rc.2" {
+		t.Errorf("GetNextTagWithOptions(api/v1.2.3-rc.1) = %q, %v; expected api/v1.2.3-rc.2", got, err)
+	}
+
+	for _, prefix := range []string{"my prefix", "api..v", "v*", "v:"} {
+		if _, err := NewTagScheme(TagSchemeOptions{Prefix: prefix}); err == nil {
+			t.Errorf("NewTagScheme() should reject prefix %q", prefix)
+		}
+	}
+}
+
+// TestSuffixSeparator tests parsing and generating tags with a non-SemVer pre-release separator
+func TestSuffixSeparator(t *testing.T) {
+	scheme, err := NewTagScheme(TagSchemeOptions{SuffixSeparator: "_"})
+	if err != nil {
+		t.Fatalf("NewTagScheme() error = %v", err)
+	}
+
+	for tag, suffix := range map[string]string{"v1.2.3_beta.1": "_beta.1", "v1.2.3-rc.2": "-rc.2"} {
+		version, ok := scheme.ParseTagVersion(tag)
+		if !ok || version.Suffix != suffix {
+			t.Errorf("ParseTagVersion(%q) = %+v, %v; expected suffix %q", tag, version, ok, suffix)
+		}
+	}
+	if _, ok := scheme.ParseTagVersion("v1.2.3.beta"); ok {
+		t.Error("ParseTagVersion() accepted a separator that is not configured")
+	}
+	if _, ok := ParseTagVersion("v1.2.3_beta.1"); ok {
+		t.Error("ParseTagVersion() accepted a separator outside the scheme that configures it")
+	}
+
+	tests := []struct {
+		name        string
+		currentTag  string
+		bumpType    string
+		opts        NextTagOptions
+		expectedTag string
+	}{
+		{"Suffix uses separator", "v1.2.3", "minor", NextTagOptions{Suffix: "beta.1"}, "v1.3.0_beta.1"},
+		{"Channel uses separator", "v1.2.3", "patch", NextTagOptions{Channel: "rc"}, "v1.2.4_rc.1"},
+		{"Increment keeps separator", "v1.3.0_beta.1", "patch", NextTagOptions{IncrementPrerelease: true}, "v1.3.0_beta.2"},
+		{"Preserve converts dash suffix", "v1.3.0-beta.1", "patch", NextTagOptions{PreserveSuffix: true}, "v1.3.1_beta.1"},
+		{"Release drops suffix", "v1.3.0_beta.2", "patch", NextTagOptions{}, "v1.3.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextTag, err := scheme.GetNextTagWithOptions(tt.currentTag, tt.bumpType, tt.opts)
+			if err != nil {
+				t.Fatalf("GetNextTagWithOptions() error = %v", err)
+			}
+			if nextTag != tt.expectedTag {
+				t.Errorf("Expected nextTag to be '%s', got '%s'", tt.expectedTag, nextTag)
+			}
+		})
+	}
+
+	if _, err := NewTagScheme(TagSchemeOptions{SuffixSeparator: "~"}); err == nil {
+		t.Error("NewTagScheme() expected an error for separator ~")
+	}
+}
+
+func TestGetNextTagWithOptionsChannel(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentTag  string
+		bumpType    string
+		opts        NextTagOptions
+		expectedTag string
+		expectError bool
+	}{
+		{
+			name:        "Channel starts a series on the bumped version",
+			currentTag:  "v1.0.0",
+			bumpType:    "minor",
+			opts:        NextTagOptions{Channel: "alpha"},
+			expectedTag: "v1.1.0-alpha.1",
+		},
+		{
+			name:        "Channel without increment bumps the core version",
+			currentTag:  "v1.1.0-alpha.1",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Channel: "alpha"},
+			expectedTag: "v1.1.1-alpha.1",
+		},
+		{
+			name:        "Increment within channel",
+			currentTag:  "v1.1.0-alpha.1",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Channel: "alpha", IncrementPrerelease: true},
+			expectedTag: "v1.1.0-alpha.2",
+		},
+		{
+			name:        "Promote to a later channel",
+			currentTag:  "v1.1.0-alpha.3",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Channel: "beta", IncrementPrerelease: true},
+			expectedTag: "v1.1.0-beta.1",
+		},
+		{
+			name:        "Promote from beta to rc",
+			currentTag:  "v2.0.0-beta.4",
+			bumpType:    "major",
+			opts:        NextTagOptions{Channel: "rc", IncrementPrerelease: true},
+			expectedTag: "v2.0.0-rc.1",
+		},
+		{
+			name:        "Channel without counter gains one",
+			currentTag:  "v1.1.0-beta",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Channel: "beta", IncrementPrerelease: true},
+			expectedTag: "v1.1.0-beta.1",
+		},
+		{
+			name:        "Increment without channel",
+			currentTag:  "v1.1.0-rc.9",
+			bumpType:    "patch",
+			opts:        NextTagOptions{IncrementPrerelease: true},
+			expectedTag: "v1.1.0-rc.10",
+		},
+		{
+			name:        "Increment from stable bumps the core version",
+			currentTag:  "v1.1.0",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Channel: "rc", IncrementPrerelease: true},
+			expectedTag: "v1.1.1-rc.1",
+		},
+		{
+			name:        "Backward move is rejected",
+			currentTag:  "v1.1.0-beta.2",
+			bumpType:    "patch",
+			opts:        NextTagOptions{Channel: "alpha", IncrementPrerelease: true},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextTag, err := GetNextTagWithOptions(tt.currentTag, tt.bumpType, tt.opts)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("GetNextTagWithOptions() error = %v, expectError %v", err, tt.expectError)
+			}
+			if nextTag != tt.expectedTag {
+				t.Errorf("Expected nextTag to be '%s', got '%s'", tt.expectedTag, nextTag)
+			}
+		})
+	}
+}
+
+// TestGetNextTagPrerelease tests advancing the pre-release without a core bump
+func TestGetNextTagPrerelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentTag  string
+		opts        NextTagOptions
+		expectedTag string
+		expectError bool
+	}{
+		{name: "Increment counter", currentTag: "v1.2.0-rc.1", expectedTag: "v1.2.0-rc.2"},
+		{name: "Initialize counter", currentTag: "v1.2.0-rc", expectedTag: "v1.2.0-rc.1"},
+		{name: "Continue named series", currentTag: "v1.2.0-beta.2", opts: NextTagOptions{Suffix: "beta"}, expectedTag: "v1.2.0-beta.3"},
+		{name: "Switch to later series", currentTag: "v1.2.0-beta.2", opts: NextTagOptions{Channel: "rc"}, expectedTag: "v1.2.0-rc.1"},
+		{name: "Start series on release", currentTag: "v1.2.0", opts: NextTagOptions{Suffix: "rc"}, expectedTag: "v1.2.1-rc.1"},
+		{name: "No suffix to increment", currentTag: "v1.2.0", expectError: true},
+		{name: "Switch to earlier series", currentTag: "v1.2.0-rc.1", opts: NextTagOptions{Suffix: "alpha"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextTag, err := GetNextTagWithOptions(tt.currentTag, "prerelease", tt.opts)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("GetNextTagWithOptions() error = %v, expectError %v", err, tt.expectError)
+			}
+			if nextTag != tt.expectedTag {
+				t.Errorf("Expected nextTag to be '%s', got '%s'", tt.expectedTag, nextTag)
+			}
+		})
+	}
+}
+
+// TestGetNextTagIncrementBuild tests advancing build metadata while keeping the version
+func TestGetNextTagIncrementBuild(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentTag  string
+		bumpType    string
+		opts        NextTagOptions
+		expectedTag string
+	}{
+		{name: "Increment counter", currentTag: "v1.2.3+build.42", opts: NextTagOptions{IncrementBuild: true}, expectedTag: "v1.2.3+build.43"},
+		{name: "Keep pre-release suffix", currentTag: "v1.2.3-rc.1+build.9", opts: NextTagOptions{IncrementBuild: true}, expectedTag: "v1.2.3-rc.1+build.10"},
+		{name: "Initialize without metadata", currentTag: "v1.2.3", opts: NextTagOptions{IncrementBuild: true}, expectedTag: "v1.2.3+build.1"},
+		{name: "Metadata without counter gains one", currentTag: "v1.2.3+ci", opts: NextTagOptions{IncrementBuild: true}, expectedTag: "v1.2.3+ci.1"},
+		{name: "Regular bump drops metadata", currentTag: "v1.2.3+build.42", bumpType: "patch", expectedTag: "v1.2.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextTag, err := GetNextTagWithOptions(tt.currentTag, tt.bumpType, tt.opts)
+			if err != nil {
+				t.Fatalf("GetNextTagWithOptions() unexpected error = %v", err)
+			}
+			if nextTag != tt.expectedTag {
+				t.Errorf("Expected nextTag to be '%s', got '%s'", tt.expectedTag, nextTag)
+			}
+		})
+	}
+}
+
+// TestLatestTagNameBuildMetadata tests that the highest build of a release is the latest
+func TestLatestTagNameBuildMetadata(t *testing.T) {
+	latest := LatestTagName([]string{"v1.2.3", "v1.2.3+build.9", "v1.2.3+build.10", "v1.2.2+build.99"})
+	if latest != "v1.2.3+build.10" {
+		t.Errorf("LatestTagName() = %q, expected v1.2.3+build.10", latest)
+	}
+	if latest := LatestTagName([]string{"v1.2.4", "v1.2.3+build.10"}); latest != "v1.2.4" {
+		t.Errorf("LatestTagName() = %q, expected v1.2.4", latest)
+	}
+}
+
 func TestParseInt(t *testing.T) {
 	if result := parseInt("123"); result != 123 {
 		t.Errorf("Expected ParseInt('123') to be 123, got %d", result)
@@ -222,7 +553,7 @@ func TestOpenGitRepoInvalidPath(t *testing.T) {
 
 func TestCreateTag(t *testing.T) {
 	// Test case to ensure createTag returns an error for an invalid command
-	err := createTag("")
+	err := createTag(".", "", TagOptions{})
 	if err == nil {
 		t.Errorf("Expected error for invalid tag command, got nil")
 	}
@@ -232,7 +563,7 @@ func TestCompareVersionsEqual(t *testing.T) {
 	// This test ensures compareVersions returns false for equal versions
 	version1 := &tagVersion{Major: 1, Minor: 0, Patch: 0}
 	version2 := &tagVersion{Major: 1, Minor: 0, Patch: 0}
-	if compareVersions(version1, version2) {
+	if compareVersions(version1, version2, nil) {
 		t.Errorf("Expected compareVersions to return false for equal versions")
 	}
 }
@@ -253,78 +584,728 @@ func TestCreateTagInvalid(t *testing.T) {
 	}
 }
 
-func TestCreateTagAnnotatedRequirement(t *testing.T) {
+// TestOpenRepo tests opening a repository from its root, a nested directory, and outside any repository
+func TestOpenRepo(t *testing.T) {
+	repoDir := t.TempDir()
+	cmd := exec.Command("git", "init")
+	cmd.Dir = repoDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v; output: %s", err, string(output))
+	}
+	nested := filepath.Join(repoDir, "cmd", "tool")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir nested: %v", err)
+	}
+
+	for _, path := range []string{repoDir, nested} {
+		repo, err := OpenRepo(path)
+		if err != nil {
+			t.Fatalf("OpenRepo(%q) error = %v", path, err)
+		}
+		if repo.Path() != repoDir {
+			t.Errorf("OpenRepo(%q).Path() = %q, expected %q", path, repo.Path(), repoDir)
+		}
+		if repo.Repository() == nil {
+			t.Errorf("OpenRepo(%q).Repository() = nil", path)
+		}
+	}
+
+	if _, err := OpenRepo(t.TempDir()); err == nil {
+		t.Error("OpenRepo() outside a repository expected an error")
+	}
+}
+
+// TestSuggestNextTags tests the patch, minor, and major candidates for a repository
+func TestSuggestNextTags(t *testing.T) {
 	repoDir := t.TempDir()
 
-	runGit := func(args ...string) string {
+	runGit := func(args ...string) {
 		t.Helper()
 		cmd := exec.Command("git", args...)
 		cmd.Dir = repoDir
-		output, err := cmd.CombinedOutput()
-		if err != nil {
+		if output, err := cmd.CombinedOutput(); err != nil {
 			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
 		}
-		return string(output)
 	}
 
 	runGit("init")
 	runGit("config", "user.name", "Test User")
 	runGit("config", "user.email", "test@example.com")
-	runGit("config", "tag.gpgSign", "false") // ensure no signing requirement in test
+	runGit("config", "tag.gpgSign", "false")
+	runGit("commit", "--allow-empty", "-m", "initial commit")
 
-	readme := filepath.Join(repoDir, "README.md")
-	if err := os.WriteFile(readme, []byte("test"), 0o644); err != nil {
-		t.Fatalf("failed to write test file: %v", err)
+	check := func(expected [3]string) {
+		t.Helper()
+		patch, minor, major, err := SuggestNextTags(repoDir)
+		if err != nil {
+			t.Fatalf("SuggestNextTags() error = %v", err)
+		}
+		if got := [3]string{patch, minor, major}; got != expected {
+			t.Errorf("SuggestNextTags() = %v, expected %v", got, expected)
+		}
 	}
-	runGit("add", "README.md")
-	runGit("commit", "-m", "initial commit")
 
-	origDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("getwd: %v", err)
-	}
-	t.Cleanup(func() {
-		_ = os.Chdir(origDir)
-	})
-	if err := os.Chdir(repoDir); err != nil {
-		t.Fatalf("chdir to repo: %v", err)
-	}
+	check([3]string{"v0.1.0", "v0.1.0", "v0.1.0"})
 
-	if err := CreateTag("v0.0.1-test"); err != nil {
-		t.Fatalf("CreateTag failed: %v", err)
-	}
+	runGit("tag", "v1.1.0")
+	runGit("tag", "v1.2.3")
+	runGit("tag", "latest")
+	check([3]string{"v1.2.4", "v1.3.0", "v2.0.0"})
 
-	tags := runGit("tag", "--list")
-	if !strings.Contains(tags, "v0.0.1-test") {
-		t.Fatalf("expected tag to be created, got: %s", tags)
+	if _, _, _, err := SuggestNextTags(t.TempDir()); err == nil {
+		t.Error("SuggestNextTags() should fail outside a repository")
 	}
 }
 
-func TestPushTagInvalid(t *testing.T) {
-	// Override execCommand to simulate a failure
-	origExecCommand := execCommand
-	defer func() { execCommand = origExecCommand }()
+// TestPeelTagRef tests resolving lightweight, annotated, and nested annotated tags to their commit
+func TestPeelTagRef(t *testing.T) {
+	repoDir := t.TempDir()
 
-	execCommand = func(name string, arg ...string) *exec.Cmd {
-		// Return a command that always fails
-		return exec.Command("false")
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return strings.TrimSpace(string(output))
 	}
 
-	err := PushTag()
-	if err == nil {
-		t.Errorf("Expected error for push outside a git repo, got nil")
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+	runGit("commit", "--allow-empty", "-m", "initial commit")
+	first := runGit("rev-parse", "HEAD")
+	runGit("tag", "v1.0.0")
+	runGit("commit", "--allow-empty", "-m", "second commit")
+	second := runGit("rev-parse", "HEAD")
+	runGit("tag", "-a", "-m", "release v1.1.0", "v1.1.0")
+	runGit("tag", "-a", "-m", "tag of a tag", "v1.1.1", "v1.1.0")
+
+	r, err := openGitRepo(repoDir)
+	if err != nil {
+		t.Fatalf("openGitRepo() error = %v", err)
 	}
-}
-
-func TestCompareVersionsHigherPatch(t *testing.T) {
-	// This test ensures compareVersions correctly compares versions with different patch numbers
-	version1 := &tagVersion{Major: 1, Minor: 0, Patch: 1}
-	version2 := &tagVersion{Major: 1, Minor: 0, Patch: 2}
-	if !compareVersions(version2, version1) {
-		t.Errorf("Expected version2 to be greater than version1 by patch")
+	tagRefs, err := getTags(r)
+	if err != nil {
+		t.Fatalf("getTags() error = %v", err)
 	}
-	if compareVersions(version1, version2) {
-		t.Errorf("Expected version1 to be less than version2 by patch")
+	if latest, err := GetLatestTag(tagRefs); err != nil || latest != "v1.1.1" {
+		t.Errorf("GetLatestTag() = %q, %v; expected v1.1.1", latest, err)
+	}
+
+	for tag, expected := range map[string]string{"v1.0.0": first, "v1.1.0": second, "v1.1.1": second} {
+		ref, err := r.Tag(tag)
+		if err != nil {
+			t.Fatalf("Tag(%s) error = %v", tag, err)
+		}
+		hash, err := PeelTagRef(r, ref)
+		if err != nil {
+			t.Fatalf("PeelTagRef(%s) error = %v", tag, err)
+		}
+		if hash.String() != expected {
+			t.Errorf("PeelTagRef(%s) = %s, expected %s", tag, hash, expected)
+		}
+	}
+}
+
+// TestCommitsBetween tests tag..HEAD and hash..hash ranges, listing merged branches in topological order
+func TestCommitsBetween(t *testing.T) {
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return strings.TrimSpace(string(output))
+	}
+	commit := func(message string) string {
+		t.Helper()
+		runGit("commit", "--allow-empty", "-m", message)
+		return runGit("rev-parse", "HEAD")
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+	first := commit("initial commit")
+	runGit("tag", "-a", "-m", "release v1.0.0", "v1.0.0")
+	second := commit("second commit")
+	runGit("checkout", "-b", "feature")
+	feature := commit("feature commit")
+	runGit("checkout", "-")
+	mainline := commit("mainline commit")
+	runGit("merge", "--no-ff", "-m", "merge feature", "feature")
+	merge := runGit("rev-parse", "HEAD")
+
+	r, err := openGitRepo(repoDir)
+	if err != nil {
+		t.Fatalf("openGitRepo() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected []string
+	}{
+		{"Tag to HEAD", "v1.0.0", "HEAD", []string{merge, mainline, feature, second}},
+		{"Hash to hash", first, second, []string{second}},
+		{"Branch to HEAD", "feature", "HEAD", []string{merge, mainline}},
+		{"Whole history", "", second, []string{second, first}},
+		{"Empty range", "HEAD", "v1.0.0", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := CommitsBetween(r, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("CommitsBetween() error = %v", err)
+			}
+			var got []string
+			for _, c := range commits {
+				got = append(got, c.Hash.String())
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("CommitsBetween(%q, %q) = %v, expected %v", tt.from, tt.to, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := CommitsBetween(r, "v9.9.9", "HEAD"); err == nil {
+		t.Error("CommitsBetween() with an unknown ref expected an error")
+	}
+}
+
+func TestCreateTagAnnotatedRequirement(t *testing.T) {
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return string(output)
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false") // ensure no signing requirement in test
+
+	readme := filepath.Join(repoDir, "README.md")
+	if err := os.WriteFile(readme, []byte("test"), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGit("add", "README.md")
+	runGit("commit", "-m", "initial commit")
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+	})
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("chdir to repo: %v", err)
+	}
+
+	if err := CreateTag("v0.0.1-test"); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+
+	tags := runGit("tag", "--list")
+	if !strings.Contains(tags, "v0.0.1-test") {
+		t.Fatalf("expected tag to be created, got: %s", tags)
+	}
+}
+
+func TestCreateTagWithOptionsMessage(t *testing.T) {
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return string(output)
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+
+	readme := filepath.Join(repoDir, "README.md")
+	if err := os.WriteFile(readme, []byte("test"), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGit("add", "README.md")
+	runGit("commit", "-m", "initial commit")
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+	})
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("chdir to repo: %v", err)
+	}
+
+	message := "Release notes\n\n- first change\n- second change\n"
+	if err := CreateTagWithOptions("v0.0.2", TagOptions{Message: message}); err != nil {
+		t.Fatalf("CreateTagWithOptions failed: %v", err)
+	}
+
+	contents := runGit("tag", "-l", "--format=%(contents)", "v0.0.2")
+	if !strings.Contains(contents, "- second change") {
+		t.Fatalf("expected tag annotation to contain message, got: %s", contents)
+	}
+}
+
+func TestCreateTagTargetAndDelete(t *testing.T) {
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		runGit("add", name)
+		runGit("commit", "-m", name)
+		if name == "a.txt" {
+			runGit("tag", "-a", "-m", "old", "release-1.0.0")
+		}
+	}
+
+	if err := CreateTagInRepo(repoDir, "v1.0.0", TagOptions{Target: "release-1.0.0^{commit}"}); err != nil {
+		t.Fatalf("CreateTagInRepo failed: %v", err)
+	}
+	if got, want := runGit("rev-parse", "v1.0.0^{commit}"), runGit("rev-parse", "release-1.0.0^{commit}"); got != want {
+		t.Errorf("v1.0.0 points at %s, expected %s", got, want)
+	}
+
+	if err := DeleteTagInRepo(repoDir, "release-1.0.0"); err != nil {
+		t.Fatalf("DeleteTagInRepo failed: %v", err)
+	}
+	if tags := runGit("tag", "--list"); tags != "v1.0.0" {
+		t.Errorf("expected only v1.0.0 to remain, got: %s", tags)
+	}
+
+	if err := DeleteTagInRepo(repoDir, "missing"); err == nil {
+		t.Error("DeleteTagInRepo should fail for a missing tag")
+	}
+}
+
+func TestReplaceTagInRepo(t *testing.T) {
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		runGit("add", name)
+		runGit("commit", "-m", name)
+		if name == "a.txt" {
+			runGit("tag", "-a", "-m", "v1.0.0", "v1.0.0")
+		}
+	}
+
+	if err := ReplaceTagInRepo(repoDir, "v1.0.0", TagOptions{}); err != nil {
+		t.Fatalf("ReplaceTagInRepo failed: %v", err)
+	}
+	if got, want := runGit("rev-parse", "v1.0.0^{commit}"), runGit("rev-parse", "HEAD"); got != want {
+		t.Errorf("v1.0.0 points at %s, expected HEAD %s", got, want)
+	}
+
+	if err := ReplaceTagInRepo(repoDir, "v9.9.9", TagOptions{}); err == nil {
+		t.Error("ReplaceTagInRepo should fail for a missing tag")
+	}
+
+	// A failed recreate restores the original tag
+	before := runGit("rev-parse", "v1.0.0")
+	if err := ReplaceTagInRepo(repoDir, "v1.0.0", TagOptions{Target: "no-such-commit"}); err == nil {
+		t.Fatal("ReplaceTagInRepo should fail for an invalid target")
+	}
+	if after := runGit("rev-parse", "v1.0.0"); after != before {
+		t.Errorf("v1.0.0 = %s after failed replace, expected restored %s", after, before)
+	}
+}
+
+// TestCreateTagInRepoAliases tests creating a precise tag with moving aliases, and rolling all back on failure
+func TestCreateTagInRepoAliases(t *testing.T) {
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+	runGit("commit", "--allow-empty", "-m", "first")
+	runGit("tag", "-a", "-m", "v1", "v1")
+	runGit("commit", "--allow-empty", "-m", "second")
+	head := runGit("rev-parse", "HEAD")
+
+	if err := CreateTagInRepo(repoDir, "v1.2.3", TagOptions{Aliases: []string{"v1", "latest"}}); err != nil {
+		t.Fatalf("CreateTagInRepo failed: %v", err)
+	}
+	for _, tag := range []string{"v1.2.3", "v1", "latest"} {
+		if got := runGit("rev-parse", tag+"^{commit}"); got != head {
+			t.Errorf("%s points at %s, expected HEAD %s", tag, got, head)
+		}
+	}
+
+	// A failing alias removes the new tag and restores the aliases already moved
+	runGit("commit", "--allow-empty", "-m", "third")
+	before := runGit("rev-parse", "v1")
+	if err := CreateTagInRepo(repoDir, "v1.2.4", TagOptions{Aliases: []string{"v1", "bad..name"}}); err == nil {
+		t.Fatal("CreateTagInRepo should fail for an invalid alias")
+	}
+	if after := runGit("rev-parse", "v1"); after != before {
+		t.Errorf("v1 = %s after failed aliasing, expected restored %s", after, before)
+	}
+	if tags := runGit("tag", "--list", "v1.2.4"); tags != "" {
+		t.Errorf("v1.2.4 should have been removed, got %q", tags)
+	}
+}
+
+func TestGitConfigValue(t *testing.T) {
+	repoDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.name", "Test User"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+	}
+
+	name, err := GitConfigValue(repoDir, "user.name")
+	if err != nil {
+		t.Fatalf("GitConfigValue error = %v", err)
+	}
+	if name != "Test User" {
+		t.Errorf("GitConfigValue(user.name) = %q, expected %q", name, "Test User")
+	}
+
+	value, err := GitConfigValue(repoDir, "bump.doesNotExist")
+	if err != nil {
+		t.Fatalf("GitConfigValue for unset key error = %v", err)
+	}
+	if value != "" {
+		t.Errorf("GitConfigValue for unset key = %q, expected empty", value)
+	}
+}
+
+// TestSigningFormat tests reading gpg.format and validating the SSH signing key
+func TestSigningFormat(t *testing.T) {
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	tests := []struct {
+		name        string
+		config      [][]string
+		expected    string
+		expectError string
+	}{
+		{name: "Default", expected: "openpgp"},
+		{name: "X509", config: [][]string{{"gpg.format", "x509"}}, expected: "x509"},
+		{name: "SSH with key", config: [][]string{{"gpg.format", "ssh"}, {"user.signingkey", "~/.ssh/id_ed25519.pub"}}, expected: "ssh"},
+		{name: "SSH without key", config: [][]string{{"gpg.format", "ssh"}}, expectError: "user.signingkey is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			for _, args := range append([][]string{{"init"}}, tt.config...) {
+				if len(args) == 2 {
+					args = append([]string{"config"}, args...)
+				}
+				cmd := exec.Command("git", args...)
+				cmd.Dir = repoDir
+				if output, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+				}
+			}
+
+			format, err := SigningFormat(repoDir)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("SigningFormat() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SigningFormat() unexpected error = %v", err)
+			}
+			if format != tt.expected {
+				t.Errorf("SigningFormat() = %q, expected %q", format, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCreateTagSign tests that signed tags are created with git tag -s
+func TestCreateTagSign(t *testing.T) {
+	orig := execCommand
+	defer func() { execCommand = orig }()
+
+	var tagArgs []string
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		if len(arg) > 0 && arg[0] == "tag" {
+			tagArgs = arg
+		}
+		// Unset config keys make git config exit with status 1
+		if len(arg) > 0 && arg[0] == "config" {
+			return exec.Command("false")
+		}
+		return exec.Command("true")
+	}
+
+	if err := createTag(t.TempDir(), "v1.0.0", TagOptions{Sign: true}); err != nil {
+		t.Fatalf("createTag() unexpected error = %v", err)
+	}
+	if got := strings.Join(tagArgs, " "); got != "tag -s -m v1.0.0 v1.0.0" {
+		t.Errorf("git args = %q, expected %q", got, "tag -s -m v1.0.0 v1.0.0")
+	}
+}
+
+// TestCreateTagLightweight tests creating a lightweight tag and rejecting annotations on one
+func TestCreateTagLightweight(t *testing.T) {
+	orig := execCommand
+	defer func() { execCommand = orig }()
+
+	var cmdTag *exec.Cmd
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		cmdTag = exec.Command("true", arg...)
+		return cmdTag
+	}
+
+	if err := createTag(t.TempDir(), "v1.0.0", TagOptions{Lightweight: true, Target: "abc123"}); err != nil {
+		t.Fatalf("createTag() unexpected error = %v", err)
+	}
+	if got := strings.Join(cmdTag.Args[1:], " "); got != "tag v1.0.0 abc123" {
+		t.Errorf("git args = %q, expected %q", got, "tag v1.0.0 abc123")
+	}
+
+	if err := createTag(t.TempDir(), "v1.0.1", TagOptions{Lightweight: true, Message: "Release"}); err == nil {
+		t.Error("createTag() should reject a message on a lightweight tag")
+	}
+}
+
+// TestTagCommandsMatchExecuted tests that the displayed git commands are the ones createTag and pushTag run
+func TestTagCommandsMatchExecuted(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TagOptions
+	}{
+		{name: "Annotated", opts: TagOptions{}},
+		{name: "Signed with aliases", opts: TagOptions{Sign: true, Aliases: []string{"v1", "latest"}}},
+		{name: "Lightweight at target", opts: TagOptions{Lightweight: true, Target: "abc123"}},
+		{name: "Custom message", opts: TagOptions{Message: "Release notes\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := execCommand
+			defer func() { execCommand = orig }()
+
+			var executed [][]string
+			execCommand = func(name string, arg ...string) *exec.Cmd {
+				switch {
+				case len(arg) > 0 && (arg[0] == "tag" || arg[0] == "push"):
+					executed = append(executed, append([]string{name}, arg...))
+				case len(arg) > 0 && (arg[0] == "config" || arg[0] == "rev-parse"):
+					// Unset config keys and missing aliases make git exit with status 1
+					return exec.Command("false")
+				}
+				return exec.Command("true")
+			}
+
+			repoPath := t.TempDir()
+			if err := createTag(repoPath, "v1.2.0", tt.opts); err != nil {
+				t.Fatalf("createTag() unexpected error = %v", err)
+			}
+			if err := createTagAliases(repoPath, "v1.2.0", tt.opts); err != nil {
+				t.Fatalf("createTagAliases() unexpected error = %v", err)
+			}
+			if err := pushTag(repoPath, "upstream"); err != nil {
+				t.Fatalf("pushTag() unexpected error = %v", err)
+			}
+
+			expected := append(TagCommands("v1.2.0", tt.opts), PushTagsCommand("upstream"))
+			if !slices.EqualFunc(executed, expected, slices.Equal) {
+				t.Errorf("executed %q, expected %q", executed, expected)
+			}
+		})
+	}
+}
+
+func TestPushTagInvalid(t *testing.T) {
+	// Override execCommand to simulate a failure
+	origExecCommand := execCommand
+	defer func() { execCommand = origExecCommand }()
+
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		// Return a command that always fails
+		return exec.Command("false")
+	}
+
+	err := PushTag()
+	if err == nil {
+		t.Errorf("Expected error for push outside a git repo, got nil")
+	}
+}
+
+func TestPushTagSurfacesStderr(t *testing.T) {
+	orig := execCommand
+	defer func() { execCommand = orig }()
+
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		return exec.Command("sh", "-c", "echo 'To origin'; echo '! [rejected] v1.0.0 (already exists)' >&2; exit 1")
+	}
+
+	err := pushTag(t.TempDir(), "origin")
+	if err == nil {
+		t.Fatal("pushTag() expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "failed to push tag") {
+		t.Errorf("pushTag() error = %q, expected human-readable prefix", err)
+	}
+	if !strings.Contains(err.Error(), "[rejected]") {
+		t.Errorf("pushTag() error = %q, expected stderr content", err)
+	}
+	if strings.Contains(err.Error(), "To origin") {
+		t.Errorf("pushTag() error = %q, should not include stdout", err)
+	}
+
+	var gitErr *GitCommandError
+	if !errors.As(err, &gitErr) {
+		t.Fatalf("pushTag() error = %T, expected to wrap *GitCommandError", err)
+	}
+	if gitErr.Stderr != "! [rejected] v1.0.0 (already exists)" {
+		t.Errorf("GitCommandError.Stderr = %q, expected rejected message", gitErr.Stderr)
+	}
+}
+
+// TestTagRefspec tests that single-tag refspecs name the full tag ref on both sides
+func TestTagRefspec(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{tag: "v1.2.3", expected: "refs/tags/v1.2.3:refs/tags/v1.2.3"},
+		{tag: "api/v1.2.3", expected: "refs/tags/api/v1.2.3:refs/tags/api/v1.2.3"},
+		{tag: "services/api/v2.0.0-rc.1", expected: "refs/tags/services/api/v2.0.0-rc.1:refs/tags/services/api/v2.0.0-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if result := TagRefspec(tt.tag); result != tt.expected {
+				t.Errorf("TagRefspec(%q) = %q, expected %q", tt.tag, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPushSingleTagInRepo tests that only the given tag is pushed, by its refspec
+func TestPushSingleTagInRepo(t *testing.T) {
+	repoPath := newTempRepo(t)
+	orig := execCommand
+	defer func() { execCommand = orig }()
+
+	var calls [][]string
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		calls = append(calls, append([]string{name}, arg...))
+		return exec.Command("true")
+	}
+
+	if err := PushSingleTagInRepo(repoPath, "origin", "api/v1.2.3"); err != nil {
+		t.Fatalf("PushSingleTagInRepo() unexpected error = %v", err)
+	}
+	if err := ForcePushTagInRepo(repoPath, "origin", "api/v1.2.3"); err != nil {
+		t.Fatalf("ForcePushTagInRepo() unexpected error = %v", err)
+	}
+
+	expected := []string{
+		"git push origin refs/tags/api/v1.2.3:refs/tags/api/v1.2.3",
+		"git push --force origin refs/tags/api/v1.2.3:refs/tags/api/v1.2.3",
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("git calls = %v, expected %v", calls, expected)
+	}
+	for i, call := range calls {
+		if got := strings.Join(call, " "); got != expected[i] {
+			t.Errorf("git call %d = %q, expected %q", i, got, expected[i])
+		}
+	}
+}
+
+func TestCompareVersionsHigherPatch(t *testing.T) {
+	// This test ensures compareVersions correctly compares versions with different patch numbers
+	version1 := &tagVersion{Major: 1, Minor: 0, Patch: 1}
+	version2 := &tagVersion{Major: 1, Minor: 0, Patch: 2}
+	if !compareVersions(version2, version1, nil) {
+		t.Errorf("Expected version2 to be greater than version1 by patch")
+	}
+	if compareVersions(version1, version2, nil) {
+		t.Errorf("Expected version1 to be less than version2 by patch")
 	}
 }
 
@@ -376,7 +1357,7 @@ func TestCompareSuffixes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := compareSuffixes(tt.suffix1, tt.suffix2)
+			result := compareSuffixes(tt.suffix1, tt.suffix2, nil)
 			if result != tt.expected {
 				t.Errorf("compareSuffixes(%q, %q) = %v, expected %v", tt.suffix1, tt.suffix2, result, tt.expected)
 			}
@@ -418,6 +1399,18 @@ func TestCompareSuffixesSemVer2(t *testing.T) {
 			suffix2:  "-beta.11",
 			expected: false,
 		},
+		{
+			name:     "legacy alpha.01 compares by value (alpha.02 > alpha.01)",
+			suffix1:  "-alpha.02",
+			suffix2:  "-alpha.01",
+			expected: true,
+		},
+		{
+			name:     "legacy alpha.01 < alpha.2",
+			suffix1:  "-alpha.01",
+			suffix2:  "-alpha.2",
+			expected: false,
+		},
 		{
 			name:     "alpha.1 < alpha.2",
 			suffix1:  "-alpha.1",
@@ -542,7 +1535,7 @@ func TestCompareSuffixesSemVer2(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := compareSuffixes(tt.suffix1, tt.suffix2)
+			result := compareSuffixes(tt.suffix1, tt.suffix2, nil)
 			if result != tt.expected {
 				t.Errorf("compareSuffixes(%q, %q) = %v, expected %v", tt.suffix1, tt.suffix2, result, tt.expected)
 			}
@@ -615,6 +1608,31 @@ func TestParseNumericIdentifier(t *testing.T) {
 	}
 }
 
+// TestLatestPerReleaseLine tests grouping tags by major.minor and picking the newest in each line
+func TestLatestPerReleaseLine(t *testing.T) {
+	names := []string{
+		"v1.1.0", "v1.1.9", "v1.1.10-rc.1", "v1.1.2",
+		"v1.2.0", "v1.2.7", "v1.2.3",
+		"v2.0.0-rc.1", "v2.0.0-rc.2",
+		"v0.9.1", "nightly",
+	}
+	expected := []ReleaseLine{
+		{Major: 2, Minor: 0, Latest: "v2.0.0-rc.2"},
+		{Major: 1, Minor: 2, Latest: "v1.2.7"},
+		{Major: 1, Minor: 1, Latest: "v1.1.10-rc.1"},
+		{Major: 0, Minor: 9, Latest: "v0.9.1"},
+	}
+	lines := LatestPerReleaseLine(names)
+	if len(lines) != len(expected) {
+		t.Fatalf("LatestPerReleaseLine() = %v, expected %v", lines, expected)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("LatestPerReleaseLine()[%d] = %+v, expected %+v", i, lines[i], expected[i])
+		}
+	}
+}
+
 // TestSortVersionsSemVer2 tests that version sorting follows SemVer 2.0 specification
 func TestSortVersionsSemVer2(t *testing.T) {
 	// Test the canonical SemVer 2.0 example sequence
@@ -629,7 +1647,7 @@ func TestSortVersionsSemVer2(t *testing.T) {
 		{Major: 1, Minor: 0, Patch: 0, Suffix: "-alpha", Tag: "v1.0.0-alpha"},
 	}
 
-	sortVersions(versions)
+	sortVersions(versions, nil)
 
 	// After sorting in descending order, the expected order is:
 	expected := []string{
@@ -685,6 +1703,159 @@ func TestValidateRepositoryPath(t *testing.T) {
 	}
 }
 
+// TestResolveGitDirGitdirFile tests that a submodule-style .git file is followed
+func TestResolveGitDirGitdirFile(t *testing.T) {
+	super := newTempRepo(t)
+	moduleGitDir := filepath.Join(super, ".git", "modules", "lib")
+	if err := os.MkdirAll(moduleGitDir, 0o755); err != nil {
+		t.Fatalf("mkdir modules: %v", err)
+	}
+	sub := filepath.Join(super, "lib")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("mkdir submodule: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, ".git"), []byte("gitdir: ../.git/modules/lib\n"), 0o644); err != nil {
+		t.Fatalf("write .git file: %v", err)
+	}
+
+	gitDir, err := resolveGitDir(sub)
+	if err != nil {
+		t.Fatalf("resolveGitDir error = %v", err)
+	}
+	if gitDir != moduleGitDir {
+		t.Errorf("resolveGitDir = %s, expected %s", gitDir, moduleGitDir)
+	}
+
+	lock, err := acquireGitLock(sub)
+	if err != nil {
+		t.Fatalf("acquireGitLock in submodule error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(moduleGitDir, "bump.lock")); err != nil {
+		t.Errorf("expected lock file in submodule git dir: %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Errorf("Release error = %v", err)
+	}
+
+	// A .git file without a gitdir pointer is not a repository
+	bogus := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bogus, ".git"), []byte("not a pointer"), 0o644); err != nil {
+		t.Fatalf("write .git file: %v", err)
+	}
+	if err := validateRepositoryPath(bogus); err == nil {
+		t.Error("validateRepositoryPath should reject a .git file without gitdir")
+	}
+}
+
+// TestConfigPath tests resolving the git config file for plain repos and linked worktrees
+func TestConfigPath(t *testing.T) {
+	repo := newTempRepo(t)
+	got, err := ConfigPath(repo)
+	if err != nil {
+		t.Fatalf("ConfigPath error = %v", err)
+	}
+	if expected := filepath.Join(repo, ".git", "config"); got != expected {
+		t.Errorf("ConfigPath = %s, expected %s", got, expected)
+	}
+
+	// A linked worktree shares the config of the main repository
+	worktreeGitDir := filepath.Join(repo, ".git", "worktrees", "feature")
+	if err := os.MkdirAll(worktreeGitDir, 0o755); err != nil {
+		t.Fatalf("mkdir worktree git dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreeGitDir, "commondir"), []byte("../..\n"), 0o644); err != nil {
+		t.Fatalf("write commondir: %v", err)
+	}
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+worktreeGitDir+"\n"), 0o644); err != nil {
+		t.Fatalf("write .git file: %v", err)
+	}
+	got, err = ConfigPath(worktree)
+	if err != nil {
+		t.Fatalf("ConfigPath in worktree error = %v", err)
+	}
+	if expected := filepath.Join(repo, ".git", "config"); got != expected {
+		t.Errorf("ConfigPath in worktree = %s, expected %s", got, expected)
+	}
+
+	if _, err := ConfigPath(t.TempDir()); err == nil {
+		t.Error("ConfigPath should error outside a repository")
+	}
+}
+
+// TestAppendLocked tests that concurrent appends never interleave their lines
+func TestAppendLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	const writers = 20
+	line := strings.Repeat("x", 4096) + "\n"
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- AppendLocked(path, []byte(line))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("AppendLocked error = %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if string(content) != strings.Repeat(line, writers) {
+		t.Errorf("file has %d bytes, expected %d intact lines", len(content), writers)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file should be removed after appending, stat error = %v", err)
+	}
+}
+
+// TestSubmodulePaths tests reading submodule paths from .gitmodules
+func TestSubmodulePaths(t *testing.T) {
+	repo := newTempRepo(t)
+
+	paths, err := SubmodulePaths(repo)
+	if err != nil {
+		t.Fatalf("SubmodulePaths without .gitmodules error = %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no submodules, got %v", paths)
+	}
+
+	modules := `[submodule "lib"]
+	path = vendor/lib
+	url = https://example.com/lib.git
+[submodule "tools"]
+	path = tools
+	url = https://example.com/tools.git
+`
+	if err := os.WriteFile(filepath.Join(repo, ".gitmodules"), []byte(modules), 0o644); err != nil {
+		t.Fatalf("write .gitmodules: %v", err)
+	}
+
+	paths, err = SubmodulePaths(repo)
+	if err != nil {
+		t.Fatalf("SubmodulePaths error = %v", err)
+	}
+	expected := []string{"vendor/lib", "tools"}
+	if len(paths) != len(expected) {
+		t.Fatalf("SubmodulePaths = %v, expected %v", paths, expected)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("SubmodulePaths[%d] = %s, expected %s", i, paths[i], expected[i])
+		}
+	}
+}
+
 // TestFindGitRepoRoot tests the findGitRepoRoot function
 func TestFindGitRepoRoot(t *testing.T) {
 	repo := newTempRepo(t)
@@ -693,68 +1864,372 @@ func TestFindGitRepoRoot(t *testing.T) {
 		t.Fatalf("Failed to create nested directory: %v", err)
 	}
 
-	tests := []struct {
-		name        string
-		startPath   string
-		expectError bool
-	}{
-		{
-			name:        "Nested path (should find git root)",
-			startPath:   nested,
-			expectError: false,
-		},
-		{
-			name:        "Root directory (should fail)",
-			startPath:   "/",
-			expectError: true,
-		},
-		{
-			name:        "Temp directory (should fail)",
-			startPath:   "/tmp",
-			expectError: true,
-		},
+	tests := []struct {
+		name        string
+		startPath   string
+		expectError bool
+	}{
+		{
+			name:        "Nested path (should find git root)",
+			startPath:   nested,
+			expectError: false,
+		},
+		{
+			name:        "Root directory (should fail)",
+			startPath:   "/",
+			expectError: true,
+		},
+		{
+			name:        "Temp directory (should fail)",
+			startPath:   "/tmp",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := findGitRepoRoot(tt.startPath)
+			if (err != nil) != tt.expectError {
+				t.Errorf("findGitRepoRoot(%q) error = %v, expectError %v", tt.startPath, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestGetLatestTagEmpty tests GetLatestTag with no valid tags
+func TestGetLatestTagEmpty(t *testing.T) {
+	// Create empty reference iterator
+	refs := []plumbing.Reference{}
+	tagRefs := NewMockReferenceIter(refs)
+
+	tag, err := GetLatestTag(tagRefs)
+	if err != nil {
+		t.Errorf("GetLatestTag with empty tags should not error, got: %v", err)
+	}
+	if tag != "" {
+		t.Errorf("GetLatestTag with empty tags should return empty string, got: %s", tag)
+	}
+}
+
+// TestGetLatestTagNonSemVer tests GetLatestTag with non-semantic version tags
+func TestGetLatestTagNonSemVer(t *testing.T) {
+	// Create references with non-semver tags
+	refs := []plumbing.Reference{
+		*plumbing.NewReferenceFromStrings("refs/tags/release", "a670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf43"),
+		*plumbing.NewReferenceFromStrings("refs/tags/foo", "b670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf44"),
+	}
+	tagRefs := NewMockReferenceIter(refs)
+
+	tag, err := GetLatestTag(tagRefs)
+	if err != nil {
+		t.Errorf("GetLatestTag should handle non-semver tags gracefully, got error: %v", err)
+	}
+	if tag != "" {
+		t.Errorf("GetLatestTag with only non-semver tags should return empty string, got: %s", tag)
+	}
+}
+
+// TestStrictTagProblems tests reporting strict SemVer violations in version-like tags
+func TestStrictTagProblems(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected []string
+	}{
+		{tag: "v1.2.3"},
+		{tag: "v1.2.3-rc.1+build.007"},
+		{tag: "v01.2.3", expected: []string{`leading zero in major version "01"`}},
+		{tag: "v1.2", expected: []string{`missing components: "1.2" is not MAJOR.MINOR.PATCH`}},
+		{tag: "v1.2.3.4", expected: []string{`too many components: "1.2.3.4" is not MAJOR.MINOR.PATCH`}},
+		{tag: "v1..3", expected: []string{"empty minor version"}},
+		{tag: "1.2.3", expected: []string{`missing "v" prefix`}},
+		{tag: "V1.2.3", expected: []string{`uppercase "V" prefix`}},
+		{tag: "v1.2.3_beta.1", expected: []string{`pre-release separator "_" is not "-"`}},
+		{tag: "v1.2.3.beta", expected: []string{`pre-release separator "." is not "-"`}},
+		{tag: "v1.2.3beta", expected: []string{`missing "-" before pre-release`}},
+		{tag: "v1.2.3-rc.01", expected: []string{`leading zero in pre-release identifier "01"`}},
+		{tag: "v1.2.3-rc..1", expected: []string{`empty pre-release identifier in "rc..1"`}},
+		{tag: "v1.2.3-rc_1", expected: []string{`invalid character in pre-release identifier "rc_1"`}},
+		{tag: "v1.2.3+", expected: []string{`empty build metadata identifier in ""`}},
+		{tag: "v1.02.3-", expected: []string{`leading zero in minor version "02"`, `empty pre-release identifier in ""`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := StrictTagProblems(tt.tag); !slices.Equal(got, tt.expected) {
+				t.Errorf("StrictTagProblems(%q) = %q, expected %q", tt.tag, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestLintTagNames tests that every malformed version tag is reported once and other tags are ignored
+func TestLintTagNames(t *testing.T) {
+	names := []string{"v1.0.0", "v1.1", "v01.2.0", "v01.2.0^{}", "nightly", "release-candidate", "v2.0.0_rc.1", "v2.0.0-rc.1", "1.0.0"}
+
+	var got []string
+	for _, lint := range LintTagNames(names) {
+		got = append(got, lint.Tag)
+	}
+	expected := []string{"1.0.0", "v01.2.0", "v1.1", "v2.0.0_rc.1"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("LintTagNames() reported %v, expected %v", got, expected)
+	}
+}
+
+// TestScanTagNames tests the scanned, version and skipped counts for a mixed tag set
+func TestScanTagNames(t *testing.T) {
+	scan := ScanTagNames([]string{"v1.0.0", "v1.0.0^{}", "release-2", "nightly", "v1.1.0-rc.1"})
+
+	if scan.Scanned != 5 || scan.Versions != 2 {
+		t.Errorf("ScanTagNames() scanned = %d, versions = %d, expected 5 and 2", scan.Scanned, scan.Versions)
+	}
+	if strings.Join(scan.Skipped, ",") != "nightly,release-2" {
+		t.Errorf("ScanTagNames() skipped = %v, expected [nightly release-2]", scan.Skipped)
+	}
+}
+
+// TestTagScanner tests finding the latest version, with and without excluded tags,
+// from names added one at a time
+func TestTagScanner(t *testing.T) {
+	scanner := NewTagScanner(func(tag string) bool { return strings.Contains(tag, "-dev") })
+	for _, name := range []string{"v1.0.0", "v1.0.0^{}", "v1.1.0-dev.3", "nightly", "v1.0.1", "v0.9.0"} {
+		scanner.Add(name)
+	}
+	scan := scanner.Done()
+
+	if latest := scanner.Latest(); latest != "v1.1.0-dev.3" {
+		t.Errorf("Latest() = %q, expected v1.1.0-dev.3", latest)
+	}
+	if latest := scanner.LatestIncluded(); latest != "v1.0.1" {
+		t.Errorf("LatestIncluded() = %q, expected v1.0.1", latest)
+	}
+	if scan.Scanned != 6 || scan.Versions != 4 || strings.Join(scan.Skipped, ",") != "nightly" {
+		t.Errorf("Done() = %+v, expected 6 scanned, 4 versions and nightly skipped", scan)
+	}
+
+	empty := NewTagScanner(nil)
+	empty.Add("nightly")
+	empty.Done()
+	if empty.Latest() != "" || empty.LatestIncluded() != "" {
+		t.Errorf("scanner without versions = %q, %q, expected empty", empty.Latest(), empty.LatestIncluded())
+	}
+}
+
+// TestParseLenientTagVersion tests parsing non-canonical version tags
+func TestParseLenientTagVersion(t *testing.T) {
+	tests := []struct {
+		tag       string
+		canonical string
+		valid     bool
+	}{
+		{tag: "v1.2.3", canonical: "v1.2.3", valid: true},
+		{tag: "1.2.3", canonical: "v1.2.3", valid: true},
+		{tag: "release-1.2.3", canonical: "v1.2.3", valid: true},
+		{tag: "release/v2.0.0-rc.1", canonical: "v2.0.0-rc.1", valid: true},
+		{tag: "V0.1.0", canonical: "v0.1.0", valid: true},
+		{tag: "release", valid: false},
+		{tag: "1.2", valid: false},
+		{tag: "release-1.2.3.4", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			version, ok := ParseLenientTagVersion(tt.tag)
+			if ok != tt.valid {
+				t.Fatalf("ParseLenientTagVersion(%q) ok = %v, expected %v", tt.tag, ok, tt.valid)
+			}
+			if !ok {
+				return
+			}
+			if version.Tag != tt.tag {
+				t.Errorf("Tag = %q, expected original %q", version.Tag, tt.tag)
+			}
+			canonical, _ := CanonicalTagName(tt.tag)
+			if canonical != tt.canonical {
+				t.Errorf("CanonicalTagName(%q) = %q, expected %q", tt.tag, canonical, tt.canonical)
+			}
+		})
+	}
+}
+
+// TestZeroVersion tests bumping from and detecting a literal v0.0.0 tag
+func TestZeroVersion(t *testing.T) {
+	tests := []struct {
+		bumpType    string
+		expectedTag string
+	}{
+		{bumpType: "patch", expectedTag: "v0.0.1"},
+		{bumpType: "minor", expectedTag: "v0.1.0"},
+		{bumpType: "major", expectedTag: "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bumpType, func(t *testing.T) {
+			nextTag, err := GetNextTag("v0.0.0", tt.bumpType, "")
+			if err != nil {
+				t.Fatalf("GetNextTag() error = %v", err)
+			}
+			if nextTag != tt.expectedTag {
+				t.Errorf("Expected nextTag to be '%s', got '%s'", tt.expectedTag, nextTag)
+			}
+		})
+	}
+
+	refs := []plumbing.Reference{
+		*plumbing.NewReferenceFromStrings("refs/tags/v0.0.0", "a670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf43"),
+		*plumbing.NewReferenceFromStrings("refs/tags/v0.0.0-alpha", "b670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf44"),
+	}
+	tag, err := GetLatestTag(NewMockReferenceIter(refs))
+	if err != nil {
+		t.Fatalf("GetLatestTag() error = %v", err)
+	}
+	if tag != "v0.0.0" {
+		t.Errorf("GetLatestTag() = %q, expected v0.0.0 to be a valid latest tag", tag)
+	}
+}
+
+// TestTagScannerDeduplicates tests that duplicate and peeled tags are counted once,
+// whether or not they are added next to each other, as ScanTagNames counts them
+func TestTagScannerDeduplicates(t *testing.T) {
+	names := []string{"v1.0.0", "v1.0.0", "v1.0.0^{}", "v1.1.0^{}", "nightly", "v1.1.0", "v1.0.0^{}", "nightly"}
+
+	scanner := NewTagScanner(nil)
+	for _, name := range names {
+		scanner.Add(name)
+	}
+	scan := scanner.Done()
+	if scanner.Latest() != "v1.1.0" {
+		t.Errorf("Latest() = %q, expected v1.1.0", scanner.Latest())
+	}
+	if scan.Scanned != len(names) || scan.Versions != 2 || !slices.Equal(scan.Skipped, []string{"nightly"}) {
+		t.Errorf("Done() = %+v, expected %d scanned, 2 versions, and nightly skipped", scan, len(names))
+	}
+	got := ScanTagNames(names)
+	if got.Scanned != scan.Scanned || got.Versions != scan.Versions || !slices.Equal(got.Skipped, scan.Skipped) {
+		t.Errorf("ScanTagNames() = %+v, expected the scanner's count %+v", got, scan)
+	}
+}
+
+// tagRefNames returns the short names of refs, in order.
+func tagRefNames(refs []plumbing.Reference) []string {
+	names := make([]string, len(refs))
+	for i := range refs {
+		names[i] = refs[i].Name().Short()
+	}
+	return names
+}
+
+// manyTagRefs returns n tag references covering releases, pre-releases, build
+// metadata, peeled duplicates, and non-version tags, in no particular order.
+func manyTagRefs(n int) []plumbing.Reference {
+	refs := make([]plumbing.Reference, 0, n)
+	for i := 0; len(refs) < n; i++ {
+		// Scatter the versions so the newest is not at either end
+		major, minor, patch := (i*7)%13, (i*11)%17, i%19
+		var name string
+		switch i % 5 {
+		case 0:
+			name = fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+		case 1:
+			name = fmt.Sprintf("v%d.%d.%d-rc.%d", major, minor, patch, i)
+		case 2:
+			name = fmt.Sprintf("v%d.%d.%d+build.%d", major, minor, patch, i)
+		case 3:
+			name = fmt.Sprintf("nightly-%d", i)
+		case 4:
+			name = fmt.Sprintf("v%d.%d.%d-beta.%d", major, minor, patch, i)
+			refs = append(refs, *plumbing.NewReferenceFromStrings("refs/tags/"+name, "a670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf43"))
+			name += "^{}"
+		}
+		refs = append(refs, *plumbing.NewReferenceFromStrings("refs/tags/"+name, "b670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf44"))
 	}
+	return refs
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			_, err := findGitRepoRoot(tt.startPath)
-			if (err != nil) != tt.expectError {
-				t.Errorf("findGitRepoRoot(%q) error = %v, expectError %v", tt.startPath, err, tt.expectError)
+// TestGetLatestTagMatchesSorted tests that the streaming maximum equals the first
+// entry of the fully sorted tag versions
+func TestGetLatestTagMatchesSorted(t *testing.T) {
+	for _, n := range []int{1, 10, 1000, 20000} {
+		t.Run(fmt.Sprintf("%d tags", n), func(t *testing.T) {
+			refs := manyTagRefs(n)
+			sorted := SortTagNames(tagRefNames(refs))
+
+			latest, err := GetLatestTag(NewMockReferenceIter(refs))
+			if err != nil {
+				t.Fatalf("GetLatestTag() error = %v", err)
+			}
+			if latest != sorted[0] {
+				t.Errorf("GetLatestTag() = %s, expected sorted[0] = %s", latest, sorted[0])
 			}
 		})
 	}
 }
 
-// TestGetLatestTagEmpty tests GetLatestTag with no valid tags
-func TestGetLatestTagEmpty(t *testing.T) {
-	// Create empty reference iterator
-	refs := []plumbing.Reference{}
-	tagRefs := NewMockReferenceIter(refs)
+// BenchmarkGetLatestTag compares finding the latest of many tags by streaming
+// against parsing and sorting all of them
+func BenchmarkGetLatestTag(b *testing.B) {
+	refs := manyTagRefs(20000)
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := GetLatestTag(NewMockReferenceIter(refs)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("sorted", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			SortTagNames(tagRefNames(refs))
+		}
+	})
+}
 
-	tag, err := GetLatestTag(tagRefs)
+// TestListRemoteTags tests parsing mocked git ls-remote output with peeled refs
+func TestListRemoteTags(t *testing.T) {
+	lsRemote := strings.Join([]string{
+		"a670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf43\trefs/tags/v1.0.0",
+		"b670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf44\trefs/tags/v1.0.0^{}",
+		"c670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf45\trefs/tags/v1.2.0",
+		"d670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf46\trefs/tags/v1.2.0^{}",
+		"e670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf47\trefs/tags/v1.10.0-rc.1^{}",
+		"f670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf48\trefs/tags/nightly",
+		"",
+	}, "\n")
+
+	orig := execCommand
+	defer func() { execCommand = orig }()
+	var gotArgs []string
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		gotArgs = arg
+		return exec.Command("printf", "%s", lsRemote)
+	}
+
+	tags, err := ListRemoteTags(".", "upstream")
 	if err != nil {
-		t.Errorf("GetLatestTag with empty tags should not error, got: %v", err)
+		t.Fatalf("ListRemoteTags error = %v", err)
 	}
-	if tag != "" {
-		t.Errorf("GetLatestTag with empty tags should return empty string, got: %s", tag)
+	if strings.Join(gotArgs, " ") != "ls-remote --tags upstream" {
+		t.Errorf("unexpected git arguments: %v", gotArgs)
 	}
-}
 
-// TestGetLatestTagNonSemVer tests GetLatestTag with non-semantic version tags
-func TestGetLatestTagNonSemVer(t *testing.T) {
-	// Create references with non-semver tags
-	refs := []plumbing.Reference{
-		*plumbing.NewReferenceFromStrings("refs/tags/release", "a670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf43"),
-		*plumbing.NewReferenceFromStrings("refs/tags/foo", "b670469b3e8a6e2e6d53635b3f3e6b1b8f6bcf44"),
+	expected := []string{"v1.0.0", "v1.2.0", "v1.10.0-rc.1", "nightly"}
+	if strings.Join(tags, ",") != strings.Join(expected, ",") {
+		t.Errorf("ListRemoteTags = %v, expected %v", tags, expected)
 	}
-	tagRefs := NewMockReferenceIter(refs)
 
-	tag, err := GetLatestTag(tagRefs)
-	if err != nil {
-		t.Errorf("GetLatestTag should handle non-semver tags gracefully, got error: %v", err)
+	if latest := LatestTagName(tags); latest != "v1.10.0-rc.1" {
+		t.Errorf("LatestTagName = %q, expected v1.10.0-rc.1", latest)
 	}
-	if tag != "" {
-		t.Errorf("GetLatestTag with only non-semver tags should return empty string, got: %s", tag)
+	if latest := LatestTagName([]string{"nightly"}); latest != "" {
+		t.Errorf("LatestTagName without versions = %q, expected empty", latest)
+	}
+
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		return exec.Command("false")
+	}
+	if _, err := ListRemoteTags(".", "upstream"); err == nil {
+		t.Error("ListRemoteTags should fail when git ls-remote fails")
 	}
 }
 
@@ -805,6 +2280,71 @@ func TestGetDefaultPushPreference(t *testing.T) {
 }
 
 // TestSetDefaultPushPreference tests the SetDefaultPushPreference function
+// TestValidateTagName tests git's reference name rules for tags
+func TestValidateTagName(t *testing.T) {
+	tests := map[string]bool{
+		"v1.2.3":          true,
+		"api/v1.2.3":      true,
+		"release-1.2.3":   true,
+		"v 1.2.3":         false,
+		"v..1.2.3":        false,
+		"api//v1.2.3":     false,
+		"/v1.2.3":         false,
+		"v1.2.3.":         false,
+		".hidden/v1.2.3":  false,
+		"api.lock/v1.2.3": false,
+		"v1.2.3~1":        false,
+		"v1:2:3":          false,
+		"v@{1.2.3":        false,
+		"v1.2.3\\tagged":  false,
+		"":                false,
+	}
+	for tag, valid := range tests {
+		if err := ValidateTagName(tag); (err == nil) != valid {
+			t.Errorf("ValidateTagName(%q) error = %v, expected valid %v", tag, err, valid)
+		}
+	}
+}
+
+// TestSetTagPrefix tests validating and writing the tag prefix
+func TestSetTagPrefix(t *testing.T) {
+	tests := []struct {
+		prefix      string
+		expected    string
+		expectError bool
+	}{
+		{prefix: "v", expected: "v"},
+		{prefix: "api/v", expected: "api/v"},
+		{prefix: " release- ", expected: "release-"},
+		{prefix: "my prefix", expectError: true},
+		{prefix: "api..v", expectError: true},
+		{prefix: "v*", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			repo := newTempRepo(t)
+			_, err := SetTagPrefix(repo, tt.prefix)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("SetTagPrefix(%q) error = %v, expectError %v", tt.prefix, err, tt.expectError)
+			}
+			value, isSet, err := GetConfigString(repo, "prefix")
+			if err != nil {
+				t.Fatalf("GetConfigString() error = %v", err)
+			}
+			if tt.expectError {
+				if isSet {
+					t.Errorf("prefix should not be written when rejected, got %q", value)
+				}
+				return
+			}
+			if value != tt.expected {
+				t.Errorf("prefix = %q, expected %q", value, tt.expected)
+			}
+		})
+	}
+}
+
 func TestSetDefaultPushPreference(t *testing.T) {
 	repo := newTempRepo(t)
 	tests := []struct {
@@ -841,7 +2381,7 @@ func TestSetDefaultPushPreference(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := SetDefaultPushPreference(tt.repoPath, tt.value)
+			_, err := SetDefaultPushPreference(tt.repoPath, tt.value)
 			if (err != nil) != tt.expectError {
 				t.Errorf("SetDefaultPushPreference(%q, %v) error = %v, expectError %v", tt.repoPath, tt.value, err, tt.expectError)
 			}
@@ -863,6 +2403,145 @@ func TestSetDefaultPushPreference(t *testing.T) {
 	}
 }
 
+// TestSetDefaultPushPreferenceUnchanged tests that setting the current value again
+// does not rewrite the config file
+func TestSetDefaultPushPreferenceUnchanged(t *testing.T) {
+	repo := newTempRepo(t)
+	configPath := filepath.Join(repo, ".git", "config")
+	changed, err := SetDefaultPushPreference(repo, true)
+	if err != nil || !changed {
+		t.Fatalf("SetDefaultPushPreference() = %v, %v; expected a change", changed, err)
+	}
+
+	// Backdate the file so a rewrite would be visible in its modification time
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(configPath, past, past); err != nil {
+		t.Fatalf("failed to backdate config: %v", err)
+	}
+
+	changed, err = SetDefaultPushPreference(repo, true)
+	if err != nil || changed {
+		t.Errorf("SetDefaultPushPreference() with the same value = %v, %v; expected no change", changed, err)
+	}
+	info, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("failed to stat config: %v", err)
+	}
+	if !info.ModTime().Equal(past) {
+		t.Errorf("config was rewritten: mtime = %v, expected %v", info.ModTime(), past)
+	}
+
+	if changed, err := SetDefaultPushPreference(repo, false); err != nil || !changed {
+		t.Errorf("SetDefaultPushPreference() with a new value = %v, %v; expected a change", changed, err)
+	}
+}
+
+// TestSetConfigStringKeepsComments tests that writing a setting leaves the rest of
+// the config file, including comments and include sections, as it was
+func TestSetConfigStringKeepsComments(t *testing.T) {
+	repo := newTempRepo(t)
+	configPath := filepath.Join(repo, ".git", "config")
+	extra := `# Team settings, keep in sync with the wiki
+[includeIf "gitdir:~/work/"]
+	path = ~/.gitconfig-work
+[bump]
+	; release straight from main
+	defaultPush = false
+`
+	file, err := os.OpenFile(configPath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open config: %v", err)
+	}
+	if _, err := file.WriteString(extra); err != nil {
+		t.Fatalf("failed to append to config: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("failed to close config: %v", err)
+	}
+
+	if _, err := SetDefaultPushPreference(repo, true); err != nil {
+		t.Fatalf("SetDefaultPushPreference() error = %v", err)
+	}
+	if _, err := SetConfigString(repo, "tagType", "lightweight"); err != nil {
+		t.Fatalf("SetConfigString() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	for _, line := range []string{
+		"# Team settings, keep in sync with the wiki",
+		`[includeIf "gitdir:~/work/"]`,
+		"\tpath = ~/.gitconfig-work",
+		"\t; release straight from main",
+	} {
+		if !strings.Contains(string(data), line) {
+			t.Errorf("config lost %q:\n%s", line, data)
+		}
+	}
+	if value, _, err := GetConfigString(repo, "defaultPush"); err != nil || value != "true" {
+		t.Errorf("defaultPush = %q, %v; expected true", value, err)
+	}
+	if value, _, err := GetConfigString(repo, "tagType"); err != nil || value != "lightweight" {
+		t.Errorf("tagType = %q, %v; expected lightweight", value, err)
+	}
+	if _, err := os.Stat(configPath + ".bump.tmp"); !os.IsNotExist(err) {
+		t.Error("SetConfigString() should not leave a temporary config file")
+	}
+}
+
+// TestPreviewDefaultPushPreference tests that previewing a config change reports the
+// change without writing the config file
+func TestPreviewDefaultPushPreference(t *testing.T) {
+	repo := newTempRepo(t)
+	configPath := filepath.Join(repo, ".git", "config")
+	if _, err := SetDefaultPushPreference(repo, false); err != nil {
+		t.Fatalf("SetDefaultPushPreference() error = %v", err)
+	}
+	before, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	beforeInfo, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("failed to stat config: %v", err)
+	}
+
+	changes, err := PreviewDefaultPushPreference(repo, true)
+	if err != nil {
+		t.Fatalf("PreviewDefaultPushPreference() error = %v", err)
+	}
+	expected := ConfigChange{Section: "bump", Key: "defaultPush", OldValue: "false", NewValue: "true", WasSet: true}
+	if len(changes) != 1 || changes[0] != expected {
+		t.Errorf("PreviewDefaultPushPreference() = %+v, expected [%+v]", changes, expected)
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	afterInfo, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("failed to stat config: %v", err)
+	}
+	if string(after) != string(before) || !afterInfo.ModTime().Equal(beforeInfo.ModTime()) {
+		t.Error("PreviewDefaultPushPreference() should not write the config file")
+	}
+	if _, err := os.Stat(configPath + ".bump.tmp"); !os.IsNotExist(err) {
+		t.Error("PreviewDefaultPushPreference() should not create a temporary config file")
+	}
+
+	// Previewing the current value reports no changes
+	changes, err = PreviewDefaultPushPreference(repo, false)
+	if err != nil {
+		t.Fatalf("PreviewDefaultPushPreference() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("PreviewDefaultPushPreference() = %+v, expected no changes", changes)
+	}
+}
+
 // TestSetDefaultPushPreferenceConfigMissing tests SetDefaultPushPreference when config file is missing
 func TestSetDefaultPushPreferenceConfigMissing(t *testing.T) {
 	repo := newTempRepo(t)
@@ -873,7 +2552,7 @@ func TestSetDefaultPushPreferenceConfigMissing(t *testing.T) {
 		t.Fatalf("failed to remove config: %v", err)
 	}
 
-	err := SetDefaultPushPreference(repo, true)
+	_, err := SetDefaultPushPreference(repo, true)
 	if err == nil {
 		t.Error("SetDefaultPushPreference should error when config file is missing")
 	}
@@ -896,55 +2575,358 @@ func TestSetDefaultPushPreferenceReadOnly(t *testing.T) {
 		if err := os.Chmod(configPath, 0o644); err != nil {
 			t.Logf("warning: failed to restore config permissions: %v", err)
 		}
-	}() // Restore permissions for cleanup
+	}() // Restore permissions for cleanup
+
+	// Make .git directory read-only to prevent temp file creation
+	gitDir := filepath.Join(repo, ".git")
+	if err := os.Chmod(gitDir, 0o555); err != nil {
+		t.Fatalf("failed to chmod .git: %v", err)
+	}
+	defer func() {
+		if err := os.Chmod(gitDir, 0o755); err != nil {
+			t.Logf("warning: failed to restore .git permissions: %v", err)
+		}
+	}() // Restore permissions for cleanup
+
+	_, err := SetDefaultPushPreference(repo, true)
+	if err == nil {
+		t.Error("SetDefaultPushPreference should error with read-only directory")
+	}
+}
+
+// TestGetDefaultPushPreferenceInvalidConfig tests GetDefaultPushPreference with invalid config content
+func TestGetDefaultPushPreferenceInvalidConfig(t *testing.T) {
+	repo := newTempRepo(t)
+	configPath := filepath.Join(repo, ".git", "config")
+
+	// Write invalid config value
+	cfg := "[bump]\ndefaultPush = invalid_value"
+	if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatalf("failed to write invalid config: %v", err)
+	}
+
+	_, _, err := GetDefaultPushPreference(repo)
+	if err == nil {
+		t.Error("GetDefaultPushPreference should error with invalid config value")
+	}
+}
+
+// TestGetDefaultPushPreferenceCorruptConfig tests GetDefaultPushPreference with corrupted config file
+func TestGetDefaultPushPreferenceCorruptConfig(t *testing.T) {
+	repo := newTempRepo(t)
+	configPath := filepath.Join(repo, ".git", "config")
+
+	// Write corrupted config (invalid INI syntax)
+	if err := os.WriteFile(configPath, []byte("[broken\n"), 0o644); err != nil {
+		t.Fatalf("failed to write corrupt config: %v", err)
+	}
+
+	_, _, err := GetDefaultPushPreference(repo)
+	if err == nil {
+		t.Error("GetDefaultPushPreference should error with corrupted config")
+	}
+}
+
+// TestDefaultPushPreferenceIncludeIf tests reading and writing preferences in a
+// config that uses git-specific syntax such as includeIf sections
+func TestDefaultPushPreferenceIncludeIf(t *testing.T) {
+	repo := newTempRepo(t)
+	configPath := filepath.Join(repo, ".git", "config")
+	content := `[core]
+	bare = false
+[includeIf "gitdir:~/work/"]
+	path = ~/.gitconfig-work
+[remote "origin"]
+	url = git@github.com:klauern/bump.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[bump]
+	defaultpush = true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	val, isSet, err := GetDefaultPushPreference(repo)
+	if err != nil {
+		t.Fatalf("GetDefaultPushPreference error = %v", err)
+	}
+	if !val || !isSet {
+		t.Errorf("GetDefaultPushPreference = (%v, %v), expected (true, true)", val, isSet)
+	}
+
+	if _, err := SetDefaultPushPreference(repo, false); err != nil {
+		t.Fatalf("SetDefaultPushPreference error = %v", err)
+	}
+	val, isSet, err = GetDefaultPushPreference(repo)
+	if err != nil || val || !isSet {
+		t.Errorf("GetDefaultPushPreference after set = (%v, %v, %v), expected (false, true, nil)", val, isSet, err)
+	}
+
+	written, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	for _, want := range []string{`[includeIf "gitdir:~/work/"]`, "path = ~/.gitconfig-work", `[remote "origin"]`} {
+		if !strings.Contains(string(written), want) {
+			t.Errorf("config lost %q after write:\n%s", want, written)
+		}
+	}
+	if strings.Count(strings.ToLower(string(written)), "defaultpush") != 1 {
+		t.Errorf("expected a single defaultPush key after write:\n%s", written)
+	}
+}
+
+// TestConfigFilePrecedence tests reading settings from --config-file before .git/config
+func TestConfigFilePrecedence(t *testing.T) {
+	t.Cleanup(func() { SetConfigFile("") })
+
+	repo := newTempRepo(t)
+	repoConfig := "[bump]\n\tdefaultPush = false\n\tnoPushOnPrerelease = true\n\tprefix = v\n"
+	if err := os.WriteFile(filepath.Join(repo, ".git", "config"), []byte(repoConfig), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	settings := filepath.Join(t.TempDir(), "bump.ini")
+	if err := os.WriteFile(settings, []byte("[bump]\n\tdefaultPush = true\n\tprefix = api/v\n"), 0o644); err != nil {
+		t.Fatalf("write settings file: %v", err)
+	}
+
+	SetConfigFile(settings)
+	if val, isSet, err := GetDefaultPushPreference(repo); err != nil || !isSet || !val {
+		t.Errorf("defaultPush = %v (set %v, err %v), expected true from the config file", val, isSet, err)
+	}
+	if prefix, _, err := GetConfigString(repo, "prefix"); err != nil || prefix != "api/v" {
+		t.Errorf("prefix = %q (err %v), expected api/v from the config file", prefix, err)
+	}
+	if val, isSet, err := GetConfigBool(repo, "noPushOnPrerelease"); err != nil || !isSet || !val {
+		t.Errorf("noPushOnPrerelease = %v (set %v, err %v), expected fallback to .git/config", val, isSet, err)
+	}
+
+	SetConfigFile("")
+	if val, _, err := GetDefaultPushPreference(repo); err != nil || val {
+		t.Errorf("defaultPush = %v (err %v), expected false from .git/config", val, err)
+	}
+
+	SetConfigFile(filepath.Join(t.TempDir(), "missing.ini"))
+	if _, _, err := GetDefaultPushPreference(repo); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+// TestRCFileSettings tests reading settings from a .bumprc or .versionrc between
+// --config-file and .git/config
+func TestRCFileSettings(t *testing.T) {
+	t.Cleanup(func() { SetConfigFile("") })
+
+	repo := newTempRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".git", "config"), []byte("[bump]\n\tdefaultPush = false\n\tprefix = v\n\ttagType = lightweight\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if RCFilePath(repo) != "" {
+		t.Errorf("RCFilePath() = %q without a settings file", RCFilePath(repo))
+	}
+
+	rc := `{"prefix": "api/v", "suffix": "beta", "push": true, "updateFiles": ["version.go"], "commitTemplate": "chore(release): {{.Version}}", "types": []}`
+	if err := os.WriteFile(filepath.Join(repo, ".versionrc"), []byte(rc), 0o644); err != nil {
+		t.Fatalf("write .versionrc: %v", err)
+	}
+	if got := RCFilePath(repo); got != filepath.Join(repo, ".versionrc") {
+		t.Errorf("RCFilePath() = %q, expected .versionrc", got)
+	}
+	for key, expected := range map[string]string{
+		"prefix":               "api/v",
+		"suffix":               "beta",
+		"updateFile":           "version.go",
+		"releaseCommitMessage": "chore(release): {{.Version}}",
+		"tagType":              "lightweight",
+	} {
+		if val, isSet, err := GetConfigString(repo, key); err != nil || !isSet || val != expected {
+			t.Errorf("GetConfigString(%s) = %q (set %v, err %v), expected %q", key, val, isSet, err, expected)
+		}
+	}
+	if val, isSet, err := GetDefaultPushPreference(repo); err != nil || !isSet || !val {
+		t.Errorf("defaultPush = %v (set %v, err %v), expected true from .versionrc", val, isSet, err)
+	}
+
+	// .bumprc is preferred over .versionrc, and --config-file over both
+	if err := os.WriteFile(filepath.Join(repo, ".bumprc"), []byte(`{"prefix": "rel-"}`), 0o644); err != nil {
+		t.Fatalf("write .bumprc: %v", err)
+	}
+	if prefix, _, err := GetConfigString(repo, "prefix"); err != nil || prefix != "rel-" {
+		t.Errorf("prefix = %q (err %v), expected rel- from .bumprc", prefix, err)
+	}
+	if _, isSet, _ := GetConfigString(repo, "suffix"); isSet {
+		t.Error("suffix should not be read from .versionrc once .bumprc exists")
+	}
+	settings := filepath.Join(t.TempDir(), "bump.ini")
+	if err := os.WriteFile(settings, []byte("[bump]\n\tprefix = team/v\n"), 0o644); err != nil {
+		t.Fatalf("write settings file: %v", err)
+	}
+	SetConfigFile(settings)
+	if prefix, _, err := GetConfigString(repo, "prefix"); err != nil || prefix != "team/v" {
+		t.Errorf("prefix = %q (err %v), expected team/v from the config file", prefix, err)
+	}
+	SetConfigFile("")
+
+	for _, content := range []string{`{"prefix": `, `{"updateFiles": ["a.go", "b.go"]}`} {
+		if err := os.WriteFile(filepath.Join(repo, ".bumprc"), []byte(content), 0o644); err != nil {
+			t.Fatalf("write .bumprc: %v", err)
+		}
+		if _, _, err := GetConfigString(repo, "prefix"); err == nil {
+			t.Errorf("GetConfigString() with .bumprc %s should fail", content)
+		}
+	}
+}
+
+// TestRCFileParsedOnce tests that the settings file is parsed once and only read
+// again after it changes
+func TestRCFileParsedOnce(t *testing.T) {
+	repo := newTempRepo(t)
+	rcPath := filepath.Join(repo, ".bumprc")
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write := func(content string, when time.Time) {
+		t.Helper()
+		if err := os.WriteFile(rcPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("write .bumprc: %v", err)
+		}
+		if err := os.Chtimes(rcPath, when, when); err != nil {
+			t.Fatalf("chtimes .bumprc: %v", err)
+		}
+	}
 
-	// Make .git directory read-only to prevent temp file creation
-	gitDir := filepath.Join(repo, ".git")
-	if err := os.Chmod(gitDir, 0o555); err != nil {
-		t.Fatalf("failed to chmod .git: %v", err)
+	write(`{"suffix": "beta"}`, modTime)
+	if suffix, _, err := GetConfigString(repo, "suffix"); err != nil || suffix != "beta" {
+		t.Fatalf("suffix = %q (err %v), expected beta", suffix, err)
 	}
-	defer func() {
-		if err := os.Chmod(gitDir, 0o755); err != nil {
-			t.Logf("warning: failed to restore .git permissions: %v", err)
-		}
-	}() // Restore permissions for cleanup
 
-	err := SetDefaultPushPreference(repo, true)
-	if err == nil {
-		t.Error("SetDefaultPushPreference should error with read-only directory")
+	// Same size and modification time: the parsed settings are reused
+	write(`{"suffix": "rc.1"}`, modTime)
+	if suffix, _, err := GetConfigString(repo, "suffix"); err != nil || suffix != "beta" {
+		t.Errorf("suffix = %q (err %v), expected the cached beta", suffix, err)
+	}
+
+	write(`{"suffix": "rc.1"}`, modTime.Add(time.Minute))
+	if suffix, _, err := GetConfigString(repo, "suffix"); err != nil || suffix != "rc.1" {
+		t.Errorf("suffix = %q (err %v), expected rc.1 after the file changed", suffix, err)
+	}
+
+	write(`{"suffix": }`, modTime.Add(2*time.Minute))
+	for range 2 {
+		if _, _, err := GetConfigString(repo, "tagType"); err == nil {
+			t.Error("GetConfigString() with a malformed .bumprc should fail on every lookup")
+		}
 	}
 }
 
-// TestGetDefaultPushPreferenceInvalidConfig tests GetDefaultPushPreference with invalid config content
-func TestGetDefaultPushPreferenceInvalidConfig(t *testing.T) {
-	repo := newTempRepo(t)
-	configPath := filepath.Join(repo, ".git", "config")
+// TestConfigSectionAlternate tests reading and writing preferences in an alternate section
+func TestConfigSectionAlternate(t *testing.T) {
+	t.Cleanup(func() { SetConfigSection("") })
 
-	// Write invalid config value
-	cfg := "[bump]\ndefaultPush = invalid_value"
-	if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
-		t.Fatalf("failed to write invalid config: %v", err)
+	tests := []struct {
+		name    string
+		section string
+		header  string
+	}{
+		{name: "Plain section", section: "release", header: "[release]"},
+		{name: "Dotted subsection", section: "tool.bump", header: `[tool "bump"]`},
+		{name: "Bracket subsection", section: `tool "bump"`, header: `[tool "bump"]`},
 	}
 
-	_, _, err := GetDefaultPushPreference(repo)
-	if err == nil {
-		t.Error("GetDefaultPushPreference should error with invalid config value")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTempRepo(t)
+			cfgPath := filepath.Join(repo, ".git", "config")
+			content := "[bump]\n\tdefaultPush = false\n" + tt.header + "\n\tdefaultPush = true\n"
+			if err := os.WriteFile(cfgPath, []byte(content), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			SetConfigSection(tt.section)
+			val, isSet, err := GetDefaultPushPreference(repo)
+			if err != nil {
+				t.Fatalf("GetDefaultPushPreference error = %v", err)
+			}
+			if !val || !isSet {
+				t.Errorf("GetDefaultPushPreference = (%v, %v), expected value from %s", val, isSet, tt.header)
+			}
+
+			if _, err := SetDefaultPushPreference(repo, false); err != nil {
+				t.Fatalf("SetDefaultPushPreference error = %v", err)
+			}
+			val, _, err = GetDefaultPushPreference(repo)
+			if err != nil || val {
+				t.Errorf("expected defaultPush=false after set, got %v (err %v)", val, err)
+			}
+
+			SetConfigSection("")
+			val, isSet, err = GetDefaultPushPreference(repo)
+			if err != nil || val || !isSet {
+				t.Errorf("default section should be untouched, got (%v, %v, %v)", val, isSet, err)
+			}
+		})
 	}
-}
 
-// TestGetDefaultPushPreferenceCorruptConfig tests GetDefaultPushPreference with corrupted config file
-func TestGetDefaultPushPreferenceCorruptConfig(t *testing.T) {
-	repo := newTempRepo(t)
-	configPath := filepath.Join(repo, ".git", "config")
+	if ConfigSection() != DefaultConfigSection {
+		t.Errorf("ConfigSection() = %q after reset, expected %q", ConfigSection(), DefaultConfigSection)
+	}
+}
 
-	// Write corrupted config (invalid INI syntax)
-	if err := os.WriteFile(configPath, []byte("[broken\n"), 0o644); err != nil {
-		t.Fatalf("failed to write corrupt config: %v", err)
+// TestMigrateConfig tests copying the bump section from one repository to another
+func TestMigrateConfig(t *testing.T) {
+	source := newTempRepo(t)
+	sourceConfig := "[core]\n\tbare = false\n[bump]\n\tdefaultPush = true\n\tprefix = api/v\n\ttagType = annotated\n"
+	if err := os.WriteFile(filepath.Join(source, ".git", "config"), []byte(sourceConfig), 0o644); err != nil {
+		t.Fatalf("write source config: %v", err)
+	}
+	values, err := ReadConfigSection(source)
+	if err != nil {
+		t.Fatalf("ReadConfigSection() error = %v", err)
+	}
+	if len(values) != 3 || values["prefix"] != "api/v" {
+		t.Fatalf("ReadConfigSection() = %v, expected the three bump settings", values)
 	}
 
-	_, _, err := GetDefaultPushPreference(repo)
-	if err == nil {
-		t.Error("GetDefaultPushPreference should error with corrupted config")
+	for _, overwrite := range []bool{false, true} {
+		t.Run(fmt.Sprintf("overwrite=%v", overwrite), func(t *testing.T) {
+			target := newTempRepo(t)
+			targetPath := filepath.Join(target, ".git", "config")
+			if err := os.WriteFile(targetPath, []byte("[bump]\n\tprefix = v\n\ttagType = annotated\n"), 0o644); err != nil {
+				t.Fatalf("write target config: %v", err)
+			}
+
+			// A dry run reports the changes without writing them
+			changes, _, err := MigrateConfig(target, values, overwrite, true)
+			if err != nil {
+				t.Fatalf("MigrateConfig() dry run error = %v", err)
+			}
+			if prefix, _, _ := GetConfigString(target, "prefix"); prefix != "v" {
+				t.Errorf("dry run wrote prefix %q", prefix)
+			}
+
+			applied, skipped, err := MigrateConfig(target, values, overwrite, false)
+			if err != nil {
+				t.Fatalf("MigrateConfig() error = %v", err)
+			}
+			if len(applied) != len(changes) {
+				t.Errorf("MigrateConfig() made %d changes, dry run reported %d", len(applied), len(changes))
+			}
+			if push, isSet, err := GetDefaultPushPreference(target); err != nil || !isSet || !push {
+				t.Errorf("defaultPush = %v (set %v, err %v), expected it copied", push, isSet, err)
+			}
+
+			expectedPrefix, expectedSkipped := "v", []string{"prefix"}
+			if overwrite {
+				expectedPrefix, expectedSkipped = "api/v", nil
+			}
+			if prefix, _, _ := GetConfigString(target, "prefix"); prefix != expectedPrefix {
+				t.Errorf("prefix = %q, expected %q", prefix, expectedPrefix)
+			}
+			if strings.Join(skipped, ",") != strings.Join(expectedSkipped, ",") {
+				t.Errorf("skipped = %v, expected %v", skipped, expectedSkipped)
+			}
+			if _, err := os.Stat(targetPath + ".bump.tmp"); !os.IsNotExist(err) {
+				t.Errorf("temporary config left behind: %v", err)
+			}
+		})
 	}
 }
 
@@ -1116,6 +3098,158 @@ func TestAcquireGitLockSuccess(t *testing.T) {
 	}
 }
 
+// TestAcquireGitLockCustomPath tests relocating the lock with the lockFile setting and LockFileEnv
+func TestAcquireGitLockCustomPath(t *testing.T) {
+	repo := newTempRepo(t)
+	lockDir := t.TempDir()
+	configPath := filepath.Join(repo, ".git", "config")
+	configured := filepath.Join(lockDir, "from-config.lock")
+	if err := os.WriteFile(configPath, []byte("[bump]\n\tlockFile = "+configured+"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	override := filepath.Join(lockDir, "from-env.lock")
+	for _, tt := range []struct {
+		name     string
+		override string
+		expected string
+	}{
+		{name: "Setting", expected: configured},
+		{name: "Override takes precedence", override: override, expected: override},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(LockFileEnv, tt.override)
+
+			lock, err := acquireGitLock(repo)
+			if err != nil {
+				t.Fatalf("acquireGitLock error = %v", err)
+			}
+			if _, err := os.Stat(tt.expected); err != nil {
+				t.Errorf("expected lock file at %s: %v", tt.expected, err)
+			}
+			if _, err := os.Stat(filepath.Join(repo, ".git", "bump.lock")); !os.IsNotExist(err) {
+				t.Error("default lock file should not be created when the lock is relocated")
+			}
+			if err := lock.Release(); err != nil {
+				t.Errorf("Release error = %v", err)
+			}
+			if _, err := os.Stat(tt.expected); !os.IsNotExist(err) {
+				t.Error("relocated lock file should be removed after release")
+			}
+		})
+	}
+
+	// A lock in a directory that does not exist is rejected up front
+	t.Setenv(LockFileEnv, filepath.Join(lockDir, "missing", "bump.lock"))
+	if _, err := acquireGitLock(repo); err == nil || !strings.Contains(err.Error(), "invalid lock file") {
+		t.Errorf("acquireGitLock error = %v, expected invalid lock file error", err)
+	}
+
+	// So is a lock in a directory bump cannot create files in
+	if os.Geteuid() != 0 {
+		readOnly := filepath.Join(lockDir, "read-only")
+		if err := os.Mkdir(readOnly, 0o555); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		t.Setenv(LockFileEnv, filepath.Join(readOnly, "bump.lock"))
+		if _, err := acquireGitLock(repo); err == nil || !strings.Contains(err.Error(), "cannot create files in") {
+			t.Errorf("acquireGitLock error = %v, expected a not writable error", err)
+		}
+		if err := os.Chmod(readOnly, 0o755); err != nil {
+			t.Fatalf("chmod: %v", err)
+		}
+	}
+}
+
+// TestAcquireGitLockUnreadableSetting tests that a lockFile setting that cannot be
+// read fails instead of falling back to the default lock file
+func TestAcquireGitLockUnreadableSetting(t *testing.T) {
+	repo := newTempRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".bumprc"), []byte(`{"suffix": }`), 0o644); err != nil {
+		t.Fatalf("write .bumprc: %v", err)
+	}
+	t.Setenv(LockFileEnv, "")
+
+	if _, err := gitLockPath(repo, filepath.Join(repo, ".git")); err == nil || !strings.Contains(err.Error(), "failed to read the lockFile setting") {
+		t.Errorf("gitLockPath error = %v, expected a lockFile setting error", err)
+	}
+	if _, err := acquireGitLock(repo); err == nil {
+		t.Error("acquireGitLock() with an unreadable setting should fail")
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".git", "bump.lock")); !os.IsNotExist(err) {
+		t.Error("default lock file should not be created when the setting cannot be read")
+	}
+}
+
+// TestAcquireGitLockFlock tests the flock backend, skipping on platforms without flock
+func TestAcquireGitLockFlock(t *testing.T) {
+	probe, err := os.Create(filepath.Join(t.TempDir(), "probe.lock"))
+	if err != nil {
+		t.Fatalf("create probe: %v", err)
+	}
+	defer probe.Close()
+	if _, err := tryFlock(probe); errors.Is(err, errFlockUnsupported) {
+		t.Skip("flock is not supported on this platform")
+	}
+
+	repo := newTempRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".git", "config"), []byte("[bump]\n\tlockBackend = flock\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	lockPath := filepath.Join(repo, ".git", "bump.lock")
+
+	lock, err := acquireGitLock(repo)
+	if err != nil {
+		t.Fatalf("acquireGitLock error = %v", err)
+	}
+	if lock.flock == nil {
+		t.Fatal("expected the flock backend to hold the lock file open")
+	}
+
+	// Another open file description cannot lock the file while it is held
+	other, err := os.Open(lockPath)
+	if err != nil {
+		t.Fatalf("open lock file: %v", err)
+	}
+	defer other.Close()
+	if locked, err := tryFlock(other); err != nil || locked {
+		t.Errorf("tryFlock while held = %v, %v, expected false", locked, err)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Errorf("Release error = %v", err)
+	}
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Errorf("flock lock file should be kept after release: %v", err)
+	}
+	if locked, err := tryFlock(other); err != nil || !locked {
+		t.Errorf("tryFlock after release = %v, %v, expected true", locked, err)
+	}
+	if err := unflock(other); err != nil {
+		t.Errorf("unflock error = %v", err)
+	}
+
+	// An existing lock file does not block the flock backend
+	lock, err = acquireGitLock(repo)
+	if err != nil {
+		t.Fatalf("acquireGitLock with existing lock file error = %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Errorf("Release error = %v", err)
+	}
+}
+
+// TestAcquireGitLockInvalidBackend tests rejecting an unknown lockBackend
+func TestAcquireGitLockInvalidBackend(t *testing.T) {
+	repo := newTempRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".git", "config"), []byte("[bump]\n\tlockBackend = nfs\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := acquireGitLock(repo); err == nil || !strings.Contains(err.Error(), "invalid lockBackend") {
+		t.Errorf("acquireGitLock error = %v, expected invalid lockBackend error", err)
+	}
+}
+
 // TestAcquireGitLockStaleLockCleanup tests stale lock detection and removal
 func TestAcquireGitLockStaleLockCleanup(t *testing.T) {
 	repo := newTempRepo(t)
@@ -1152,6 +3286,219 @@ func TestAcquireGitLockStaleLockCleanup(t *testing.T) {
 	}
 }
 
+// TestTagPattern tests restricting the tags that count as releases
+func TestTagPattern(t *testing.T) {
+	names := []string{"v1.2.0", "v1.3.0-rc.1", "v1.3.0-rc.1^{}", "v2.0.0", "v2.1.0-beta.1"}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected []string
+	}{
+		{
+			name:     "No pattern",
+			expected: []string{"v2.1.0-beta.1", "v2.0.0", "v1.3.0-rc.1", "v1.2.0"},
+		},
+		{
+			name:     "Exclude pre-releases",
+			pattern:  `^v\d+\.\d+\.\d+$`,
+			expected: []string{"v2.0.0", "v1.2.0"},
+		},
+		{
+			name:     "Select a major line",
+			pattern:  `^v1\.`,
+			expected: []string{"v1.3.0-rc.1", "v1.2.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, err := NewTagScheme(TagSchemeOptions{TagPattern: tt.pattern})
+			if err != nil {
+				t.Fatalf("NewTagScheme() unexpected error = %v", err)
+			}
+			if got := scheme.SortTagNames(names); !slices.Equal(got, tt.expected) {
+				t.Errorf("SortTagNames() = %v, expected %v", got, tt.expected)
+			}
+			if got := scheme.LatestTagName(names); got != tt.expected[0] {
+				t.Errorf("LatestTagName() = %q, expected %q", got, tt.expected[0])
+			}
+		})
+	}
+
+	if _, err := NewTagScheme(TagSchemeOptions{TagPattern: "v1.("}); err == nil {
+		t.Error("NewTagScheme() should reject an invalid tag pattern")
+	}
+}
+
+// TestChannelOrder tests ordering pre-release channels by a configured rank
+func TestChannelOrder(t *testing.T) {
+	names := []string{"v1.0.0-rc.1", "v1.0.0-dev.2", "v1.0.0-beta.1", "v1.0.0-alpha.1", "v1.0.0-dev.1", "v1.0.0", "v1.0.0-snapshot.1"}
+
+	if got := SortTagNames(names); got[0] != "v1.0.0" || got[1] != "v1.0.0-snapshot.1" {
+		t.Errorf("SortTagNames() without an order = %v, expected lexical order", got)
+	}
+
+	scheme, err := NewTagScheme(TagSchemeOptions{ChannelOrder: []string{"dev", "alpha", "beta", "rc"}})
+	if err != nil {
+		t.Fatalf("NewTagScheme() unexpected error = %v", err)
+	}
+	expected := []string{"v1.0.0", "v1.0.0-snapshot.1", "v1.0.0-rc.1", "v1.0.0-beta.1", "v1.0.0-alpha.1", "v1.0.0-dev.2", "v1.0.0-dev.1"}
+	if got := scheme.SortTagNames(names); !slices.Equal(got, expected) {
+		t.Errorf("SortTagNames() = %v, expected %v", got, expected)
+	}
+	for _, pair := range [][2]string{{"-alpha.1", "-dev.9"}, {"-beta", "-alpha.3"}, {"-rc.1", "-beta.2"}} {
+		if !compareSuffixes(pair[0], pair[1], scheme.channelRanks) || compareSuffixes(pair[1], pair[0], scheme.channelRanks) {
+			t.Errorf("expected %s > %s under the configured order", pair[0], pair[1])
+		}
+	}
+	if next, err := scheme.nextChannelSuffix("dev.3", "alpha"); err != nil || next != "alpha.1" {
+		t.Errorf("nextChannelSuffix(dev.3, alpha) = %q, %v; expected alpha.1", next, err)
+	}
+
+	for _, order := range [][]string{{"dev", ""}, {"dev", "1"}, {"dev", "dev"}, {"rc.1"}} {
+		if _, err := NewTagScheme(TagSchemeOptions{ChannelOrder: order}); err == nil {
+			t.Errorf("NewTagScheme() with channel order %q should fail", order)
+		}
+	}
+}
+
+// TestReadTagScheme tests building a TagScheme from a repository's settings
+func TestReadTagScheme(t *testing.T) {
+	repo := newTempRepo(t)
+	names := []string{"v1.1.0", "v1.2.0_rc.1", "v2.0.0", "api/v1.1.0", "api/v1.2.0_rc.1", "api/v2.0.0"}
+
+	scheme, err := ReadTagScheme(repo)
+	if err != nil {
+		t.Fatalf("ReadTagScheme() unexpected error = %v", err)
+	}
+	if got := scheme.LatestTagName(names); got != "v2.0.0" {
+		t.Errorf("LatestTagName() without settings = %s, expected v2.0.0", got)
+	}
+
+	for key, value := range map[string]string{"prefix": "api/v", "suffixSeparator": "_", "tagPattern": `^api/v1\.`, "channelOrder": "alpha, rc"} {
+		if _, err := SetConfigString(repo, key, value); err != nil {
+			t.Fatalf("SetConfigString(%s) error = %v", key, err)
+		}
+	}
+	opts, err := ReadTagSchemeOptions(repo)
+	if err != nil {
+		t.Fatalf("ReadTagSchemeOptions() unexpected error = %v", err)
+	}
+	if opts.Prefix != "api/v" || opts.SuffixSeparator != "_" || opts.TagPattern != `^api/v1\.` || !slices.Equal(opts.ChannelOrder, []string{"alpha", " rc"}) {
+		t.Errorf("ReadTagSchemeOptions() = %+v", opts)
+	}
+	if scheme, err = ReadTagScheme(repo); err != nil {
+		t.Fatalf("ReadTagScheme() unexpected error = %v", err)
+	}
+	if got := scheme.LatestTagName(names); got != "api/v1.2.0_rc.1" {
+		t.Errorf("LatestTagName() with settings = %s, expected api/v1.2.0_rc.1", got)
+	}
+
+	if _, err := SetConfigString(repo, "channelOrder", "rc,rc"); err != nil {
+		t.Fatalf("SetConfigString() error = %v", err)
+	}
+	if _, err := ReadTagScheme(repo); err == nil {
+		t.Error("ReadTagScheme() should reject an invalid channel order")
+	}
+}
+
+// TestReadLock tests reading the holder of a lock file and removing it
+func TestReadLock(t *testing.T) {
+	repo := newTempRepo(t)
+	if holder, err := ReadLock(repo); err != nil || holder != nil {
+		t.Fatalf("ReadLock() without a lock = %+v, %v; expected nil", holder, err)
+	}
+
+	lock, err := acquireGitLock(repo)
+	if err != nil {
+		t.Fatalf("acquireGitLock() unexpected error = %v", err)
+	}
+	holder, err := ReadLock(repo)
+	if err != nil {
+		t.Fatalf("ReadLock() unexpected error = %v", err)
+	}
+	if holder == nil || holder.PID != os.Getpid() || !holder.Alive || holder.Time.IsZero() {
+		t.Errorf("ReadLock() = %+v, expected this running process", holder)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() unexpected error = %v", err)
+	}
+
+	lockPath := filepath.Join(repo, ".git", "bump.lock")
+	if err := os.WriteFile(lockPath, []byte("pid: 99999999\ntime: 2024-01-02T03:04:05Z\n"), 0o644); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+	holder, err = ReadLock(repo)
+	if err != nil {
+		t.Fatalf("ReadLock() unexpected error = %v", err)
+	}
+	expected := &LockHolder{Path: lockPath, PID: 99999999, Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	if holder == nil || holder.Path != expected.Path || holder.PID != expected.PID || !holder.Time.Equal(expected.Time) || holder.Alive {
+		t.Errorf("ReadLock() = %+v, expected %+v", holder, expected)
+	}
+	if err := RemoveLock(holder); err != nil {
+		t.Fatalf("RemoveLock() unexpected error = %v", err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Error("lock file should be removed")
+	}
+}
+
+// TestAcquireGitLockBusy tests that a lock held by another process reports ErrLockBusy
+func TestAcquireGitLockBusy(t *testing.T) {
+	repo := newTempRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".git", "bump.lock"), []byte("pid: 1\n"), 0o644); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+
+	_, err := acquireGitLock(repo)
+	if !errors.Is(err, ErrLockBusy) {
+		t.Errorf("acquireGitLock() error = %v, expected ErrLockBusy", err)
+	}
+}
+
+// TestHandleInterrupt tests that an interrupt releases a held lock before exiting
+func TestHandleInterrupt(t *testing.T) {
+	repo := newTempRepo(t)
+	var exitCode int
+	exitFunc = func(code int) { exitCode = code }
+	defer func() { exitFunc = os.Exit }()
+
+	lock, err := acquireGitLock(repo)
+	if err != nil {
+		t.Fatalf("acquireGitLock error = %v", err)
+	}
+	lockFile := filepath.Join(repo, ".git", "bump.lock")
+	if _, err := os.Stat(lockFile); err != nil {
+		t.Fatalf("expected lock file: %v", err)
+	}
+
+	handleInterrupt(syscall.SIGINT)
+
+	if _, err := os.Stat(lockFile); !os.IsNotExist(err) {
+		t.Error("lock file should be removed on interrupt")
+	}
+	if exitCode != 130 {
+		t.Errorf("exit code = %d, expected 130", exitCode)
+	}
+	if err := lock.Release(); err != nil {
+		t.Errorf("Release() after interrupt should not error, got: %v", err)
+	}
+
+	// The repository can be locked again, and the handler is removed once it is released
+	lock, err = acquireGitLock(repo)
+	if err != nil {
+		t.Fatalf("acquireGitLock after interrupt error = %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if interrupts != nil {
+		t.Error("interrupt handler should be removed when no lock is held")
+	}
+}
+
 // TestGitLockReleaseNotAcquired tests Release on a lock that wasn't acquired
 func TestGitLockReleaseNotAcquired(t *testing.T) {
 	lock := &GitLock{
@@ -1255,14 +3602,32 @@ func TestParseTagVersionEdgeCases(t *testing.T) {
 			tag:      "",
 			expectOk: false,
 		},
+		{
+			name:     "Leading and trailing whitespace",
+			tag:      " v1.2.3 ",
+			expectOk: true,
+		},
+		{
+			name:     "Trailing newline",
+			tag:      "v1.2.3-rc.1\n",
+			expectOk: true,
+		},
+		{
+			name:     "Invalid - internal whitespace",
+			tag:      "v1.2.3 -rc.1",
+			expectOk: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, ok := ParseTagVersion(tt.tag)
+			version, ok := ParseTagVersion(tt.tag)
 			if ok != tt.expectOk {
 				t.Errorf("ParseTagVersion(%q) ok = %v, expected %v", tt.tag, ok, tt.expectOk)
 			}
+			if ok && version.Tag != strings.TrimSpace(tt.tag) {
+				t.Errorf("ParseTagVersion(%q).Tag = %q, expected surrounding whitespace trimmed", tt.tag, version.Tag)
+			}
 		})
 	}
 }
@@ -1315,7 +3680,7 @@ func TestCompareVersionsWithSuffixes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := compareVersions(tt.v1, tt.v2)
+			result := compareVersions(tt.v1, tt.v2, nil)
 			if result != tt.expected {
 				t.Errorf("compareVersions() = %v, expected %v", result, tt.expected)
 			}
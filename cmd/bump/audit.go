@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/klauern/bump"
+)
+
+// AuditEntry is the JSON line appended to the audit log after each bump.
+type AuditEntry struct {
+	Who      string `json:"who"`  // Git identity as "Name <email>", empty when unset
+	When     string `json:"when"` // RFC 3339, UTC
+	From     string `json:"from"` // Previous tag, empty for the first release
+	To       string `json:"to"`
+	Pushed   bool   `json:"pushed"`
+	RepoPath string `json:"repoPath"`
+}
+
+// appendAuditLog appends entry to the audit log at path as a single JSON line.
+func appendAuditLog(path string, entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	return bump.AppendLocked(path, append(line, '\n'))
+}
@@ -1,32 +1,810 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"go/token"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/go-git/go-git/v5"
 	"github.com/klauern/bump"
 )
 
 // calculateNextVersion determines the next semantic version tag based on the latest tag,
-// bump type (patch/minor/major), and optional suffix.
+// bump type (patch/minor/major), and suffix options. An empty latestTag means the
+// repository has no version tags and starts at v0.1.0; an existing v0.0.0 tag is
+// bumped like any other (patch gives v0.0.1). Whitespace around latestTag is ignored.
 // This is a pure function with no I/O dependencies.
-func calculateNextVersion(latestTag, bumpType, suffix string) (string, error) {
+func calculateNextVersion(scheme bump.TagScheme, latestTag, bumpType string, opts bump.NextTagOptions) (string, error) {
+	latestTag = strings.TrimSpace(latestTag)
 	if latestTag == "" {
-		return "v0.1.0", nil
+		return scheme.Prefix() + "0.1.0", nil
 	}
-	return bump.GetNextTag(latestTag, bumpType, suffix)
+	return scheme.GetNextTagWithOptions(latestTag, bumpType, opts)
+}
+
+// VersionCandidate is the version a bump type would produce, as shown by bump preview.
+type VersionCandidate struct {
+	BumpType string
+	Version  string
+}
+
+// previewVersions computes the next version for each bump type from latestTag.
+// A prerelease candidate is included when latestTag is a pre-release or a
+// pre-release suffix is given; it starts or advances that series.
+// This is a pure function with no I/O dependencies.
+func previewVersions(scheme bump.TagScheme, latestTag, suffix string) ([]VersionCandidate, error) {
+	var candidates []VersionCandidate
+	for _, bumpType := range []string{"patch", "minor", "major"} {
+		version, err := calculateNextVersion(scheme, latestTag, bumpType, bump.NextTagOptions{})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, VersionCandidate{BumpType: bumpType, Version: version})
+	}
+	if latestTag != "" && (suffix != "" || isPrerelease(scheme, latestTag)) {
+		version, err := calculateNextVersion(scheme, latestTag, "prerelease", bump.NextTagOptions{Suffix: suffix})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, VersionCandidate{BumpType: "prerelease", Version: version})
+	}
+	return candidates, nil
+}
+
+// formatPreview renders the latest tag and the candidate next versions as a table.
+// This is a pure function with no I/O dependencies.
+func formatPreview(latestTag string, candidates []VersionCandidate) string {
+	current := latestTag
+	if current == "" {
+		current = "(no tags)"
+	}
+	width := len("current")
+	for _, candidate := range candidates {
+		width = max(width, len(candidate.BumpType))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s  %s\n", width, "current", current)
+	for _, candidate := range candidates {
+		fmt.Fprintf(&b, "%-*s  %s\n", width, candidate.BumpType, candidate.Version)
+	}
+	return b.String()
+}
+
+// ConfigSetting is a bump setting shown by bump status.
+type ConfigSetting struct {
+	Key   string // Setting name within the bump config section
+	Value string // Configured value; empty when unset
+	Set   bool   // Whether the setting is configured
+}
+
+// RepoStatus summarizes the release state of a repository for bump status.
+type RepoStatus struct {
+	LatestTag    string             // Latest version tag; empty when there are none
+	Candidates   []VersionCandidate // Next version for each bump type
+	Changed      []string           // Paths with uncommitted changes to tracked files
+	CommitsSince int                // Non-merge commits on HEAD since LatestTag
+	Settings     []ConfigSetting    // Bump settings in display order
+}
+
+// formatStatus renders a repository status as aligned "label: value" lines.
+// This is a pure function with no I/O dependencies.
+func formatStatus(status RepoStatus) string {
+	latest := status.LatestTag
+	if latest == "" {
+		latest = "(no tags)"
+	}
+	tree := "clean"
+	if len(status.Changed) > 0 {
+		tree = fmt.Sprintf("dirty (%d changed: %s)", len(status.Changed), strings.Join(status.Changed, ", "))
+	}
+
+	lines := [][2]string{{"Latest tag", latest}}
+	for _, candidate := range status.Candidates {
+		lines = append(lines, [2]string{"Next " + candidate.BumpType, candidate.Version})
+	}
+	lines = append(lines,
+		[2]string{"Working tree", tree},
+		[2]string{"Commits since tag", strconv.Itoa(status.CommitsSince)},
+	)
+	for _, setting := range status.Settings {
+		value := setting.Value
+		if !setting.Set {
+			value = "(not set)"
+		}
+		lines = append(lines, [2]string{setting.Key, value})
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line[0]))
+	}
+	var b strings.Builder
+	for _, line := range lines {
+		fmt.Fprintf(&b, "%-*s  %s\n", width+1, line[0]+":", line[1])
+	}
+	return b.String()
+}
+
+// SuffixTemplateData holds the values available to --suffix templates.
+type SuffixTemplateData struct {
+	Date     string // Current UTC date as YYYYMMDD
+	ShortSHA string // Abbreviated hash of HEAD
+	SHA      string // Full hash of HEAD
+}
+
+// renderSuffix expands template tokens such as {{.Date}} or {{.ShortSHA}} in a
+// suffix. Suffixes without tokens are returned unchanged.
+// This is a pure function with no I/O dependencies.
+func renderSuffix(suffix string, data SuffixTemplateData) (string, error) {
+	if !strings.Contains(suffix, "{{") {
+		return suffix, nil
+	}
+	tmpl, err := template.New("suffix").Option("missingkey=error").Parse(suffix)
+	if err != nil {
+		return "", fmt.Errorf("invalid suffix template %q: %w", suffix, err)
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("invalid suffix template %q: %w", suffix, err)
+	}
+	return rendered.String(), nil
+}
+
+// Versions --file-version can write to --update-file after tagging.
+const (
+	fileVersionDev     = "dev"     // The development version that follows the tag (v1.2.3 -> 1.2.4-dev)
+	fileVersionRelease = "release" // The released version itself (v1.2.3 -> 1.2.3)
+)
+
+// defaultReleaseCommitMessage is the message template used by --release-commit.
+const defaultReleaseCommitMessage = "Release {{.Tag}}"
+
+// ReleaseCommitData holds the values available to --release-commit-message templates.
+type ReleaseCommitData struct {
+	Tag     string // The new tag, e.g. v1.2.3
+	Version string // The new tag without its prefix, e.g. 1.2.3
+}
+
+// renderReleaseCommitMessage expands a release commit message template for the
+// given tag. An empty template falls back to defaultReleaseCommitMessage.
+// This is a pure function with no I/O dependencies.
+func renderReleaseCommitMessage(scheme bump.TagScheme, message, tag string) (string, error) {
+	if message == "" {
+		message = defaultReleaseCommitMessage
+	}
+	tmpl, err := template.New("release-commit").Option("missingkey=error").Parse(message)
+	if err != nil {
+		return "", fmt.Errorf("invalid release commit message template %q: %w", message, err)
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, ReleaseCommitData{Tag: tag, Version: scheme.StripPrefix(tag)}); err != nil {
+		return "", fmt.Errorf("invalid release commit message template %q: %w", message, err)
+	}
+	if strings.TrimSpace(rendered.String()) == "" {
+		return "", fmt.Errorf("release commit message template %q renders an empty message", message)
+	}
+	return rendered.String(), nil
+}
+
+// SuffixLimits bounds the size of a pre-release suffix. A zero field is unlimited.
+type SuffixLimits struct {
+	MaxLength      int  // Maximum length of the suffix in characters
+	MaxIdentifiers int  // Maximum number of dot-separated identifiers
+	LenientZero    bool // Accept numeric identifiers with leading zeros ("01"), as legacy tags may use
+}
+
+// defaultSuffixLimits are generous enough for any hand-written suffix while still
+// catching runaway templates.
+var defaultSuffixLimits = SuffixLimits{MaxLength: 64, MaxIdentifiers: 8}
+
+// validateSuffix checks that a suffix is a valid SemVer pre-release: one or more
+// dot-separated, non-empty identifiers made of ASCII letters, digits, and dashes,
+// within the given limits. Numeric identifiers must not have leading zeros
+// ("alpha.01") unless limits.LenientZero is set.
+// This is a pure function with no I/O dependencies.
+func validateSuffix(suffix string, limits SuffixLimits) error {
+	if limits.MaxLength > 0 && len(suffix) > limits.MaxLength {
+		return fmt.Errorf("invalid suffix %q: %d characters exceeds the limit of %d", suffix, len(suffix), limits.MaxLength)
+	}
+	ids := strings.Split(suffix, ".")
+	if limits.MaxIdentifiers > 0 && len(ids) > limits.MaxIdentifiers {
+		return fmt.Errorf("invalid suffix %q: %d identifiers exceeds the limit of %d", suffix, len(ids), limits.MaxIdentifiers)
+	}
+	for _, id := range ids {
+		if id == "" {
+			return fmt.Errorf("invalid suffix %q: empty identifier", suffix)
+		}
+		for _, ch := range id {
+			if !(ch == '-' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z') {
+				return fmt.Errorf("invalid suffix %q: identifiers may contain only letters, digits, and dashes", suffix)
+			}
+		}
+		if !limits.LenientZero && len(id) > 1 && id[0] == '0' && strings.Trim(id, "0123456789") == "" {
+			return fmt.Errorf("invalid suffix %q: numeric identifier %s has a leading zero (use --lenient-zero for legacy tags)", suffix, id)
+		}
+	}
+	return nil
 }
 
 // calculateDevVersion generates a development version string from a tag.
 // It parses the tag and increments the patch version with a "-dev" suffix.
 // This is a pure function with no I/O dependencies.
-func calculateDevVersion(tag string) (string, error) {
-	version, ok := bump.ParseTagVersion(tag)
+func calculateDevVersion(scheme bump.TagScheme, tag string) (string, error) {
+	version, ok := scheme.ParseTagVersion(tag)
 	if !ok {
 		return "", fmt.Errorf("failed to parse tag: %s", tag)
 	}
 	return fmt.Sprintf("%d.%d.%d-dev", version.Major, version.Minor, version.Patch+1), nil
 }
 
+// isDevTag reports whether tag is a development snapshot tag as created by bump
+// dev: a version whose pre-release is exactly dev.N (v1.2.4-dev.3), the shape
+// nextDevTag produces. Other pre-releases on a "dev" channel, such as v1.2.0-dev
+// or v1.2.0-dev.1.rc, are ordinary pre-releases.
+// This is a pure function with no I/O dependencies.
+func isDevTag(scheme bump.TagScheme, tag string) bool {
+	version, ok := scheme.ParseTagVersion(tag)
+	if !ok || version.Build != "" {
+		return false
+	}
+	counter, ok := strings.CutPrefix(version.Suffix, "-dev.")
+	return ok && counter != "" && strings.Trim(counter, "0123456789") == ""
+}
+
+// isDevTagIn returns isDevTag for tags under scheme, as a TagScanner exclude function.
+func isDevTagIn(scheme bump.TagScheme) func(tag string) bool {
+	return func(tag string) bool {
+		return isDevTag(scheme, tag)
+	}
+}
+
+// latestBaseTagName returns the latest of names that is not a bump dev snapshot:
+// the tag a core bump starts from, or an empty string if there is none.
+// This is a pure function with no I/O dependencies.
+func latestBaseTagName(scheme bump.TagScheme, names []string) string {
+	scanner := scheme.NewTagScanner(isDevTagIn(scheme))
+	for _, name := range names {
+		scanner.Add(name)
+	}
+	scanner.Done()
+	return scanner.LatestIncluded()
+}
+
+// nextDevTag returns the snapshot tag for a commit that is commits commits past
+// baseTag: the development version calculateDevVersion derives, numbered by the
+// commit count (v1.2.3 and 4 commits give v1.2.4-dev.4), so it sorts below the
+// next patch release. Without a base tag it precedes the first release, v0.1.0.
+// The tag carries the scheme's prefix.
+// This is a pure function with no I/O dependencies.
+func nextDevTag(scheme bump.TagScheme, baseTag string, commits int) (string, error) {
+	if baseTag == "" {
+		return fmt.Sprintf("%s0.1.0-dev.%d", scheme.Prefix(), commits), nil
+	}
+	devVersion, err := calculateDevVersion(scheme, baseTag)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s.%d", scheme.Prefix(), devVersion, commits), nil
+}
+
+// checkTagNameOverride validates a --tag-name override against git's rules for tag
+// names. When a version file is to be updated, the name must also parse as a
+// version so the development version can be derived from it.
+// This is a pure function with no I/O dependencies.
+func checkTagNameOverride(scheme bump.TagScheme, tagName string, updateFile bool) error {
+	if err := bump.ValidateTagName(tagName); err != nil {
+		return fmt.Errorf("invalid --tag-name: %w", err)
+	}
+	if updateFile {
+		if _, err := calculateDevVersion(scheme, tagName); err != nil {
+			return fmt.Errorf("--tag-name %q is not a version, so --update-file cannot derive a version from it", tagName)
+		}
+	}
+	return nil
+}
+
+// checkNotDowngrade refuses a next tag that does not sort strictly after the
+// latest tag, whichever way it was chosen. A repository without tags passes.
+// This is a pure function with no I/O dependencies.
+func checkNotDowngrade(scheme bump.TagScheme, latestTag, nextTag string) error {
+	if latestTag == "" {
+		return nil
+	}
+	greater, err := scheme.TagGreater(nextTag, latestTag)
+	if err != nil {
+		return fmt.Errorf("cannot check %s against the latest tag %s for a downgrade: %w", nextTag, latestTag, err)
+	}
+	if !greater {
+		return fmt.Errorf("refusing to create %s: it is not newer than the latest tag %s (--fail-on-downgrade)", nextTag, latestTag)
+	}
+	return nil
+}
+
+// checkTagAliases validates the --also-tag names: each must be a valid tag name,
+// distinct from the others and from the tag being created.
+// This is a pure function with no I/O dependencies.
+func checkTagAliases(tag string, aliases []string) error {
+	seen := map[string]bool{tag: true}
+	for _, alias := range aliases {
+		if err := bump.ValidateTagName(alias); err != nil {
+			return fmt.Errorf("invalid --also-tag: %w", err)
+		}
+		if seen[alias] {
+			return fmt.Errorf("--also-tag %s is given twice or names the new tag itself", alias)
+		}
+		seen[alias] = true
+	}
+	return nil
+}
+
+// releaseBranchName returns the gitflow release branch cut for a tag, such as
+// release/v1.2.0.
+// This is a pure function with no I/O dependencies.
+func releaseBranchName(tag string) string {
+	return "release/" + tag
+}
+
+// isPrerelease reports whether a tag carries a pre-release suffix.
+// This is a pure function with no I/O dependencies.
+func isPrerelease(scheme bump.TagScheme, tag string) bool {
+	version, ok := scheme.ParseTagVersion(tag)
+	return ok && version.Suffix != ""
+}
+
+// prereleaseSuffix returns the pre-release suffix of tag without its separator
+// (v1.2.0-rc.1 gives rc.1), or an empty string for a release.
+// This is a pure function with no I/O dependencies.
+func prereleaseSuffix(scheme bump.TagScheme, tag string) string {
+	version, ok := scheme.ParseTagVersion(tag)
+	if !ok || version.Suffix == "" {
+		return ""
+	}
+	return version.Suffix[1:]
+}
+
+// checkPrereleaseBase rejects bumping the core version of a pre-release base tag,
+// as in v1.2.0-rc.1 to v1.3.0, which usually means releasing off a release
+// candidate by accident. Advancing the pre-release itself, with the prerelease
+// bump type or an increment mode, is still allowed.
+// This is a pure function with no I/O dependencies.
+func checkPrereleaseBase(scheme bump.TagScheme, baseTag, bumpType string, increment bool) error {
+	if bumpType == "prerelease" || increment || !isPrerelease(scheme, baseTag) {
+		return nil
+	}
+	return fmt.Errorf("latest tag %s is a pre-release; pass --allow-prerelease-as-base to %s bump from it, or choose the base with --base-from-file", baseTag, bumpType)
+}
+
+// isPromotion reports whether nextTag promotes the pre-release baseTag to another
+// version of the same core, as in v1.2.0-rc.1 to v1.2.0. A promotion usually tags
+// the commit the pre-release was cut from, so it needs no new commits.
+// This is a pure function with no I/O dependencies.
+func isPromotion(scheme bump.TagScheme, baseTag, nextTag string) bool {
+	base, ok := scheme.ParseTagVersion(baseTag)
+	if !ok || base.Suffix == "" {
+		return false
+	}
+	next, ok := scheme.ParseTagVersion(nextTag)
+	if !ok || nextTag == baseTag {
+		return false
+	}
+	return next.Major == base.Major && next.Minor == base.Minor && next.Patch == base.Patch
+}
+
+// checkVersionConsistency reports whether a version read from a file agrees with
+// the latest tag. The file may hold either the tagged version itself or the
+// development version that --update-file writes after tagging; a "-dev" suffix is
+// ignored when comparing against the tag.
+// This is a pure function with no I/O dependencies.
+func checkVersionConsistency(scheme bump.TagScheme, fileVersion, latestTag string) error {
+	if latestTag == "" {
+		return fmt.Errorf("no semantic version tags found")
+	}
+
+	version, ok := scheme.ParseTagVersion(latestTag)
+	if !ok {
+		return fmt.Errorf("failed to parse tag: %s", latestTag)
+	}
+
+	devVersion, err := calculateDevVersion(scheme, latestTag)
+	if err != nil {
+		return err
+	}
+
+	tagVersion := scheme.StripPrefix(latestTag)
+	coreVersion := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	switch fileVersion {
+	case tagVersion, devVersion:
+		return nil
+	}
+	if strings.TrimSuffix(fileVersion, "-dev") == coreVersion {
+		return nil
+	}
+
+	return fmt.Errorf("version %s does not match latest tag %s (expected %s or %s)", fileVersion, latestTag, tagVersion, devVersion)
+}
+
+// parseVersionFile reads the version held in a VERSION file, with or without a
+// "v" or the tag prefix, and returns it as a tag with the scheme's prefix.
+// This is a pure function with no I/O dependencies.
+func parseVersionFile(scheme bump.TagScheme, content string) (string, error) {
+	version := strings.TrimSpace(content)
+	if version == "" {
+		return "", fmt.Errorf("version file is empty")
+	}
+	core, ok := strings.CutPrefix(version, scheme.Prefix())
+	if !ok {
+		core = strings.TrimPrefix(version, "v")
+	}
+	tag := scheme.Prefix() + core
+	if _, ok := scheme.ParseTagVersion(tag); !ok {
+		return "", fmt.Errorf("invalid version in file: %s", version)
+	}
+	return tag, nil
+}
+
+// formatVersionFile renders a tag as the contents of a VERSION file without the
+// scheme's prefix, starting it with "v" only if the previous contents did.
+// This is a pure function with no I/O dependencies.
+func formatVersionFile(scheme bump.TagScheme, tag, previous string) string {
+	version := scheme.StripPrefix(tag)
+	if strings.HasPrefix(strings.TrimSpace(previous), "v") {
+		version = "v" + version
+	}
+	return version + "\n"
+}
+
+// changedPaths returns the sorted paths with staged changes or, unless indexOnly
+// is set, with any tracked modification. Untracked files are not changes.
+// This is a pure function with no I/O dependencies.
+func changedPaths(status git.Status, indexOnly bool) []string {
+	var paths []string
+	for path, file := range status {
+		staged := file.Staging != git.Unmodified && file.Staging != git.Untracked
+		modified := file.Worktree != git.Unmodified && file.Worktree != git.Untracked
+		if staged || (!indexOnly && modified) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// isBehindRemote reports whether the remote's latest tag is newer than the local one.
+// This is a pure function with no I/O dependencies.
+func isBehindRemote(scheme bump.TagScheme, localTag, remoteTag string) bool {
+	if remoteTag == "" || remoteTag == localTag {
+		return false
+	}
+	if localTag == "" {
+		return true
+	}
+	return scheme.LatestTagName([]string{localTag, remoteTag}) == remoteTag
+}
+
+// newerRemoteTags returns the version tags among remoteTags that are newer than
+// the latest local tag, newest first. Dev snapshots are left out, since a bump
+// would not start from them.
+// This is a pure function with no I/O dependencies.
+func newerRemoteTags(scheme bump.TagScheme, localTag string, remoteTags []string) []string {
+	var newer []string
+	for _, tag := range scheme.SortTagNames(remoteTags) {
+		if !isBehindRemote(scheme, localTag, tag) {
+			break
+		}
+		if !isDevTag(scheme, tag) {
+			newer = append(newer, tag)
+		}
+	}
+	return newer
+}
+
+// formatRemoteGap describes how far the local tags are behind the remote: the
+// remote's tags newer than the latest local tag, or that there are none.
+// This is a pure function with no I/O dependencies.
+func formatRemoteGap(remote, localTag string, newer []string) string {
+	local := localTag
+	if local == "" {
+		local = "none"
+	}
+	if len(newer) == 0 {
+		return fmt.Sprintf("Local tags are up to date with %s (latest local tag: %s)\n", remote, local)
+	}
+	return fmt.Sprintf("%s is ahead of the latest local tag %s by %d tag(s): %s\n", remote, local, len(newer), strings.Join(newer, ", "))
+}
+
+// bumpTypes lists the bump commands a suffixPolicy can name.
+var bumpTypes = []string{"major", "minor", "patch", "prerelease"}
+
+// suffixPolicyValues maps the values accepted in suffixPolicy to whether they allow a suffix.
+var suffixPolicyValues = map[string]bool{"any": true, "stable": false}
+
+// checkSuffixPolicy validates the suffix of a bump against the suffixPolicy setting.
+// The policy is a comma-separated list of bumpType=value pairs, where "stable"
+// forbids a pre-release suffix and "any" allows one, e.g. "major=stable,minor=any".
+// Bump types the policy does not mention allow a suffix.
+// This is a pure function with no I/O dependencies.
+func checkSuffixPolicy(policy, bumpType, suffix string) error {
+	allowed := true
+	for _, entry := range strings.Split(policy, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || !slices.Contains(bumpTypes, key) {
+			return fmt.Errorf("invalid suffixPolicy entry %q: expected <%s>=<any|stable>", entry, strings.Join(bumpTypes, "|"))
+		}
+		allow, known := suffixPolicyValues[value]
+		if !known {
+			return fmt.Errorf("invalid suffixPolicy value %q for %s: expected any or stable", value, key)
+		}
+		if key == bumpType {
+			allowed = allow
+		}
+	}
+	if !allowed && suffix != "" {
+		return fmt.Errorf("suffix %q is not allowed on %s bumps: suffixPolicy requires %s releases to be stable", suffix, bumpType, bumpType)
+	}
+	return nil
+}
+
+// resolveLightweight decides whether to create a lightweight tag. The --annotated
+// and --lightweight flags override the tagType setting ("annotated" or
+// "lightweight"), which defaults to annotated, and cannot be combined.
+// This is a pure function with no I/O dependencies.
+func resolveLightweight(tagType string, annotated, lightweight bool) (bool, error) {
+	if annotated && lightweight {
+		return false, fmt.Errorf("--annotated and --lightweight cannot be used together")
+	}
+	if annotated || lightweight {
+		return lightweight, nil
+	}
+	switch strings.TrimSpace(tagType) {
+	case "", "annotated":
+		return false, nil
+	case "lightweight":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid tagType %q: expected annotated or lightweight", tagType)
+	}
+}
+
+// applyNoOpPolicy maps the outcome of a bump to the error the command returns.
+// By default an idempotent no-op succeeds and ErrNoChanges is an ordinary error;
+// under strict both become ErrNoOp so pipelines can branch on the exit code.
+// This is a pure function with no I/O dependencies.
+func applyNoOpPolicy(result *BumpResult, err error, strict bool) error {
+	if !strict {
+		return err
+	}
+	if errors.Is(err, ErrNoChanges) {
+		return fmt.Errorf("%w: %w", ErrNoOp, err)
+	}
+	if err == nil && result != nil && result.NoOp {
+		return ErrNoOp
+	}
+	return err
+}
+
+// displayVersion renders a tag for printing, removing the scheme's prefix when
+// stripPrefix is set so the output can be embedded directly (e.g. via -ldflags -X).
+// This is a pure function with no I/O dependencies.
+func displayVersion(scheme bump.TagScheme, tag string, stripPrefix bool) string {
+	if stripPrefix {
+		return scheme.StripPrefix(tag)
+	}
+	return tag
+}
+
+// NormalizeOp describes migrating one non-canonical version tag to its canonical name.
+type NormalizeOp struct {
+	OldTag string // Existing non-canonical tag, e.g. "release-1.2.3"
+	NewTag string // Canonical tag name, e.g. "v1.2.3"
+	Exists bool   // The canonical tag already exists, so nothing is created or deleted
+}
+
+// planNormalization determines which tags need a canonical "v"-prefixed counterpart.
+// Tags already in canonical form and tags that cannot be parsed are ignored. When
+// several tags map to the same canonical name, only the first (in sorted order) is
+// migrated and the rest are marked as existing.
+// This is a pure function with no I/O dependencies.
+func planNormalization(scheme bump.TagScheme, tags []string) []NormalizeOp {
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+
+	taken := make(map[string]bool)
+	for _, tag := range sorted {
+		if _, ok := scheme.ParseTagVersion(tag); ok {
+			taken[tag] = true
+		}
+	}
+
+	var ops []NormalizeOp
+	for _, tag := range sorted {
+		if taken[tag] {
+			continue
+		}
+		canonical, ok := bump.CanonicalTagName(tag)
+		if !ok {
+			continue
+		}
+		ops = append(ops, NormalizeOp{OldTag: tag, NewTag: canonical, Exists: taken[canonical]})
+		taken[canonical] = true
+	}
+	return ops
+}
+
+// formatTagLints lists each malformed version tag with its problems, one tag per line.
+// This is a pure function with no I/O dependencies.
+func formatTagLints(lints []bump.TagLint) string {
+	if len(lints) == 0 {
+		return "All version tags are valid SemVer\n"
+	}
+	var b strings.Builder
+	for _, lint := range lints {
+		fmt.Fprintf(&b, "%s: %s\n", lint.Tag, strings.Join(lint.Problems, "; "))
+	}
+	return b.String()
+}
+
+// formatNormalizePreview describes the planned normalization without making changes.
+// This is a pure function with no I/O dependencies.
+func formatNormalizePreview(ops []NormalizeOp, deleteOld bool) string {
+	if len(ops) == 0 {
+		return "All version tags are already canonical\n"
+	}
+
+	var msg string
+	for _, op := range ops {
+		if op.Exists {
+			msg += fmt.Sprintf("Skipping %s: %s already exists\n", op.OldTag, op.NewTag)
+			continue
+		}
+		msg += fmt.Sprintf("Would create tag %s at %s\n", op.NewTag, op.OldTag)
+		if deleteOld {
+			msg += fmt.Sprintf("Would delete tag %s\n", op.OldTag)
+		}
+	}
+	msg += "Run again with --apply to make these changes\n"
+	return msg
+}
+
+// parseTrailer converts a "key=value" flag into a git trailer line such as
+// "Co-authored-by: Jane <jane@example.com>". Keys may contain only letters,
+// digits, and dashes.
+// This is a pure function with no I/O dependencies.
+func parseTrailer(trailer string) (string, error) {
+	key, value, ok := strings.Cut(trailer, "=")
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+	if !ok || key == "" || value == "" {
+		return "", fmt.Errorf("invalid trailer %q: expected key=value", trailer)
+	}
+	for _, ch := range key {
+		if !(ch == '-' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z') {
+			return "", fmt.Errorf("invalid trailer key %q: only letters, digits, and dashes are allowed", key)
+		}
+	}
+	return fmt.Sprintf("%s: %s", key, value), nil
+}
+
+// Versions a --constant can be set to.
+const (
+	constantRelease = "release" // The released version, e.g. 1.3.0
+	constantDev     = "dev"     // The development version written after tagging, e.g. 1.3.1-dev
+)
+
+// constantValues maps each "Name=release" or "Name=dev" spec to the new value of
+// the named constant. The Version constant is set by --update-file itself, so it
+// cannot be named.
+// This is a pure function with no I/O dependencies.
+func constantValues(specs []string, release, dev string) (map[string]string, error) {
+	values := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		name, kind, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("invalid --constant %q: expected Name=%s or Name=%s", spec, constantRelease, constantDev)
+		}
+		if name == "Version" {
+			return nil, fmt.Errorf("invalid --constant %q: Version is already set by --update-file", spec)
+		}
+		if _, dup := values[name]; dup {
+			return nil, fmt.Errorf("invalid --constant %q: %s is given more than once", spec, name)
+		}
+		switch strings.TrimSpace(kind) {
+		case constantRelease:
+			values[name] = release
+		case constantDev:
+			values[name] = dev
+		default:
+			return nil, fmt.Errorf("invalid --constant %q: expected Name=%s or Name=%s", spec, constantRelease, constantDev)
+		}
+	}
+	return values, nil
+}
+
+// formatLockHolder describes a lock file and the process recorded in it.
+// This is a pure function with no I/O dependencies.
+func formatLockHolder(holder bump.LockHolder) string {
+	msg := fmt.Sprintf("Lock file: %s\n", holder.Path)
+	switch {
+	case holder.PID == 0:
+		msg += "Held by: unknown process\n"
+	case holder.Alive:
+		msg += fmt.Sprintf("Held by: process %d (running)\n", holder.PID)
+	default:
+		msg += fmt.Sprintf("Held by: process %d (not running)\n", holder.PID)
+	}
+	if !holder.Time.IsZero() {
+		msg += fmt.Sprintf("Taken at: %s\n", holder.Time.Format(time.RFC3339))
+	}
+	return msg
+}
+
+// composeTagMessage appends trailer lines to a tag annotation, separated from the
+// body by a blank line as git expects.
+// This is a pure function with no I/O dependencies.
+func composeTagMessage(message string, trailers []string) string {
+	if len(trailers) == 0 {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(trailers, "\n") + "\n"
+}
+
+// selectDefaultRemote picks the remote to push to when none was given: the only
+// remote if there is just one, otherwise "origin" if it exists.
+// This is a pure function with no I/O dependencies.
+func selectDefaultRemote(remotes []string) (string, error) {
+	switch len(remotes) {
+	case 0:
+		return "", ErrNoRemote
+	case 1:
+		return remotes[0], nil
+	}
+	for _, remote := range remotes {
+		if remote == "origin" {
+			return remote, nil
+		}
+	}
+	return "", fmt.Errorf("multiple remotes configured (%s); specify one with --remote", strings.Join(remotes, ", "))
+}
+
+// parseGitHubRemote extracts the owner and repository name from a GitHub remote
+// URL. Both SSH ("git@github.com:owner/repo.git") and HTTPS
+// ("https://github.com/owner/repo") forms are accepted.
+// This is a pure function with no I/O dependencies.
+func parseGitHubRemote(remoteURL string) (owner, repo string, err error) {
+	path := strings.TrimSpace(remoteURL)
+	switch {
+	case strings.HasPrefix(path, "git@github.com:"):
+		path = strings.TrimPrefix(path, "git@github.com:")
+	case strings.HasPrefix(path, "ssh://git@github.com/"):
+		path = strings.TrimPrefix(path, "ssh://git@github.com/")
+	case strings.HasPrefix(path, "https://github.com/"):
+		path = strings.TrimPrefix(path, "https://github.com/")
+	case strings.HasPrefix(path, "http://github.com/"):
+		path = strings.TrimPrefix(path, "http://github.com/")
+	default:
+		return "", "", fmt.Errorf("not a GitHub remote: %s", remoteURL)
+	}
+
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("not a GitHub remote: %s", remoteURL)
+	}
+	return parts[0], parts[1], nil
+}
+
 // formatBumpMessage returns the success message after creating a tag.
 // The message varies based on whether the tag was pushed to remote.
 // This is a pure function with no I/O dependencies.
@@ -37,17 +815,267 @@ func formatBumpMessage(tag string, pushed bool) string {
 	return fmt.Sprintf("Successfully created tag %s. To push, run: git push --tags", tag)
 }
 
+// TagEntry is one line of the tag listing.
+type TagEntry struct {
+	Tag     string // Tag name
+	Subject string // Subject line of the tagged commit; empty when not requested
+}
+
+// formatTagList renders tags one per line, newest first as given. With subjects,
+// each tag is followed by its commit subject in an aligned column.
+// This is a pure function with no I/O dependencies.
+func formatTagList(entries []TagEntry, withSubjects bool) string {
+	width := 0
+	for _, entry := range entries {
+		width = max(width, len(entry.Tag))
+	}
+	var b strings.Builder
+	for _, entry := range entries {
+		if withSubjects {
+			fmt.Fprintf(&b, "%-*s  %s\n", width, entry.Tag, entry.Subject)
+		} else {
+			fmt.Fprintln(&b, entry.Tag)
+		}
+	}
+	return b.String()
+}
+
+// formatReleaseLines renders each release line with its newest tag, e.g. "1.2.x: v1.2.7".
+// This is a pure function with no I/O dependencies.
+func formatReleaseLines(lines []bump.ReleaseLine) string {
+	var b strings.Builder
+	for _, line := range lines {
+		fmt.Fprintf(&b, "%d.%d.x: %s\n", line.Major, line.Minor, line.Latest)
+	}
+	return b.String()
+}
+
+// defaultDevCommitPattern matches the subject of the commit --update-file creates
+// for a development version.
+const defaultDevCommitPattern = `^Bump version to \S+-dev$`
+
+// findDevCommit returns the index of the newest commit whose subject matches
+// pattern, or -1 if none does. Commits are ordered newest first.
+// This is a pure function with no I/O dependencies.
+func findDevCommit(commits []CommitInfo, pattern *regexp.Regexp) int {
+	return slices.IndexFunc(commits, func(c CommitInfo) bool {
+		return pattern.MatchString(c.Subject)
+	})
+}
+
+// shortHash abbreviates a commit hash to seven characters, as git does.
+// This is a pure function with no I/O dependencies.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// withoutMerges returns the commits that have at most one parent, keeping their order.
+// This is a pure function with no I/O dependencies.
+func withoutMerges(commits []CommitInfo) []CommitInfo {
+	var kept []CommitInfo
+	for _, c := range commits {
+		if !c.IsMerge {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// changelogRefPattern matches a pull request reference such as "(#123)" or a commit
+// hash of at least seven hex digits in a commit subject.
+var changelogRefPattern = regexp.MustCompile(`\(#(\d+)\)|\b[0-9a-f]{7,40}\b`)
+
+// linkChangelogRefs rewrites pull request references and commit hashes in subject
+// into Markdown links under repoURL: "(#123)" becomes "([#123](<repoURL>/pull/123))"
+// and "abc1234" becomes "[abc1234](<repoURL>/commit/abc1234)". Hex runs without a
+// digit, such as "defaced", are words rather than hashes and are left alone. An
+// empty repoURL returns subject unchanged.
+// This is a pure function with no I/O dependencies.
+func linkChangelogRefs(subject, repoURL string) string {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	if repoURL == "" {
+		return subject
+	}
+	return changelogRefPattern.ReplaceAllStringFunc(subject, func(ref string) string {
+		if number, ok := strings.CutPrefix(ref, "(#"); ok {
+			number = strings.TrimSuffix(number, ")")
+			return fmt.Sprintf("([#%s](%s/pull/%s))", number, repoURL, number)
+		}
+		if !strings.ContainsAny(ref, "0123456789") {
+			return ref
+		}
+		return fmt.Sprintf("[%s](%s/commit/%s)", ref, repoURL, ref)
+	})
+}
+
+// formatChangelog renders the commits included in a release as a bulleted list,
+// linking pull request references and commit hashes under repoURL when it is set.
+// This is a pure function with no I/O dependencies.
+func formatChangelog(tag, previousTag string, commits []CommitInfo, repoURL string) string {
+	if len(commits) == 0 {
+		if previousTag == "" {
+			return fmt.Sprintf("No changes in %s\n", tag)
+		}
+		return fmt.Sprintf("No changes in %s since %s\n", tag, previousTag)
+	}
+
+	var msg string
+	if previousTag == "" {
+		msg = fmt.Sprintf("Changes in %s:\n", tag)
+	} else {
+		msg = fmt.Sprintf("Changes in %s since %s:\n", tag, previousTag)
+	}
+	for _, commit := range commits {
+		msg += fmt.Sprintf("- %s\n", linkChangelogRefs(commit.Subject, repoURL))
+	}
+	return msg
+}
+
+// Explanation records the inputs that determined a computed version, for --explain.
+type Explanation struct {
+	LatestTag string   // Latest version tag; empty when there are none
+	TagCount  int      // Distinct tags considered
+	Skipped   []string // Tags ignored because they are not semantic versions
+	BaseTag   string   // Version read from BaseFile, when the bump started from a file
+	BaseFile  string   // VERSION file the base version was read from
+	BumpType  string   // Bump type, with any increment mode
+	Suffix    string   // Pre-release suffix or channel; empty for none
+	TagName   string   // Exact tag name given instead of a computed version
+	Result    string   // Tag that was (or would be) created
+}
+
+// explainTags counts the distinct tags among names and returns, sorted, those
+// that cannot be the latest tag because they are not semantic versions or do not
+// match the tag pattern.
+// This is a pure function with no I/O dependencies.
+func explainTags(scan bump.TagScan) (int, []string) {
+	return scan.Versions + len(scan.Skipped), scan.Skipped
+}
+
+// formatExplanation renders the reasoning behind a computed version, such as
+// "Latest tag: v1.2.3 (from 14 tags). Bump type: minor. Suffix: none. Result: v1.3.0."
+// This is a pure function with no I/O dependencies.
+func formatExplanation(e Explanation) string {
+	latest := e.LatestTag
+	if latest == "" {
+		latest = "none"
+	}
+	noun := "tags"
+	if e.TagCount == 1 {
+		noun = "tag"
+	}
+	msg := fmt.Sprintf("Latest tag: %s (from %d %s", latest, e.TagCount, noun)
+	if len(e.Skipped) > 0 {
+		msg += fmt.Sprintf("; skipped as non-semver: %s", strings.Join(e.Skipped, ", "))
+	}
+	msg += ")."
+	if e.BaseFile != "" {
+		msg += fmt.Sprintf(" Base version: %s (from %s).", e.BaseTag, e.BaseFile)
+	}
+	if e.TagName != "" {
+		return msg + fmt.Sprintf(" Tag name: given by --tag-name. Result: %s.\n", e.Result)
+	}
+	suffix := e.Suffix
+	if suffix == "" {
+		suffix = "none"
+	}
+	return msg + fmt.Sprintf(" Bump type: %s. Suffix: %s. Result: %s.\n", e.BumpType, suffix, e.Result)
+}
+
 // formatDryRunMessage returns a preview message for dry-run mode.
-// It shows what would be created without making actual changes.
+// It shows what would be created without making actual changes, including the
+// development version that would be written to updateFile.
 // This is a pure function with no I/O dependencies.
-func formatDryRunMessage(tag string, wouldPush bool, updateFile string) string {
+func formatDryRunMessage(scheme bump.TagScheme, tag string, wouldPush bool, updateFile string) string {
 	var msg string
 	msg = fmt.Sprintf("Would create tag: %s\n", tag)
 	if wouldPush {
 		msg += "Would push tag to remote\n"
 	}
 	if updateFile != "" {
-		msg += fmt.Sprintf("Would update file: %s\n", updateFile)
+		if devVersion, err := calculateDevVersion(scheme, tag); err == nil {
+			msg += fmt.Sprintf("Would update file %s: Version -> %s\n", updateFile, devVersion)
+		} else {
+			msg += fmt.Sprintf("Would update file: %s\n", updateFile)
+		}
+	}
+	return msg
+}
+
+// plannedGitCommands returns the git commands that create nextTag with tagOpts and,
+// when push is set, publish it to remote, in the order Bump runs them.
+// This is a pure function with no I/O dependencies.
+func plannedGitCommands(nextTag string, tagOpts bump.TagOptions, push bool, remote string, onlyNew bool) [][]string {
+	commands := bump.TagCommands(nextTag, tagOpts)
+	if !push {
+		return commands
+	}
+	for _, alias := range tagOpts.Aliases {
+		commands = append(commands, bump.PushSingleTagCommand(remote, alias, true))
 	}
+	if onlyNew {
+		return append(commands, bump.PushSingleTagCommand(remote, nextTag, false))
+	}
+	return append(commands, bump.PushTagsCommand(remote))
+}
+
+// shellSafeArg matches arguments that need no quoting in a POSIX shell.
+var shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9_./:@%+=,-]+$`)
+
+// formatGitCommands renders commands one per line, quoting arguments so each line
+// can be pasted into a POSIX shell.
+// This is a pure function with no I/O dependencies.
+func formatGitCommands(commands [][]string) string {
+	var b strings.Builder
+	b.WriteString("Would run:\n")
+	for _, command := range commands {
+		quoted := make([]string, len(command))
+		for i, arg := range command {
+			if shellSafeArg.MatchString(arg) {
+				quoted[i] = arg
+			} else {
+				quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+			}
+		}
+		fmt.Fprintf(&b, "  %s\n", strings.Join(quoted, " "))
+	}
+	return b.String()
+}
+
+// formatConfigDiff renders the changes a config update would make as a
+// before/after diff of the affected section.
+// This is a pure function with no I/O dependencies.
+func formatConfigDiff(changes []bump.ConfigChange) string {
+	if len(changes) == 0 {
+		return "No config changes\n"
+	}
+
+	var msg string
+	section := ""
+	for _, change := range changes {
+		if change.Section != section {
+			section = change.Section
+			msg += fmt.Sprintf("[%s]\n", section)
+		}
+		if change.WasSet {
+			msg += fmt.Sprintf("- %s = %s\n", change.Key, change.OldValue)
+		}
+		msg += fmt.Sprintf("+ %s = %s\n", change.Key, change.NewValue)
+	}
+	return msg
+}
+
+// formatTimings renders phase durations as key=duration lines, one per phase.
+// This is a pure function with no I/O dependencies.
+func formatTimings(timings BumpTimings) string {
+	var msg string
+	msg += fmt.Sprintf("tag_enumeration=%s\n", timings.TagEnumeration)
+	msg += fmt.Sprintf("latest_tag=%s\n", timings.LatestTag)
+	msg += fmt.Sprintf("tag_creation=%s\n", timings.TagCreation)
+	msg += fmt.Sprintf("push=%s\n", timings.Push)
+	msg += fmt.Sprintf("file_update=%s\n", timings.FileUpdate)
 	return msg
 }
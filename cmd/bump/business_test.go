@@ -1,9 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/klauern/bump"
 )
 
 // TestCalculateNextVersion tests the pure function for calculating next version
@@ -72,11 +78,23 @@ func TestCalculateNextVersion(t *testing.T) {
 			expected:    "v3.0.0-rc1",
 			expectError: false,
 		},
+		{
+			name:      "Surrounding whitespace ignored",
+			latestTag: " v1.2.3\n",
+			bumpType:  "patch",
+			expected:  "v1.2.4",
+		},
+		{
+			name:        "Internal whitespace rejected",
+			latestTag:   "v1.2 .3",
+			bumpType:    "patch",
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := calculateNextVersion(tt.latestTag, tt.bumpType, tt.suffix)
+			result, err := calculateNextVersion(bump.TagScheme{}, tt.latestTag, tt.bumpType, bump.NextTagOptions{Suffix: tt.suffix})
 			if (err != nil) != tt.expectError {
 				t.Errorf("calculateNextVersion() error = %v, expectError %v", err, tt.expectError)
 				return
@@ -142,7 +160,7 @@ func TestCalculateDevVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := calculateDevVersion(tt.tag)
+			result, err := calculateDevVersion(bump.TagScheme{}, tt.tag)
 			if (err != nil) != tt.expectError {
 				t.Errorf("calculateDevVersion() error = %v, expectError %v", err, tt.expectError)
 				return
@@ -154,6 +172,752 @@ func TestCalculateDevVersion(t *testing.T) {
 	}
 }
 
+// TestNextDevTag tests the pure function for calculating dev snapshot tags
+func TestNextDevTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseTag    string
+		commits    int
+		expected   string
+		nextStable string
+	}{
+		{name: "Release base", baseTag: "v1.2.3", commits: 4, expected: "v1.2.4-dev.4", nextStable: "v1.2.4"},
+		{name: "Pre-release base", baseTag: "v2.0.0-rc.1", commits: 1, expected: "v2.0.1-dev.1", nextStable: "v2.0.1"},
+		{name: "No base tag", baseTag: "", commits: 7, expected: "v0.1.0-dev.7", nextStable: "v0.1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := nextDevTag(bump.TagScheme{}, tt.baseTag, tt.commits)
+			if err != nil {
+				t.Fatalf("nextDevTag() unexpected error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("nextDevTag() = %v, expected %v", result, tt.expected)
+			}
+			if !isDevTag(bump.TagScheme{}, result) {
+				t.Errorf("isDevTag(%s) = false, expected a dev tag", result)
+			}
+			if below, err := bump.TagGreater(tt.nextStable, result); err != nil || !below {
+				t.Errorf("%s should sort below %s (err = %v)", result, tt.nextStable, err)
+			}
+		})
+	}
+
+	if _, err := nextDevTag(bump.TagScheme{}, "invalid", 1); err == nil {
+		t.Error("nextDevTag() expected an error for an invalid base tag")
+	}
+	for _, tag := range []string{"v1.2.3", "v1.2.4-rc.1", "v1.2.4-devel", "v1.2.4-dev", "v1.2.4-dev.1.rc", "v1.2.4-dev.x", "v1.2.4-dev.1+build.2", "invalid"} {
+		if isDevTag(bump.TagScheme{}, tag) {
+			t.Errorf("isDevTag(%s) = true, expected false", tag)
+		}
+	}
+}
+
+// TestCheckVersionConsistency tests the pure function comparing file and tag versions
+func TestCheckVersionConsistency(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileVersion string
+		latestTag   string
+		expectError bool
+	}{
+		{
+			name:        "File matches tag",
+			fileVersion: "1.2.3",
+			latestTag:   "v1.2.3",
+			expectError: false,
+		},
+		{
+			name:        "File holds next dev version",
+			fileVersion: "1.2.4-dev",
+			latestTag:   "v1.2.3",
+			expectError: false,
+		},
+		{
+			name:        "File holds dev suffix on tagged version",
+			fileVersion: "1.2.3-dev",
+			latestTag:   "v1.2.3",
+			expectError: false,
+		},
+		{
+			name:        "File matches pre-release tag",
+			fileVersion: "2.0.0-rc1",
+			latestTag:   "v2.0.0-rc1",
+			expectError: false,
+		},
+		{
+			name:        "File behind tag",
+			fileVersion: "1.2.2",
+			latestTag:   "v1.2.3",
+			expectError: true,
+		},
+		{
+			name:        "Stale dev version",
+			fileVersion: "1.2.3-dev",
+			latestTag:   "v1.3.0",
+			expectError: true,
+		},
+		{
+			name:        "No tags",
+			fileVersion: "0.1.0",
+			latestTag:   "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVersionConsistency(bump.TagScheme{}, tt.fileVersion, tt.latestTag)
+			if (err != nil) != tt.expectError {
+				t.Errorf("checkVersionConsistency() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestPlanNormalization tests planning canonical tags over a mixed tag set
+func TestPlanNormalization(t *testing.T) {
+	tags := []string{"v1.0.0", "release-1.0.0", "1.1.0", "release-1.2.0-beta", "release-1.1.0", "nightly", "v2.0.0"}
+
+	ops := planNormalization(bump.TagScheme{}, tags)
+
+	expected := []NormalizeOp{
+		{OldTag: "1.1.0", NewTag: "v1.1.0"},
+		{OldTag: "release-1.0.0", NewTag: "v1.0.0", Exists: true},
+		{OldTag: "release-1.1.0", NewTag: "v1.1.0", Exists: true},
+		{OldTag: "release-1.2.0-beta", NewTag: "v1.2.0-beta"},
+	}
+	if len(ops) != len(expected) {
+		t.Fatalf("planNormalization() = %+v, expected %+v", ops, expected)
+	}
+	for i := range expected {
+		if ops[i] != expected[i] {
+			t.Errorf("op %d = %+v, expected %+v", i, ops[i], expected[i])
+		}
+	}
+
+	if ops := planNormalization(bump.TagScheme{}, []string{"v1.0.0", "v1.1.0"}); len(ops) != 0 {
+		t.Errorf("canonical tags should need no operations, got %+v", ops)
+	}
+}
+
+// TestFormatNormalizePreview tests the pure function for previewing normalization
+func TestFormatNormalizePreview(t *testing.T) {
+	ops := []NormalizeOp{
+		{OldTag: "1.1.0", NewTag: "v1.1.0"},
+		{OldTag: "release-1.0.0", NewTag: "v1.0.0", Exists: true},
+	}
+
+	result := formatNormalizePreview(ops, true)
+	for _, expected := range []string{
+		"Would create tag v1.1.0 at 1.1.0",
+		"Would delete tag 1.1.0",
+		"Skipping release-1.0.0: v1.0.0 already exists",
+		"--apply",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("formatNormalizePreview() missing %q\nGot: %v", expected, result)
+		}
+	}
+
+	if result := formatNormalizePreview(ops, false); strings.Contains(result, "Would delete") {
+		t.Errorf("formatNormalizePreview() should not delete without deleteOld\nGot: %v", result)
+	}
+	if result := formatNormalizePreview(nil, false); !strings.Contains(result, "already canonical") {
+		t.Errorf("formatNormalizePreview() with no ops = %q", result)
+	}
+}
+
+// TestParseTrailer tests the pure function for parsing trailer flags
+func TestParseTrailer(t *testing.T) {
+	tests := []struct {
+		name        string
+		trailer     string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Co-authored-by trailer",
+			trailer:  "Co-authored-by=Jane Doe <jane@example.com>",
+			expected: "Co-authored-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "Value containing equals sign",
+			trailer:  "Reviewed-on=https://example.com/?id=1",
+			expected: "Reviewed-on: https://example.com/?id=1",
+		},
+		{
+			name:        "Missing value",
+			trailer:     "Signed-off-by=",
+			expectError: true,
+		},
+		{
+			name:        "Missing separator",
+			trailer:     "Signed-off-by",
+			expectError: true,
+		},
+		{
+			name:        "Invalid key characters",
+			trailer:     "Signed off by=Jane",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTrailer(tt.trailer)
+			if (err != nil) != tt.expectError {
+				t.Errorf("parseTrailer() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if !tt.expectError && result != tt.expected {
+				t.Errorf("parseTrailer() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestComposeTagMessage tests the pure function for appending trailers to a message
+func TestComposeTagMessage(t *testing.T) {
+	trailers := []string{"Signed-off-by: Jane <jane@example.com>", "Co-authored-by: Bob <bob@example.com>"}
+
+	result := composeTagMessage("Release notes\n\n- change\n", trailers)
+	expected := "Release notes\n\n- change\n\nSigned-off-by: Jane <jane@example.com>\nCo-authored-by: Bob <bob@example.com>\n"
+	if result != expected {
+		t.Errorf("composeTagMessage() = %q, expected %q", result, expected)
+	}
+
+	if result := composeTagMessage("v1.0.0", nil); result != "v1.0.0" {
+		t.Errorf("composeTagMessage() without trailers = %q, expected message unchanged", result)
+	}
+}
+
+// TestSelectDefaultRemote tests the pure function for choosing the remote to push to
+func TestSelectDefaultRemote(t *testing.T) {
+	tests := []struct {
+		name        string
+		remotes     []string
+		expected    string
+		expectError bool
+	}{
+		{name: "Single remote", remotes: []string{"upstream"}, expected: "upstream"},
+		{name: "Origin preferred", remotes: []string{"fork", "origin", "upstream"}, expected: "origin"},
+		{name: "Ambiguous", remotes: []string{"fork", "upstream"}, expectError: true},
+		{name: "No remotes", remotes: nil, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := selectDefaultRemote(tt.remotes)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("selectDefaultRemote() error = %v, expectError %v", err, tt.expectError)
+			}
+			if result != tt.expected {
+				t.Errorf("selectDefaultRemote() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseGitHubRemote tests the pure function for extracting owner/repo from remote URLs
+func TestParseGitHubRemote(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		owner       string
+		repo        string
+		expectError bool
+	}{
+		{name: "SSH", url: "git@github.com:klauern/bump.git", owner: "klauern", repo: "bump"},
+		{name: "SSH URL", url: "ssh://git@github.com/klauern/bump.git", owner: "klauern", repo: "bump"},
+		{name: "HTTPS with .git", url: "https://github.com/klauern/bump.git", owner: "klauern", repo: "bump"},
+		{name: "HTTPS without .git", url: "https://github.com/klauern/bump/", owner: "klauern", repo: "bump"},
+		{name: "Other host", url: "git@gitlab.com:klauern/bump.git", expectError: true},
+		{name: "Missing repo", url: "https://github.com/klauern", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, err := parseGitHubRemote(tt.url)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseGitHubRemote() error = %v, expectError %v", err, tt.expectError)
+			}
+			if owner != tt.owner || repo != tt.repo {
+				t.Errorf("parseGitHubRemote() = %q, %q, expected %q, %q", owner, repo, tt.owner, tt.repo)
+			}
+		})
+	}
+}
+
+// TestRenderSuffix tests the pure function for expanding suffix templates
+func TestRenderSuffix(t *testing.T) {
+	data := SuffixTemplateData{Date: "20240601", ShortSHA: "abc1234", SHA: "abc1234def"}
+	tests := []struct {
+		name        string
+		suffix      string
+		expected    string
+		expectError bool
+	}{
+		{name: "No tokens", suffix: "rc.1", expected: "rc.1"},
+		{name: "Date", suffix: "nightly.{{.Date}}", expected: "nightly.20240601"},
+		{name: "Short SHA", suffix: "snapshot.{{.ShortSHA}}", expected: "snapshot.abc1234"},
+		{name: "Unknown field", suffix: "{{.Branch}}", expectError: true},
+		{name: "Malformed template", suffix: "nightly.{{.Date", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renderSuffix(tt.suffix, data)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("renderSuffix() error = %v, expectError %v", err, tt.expectError)
+			}
+			if result != tt.expected {
+				t.Errorf("renderSuffix() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRenderReleaseCommitMessage tests expanding --release-commit-message templates
+func TestRenderReleaseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		expected    string
+		expectError bool
+	}{
+		{name: "Default", message: "", expected: "Release v1.2.3"},
+		{name: "Version", message: "chore(release): {{.Version}}", expected: "chore(release): 1.2.3"},
+		{name: "No tokens", message: "Cut a release", expected: "Cut a release"},
+		{name: "Unknown field", message: "Release {{.Branch}}", expectError: true},
+		{name: "Empty result", message: "  ", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renderReleaseCommitMessage(bump.TagScheme{}, tt.message, "v1.2.3")
+			if (err != nil) != tt.expectError {
+				t.Fatalf("renderReleaseCommitMessage() error = %v, expectError %v", err, tt.expectError)
+			}
+			if result != tt.expected {
+				t.Errorf("renderReleaseCommitMessage() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestValidateSuffix tests the pure function for validating pre-release suffixes
+func TestValidateSuffix(t *testing.T) {
+	tests := []struct {
+		suffix      string
+		expectError bool
+	}{
+		{suffix: "rc.1"},
+		{suffix: "nightly.20240601"},
+		{suffix: "x-y-z.0"},
+		{suffix: "rc..1", expectError: true},
+		{suffix: "rc.", expectError: true},
+		{suffix: "feature/login", expectError: true},
+		{suffix: "build_1", expectError: true},
+		{suffix: "alpha.01", expectError: true},
+		{suffix: "alpha.0a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.suffix, func(t *testing.T) {
+			if err := validateSuffix(tt.suffix, defaultSuffixLimits); (err != nil) != tt.expectError {
+				t.Errorf("validateSuffix(%q) error = %v, expectError %v", tt.suffix, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestValidateSuffixLenientZero tests accepting leading-zero identifiers for legacy tags, which still count by value
+func TestValidateSuffixLenientZero(t *testing.T) {
+	if err := validateSuffix("alpha.01", SuffixLimits{}); err == nil || !strings.Contains(err.Error(), "leading zero") {
+		t.Errorf("validateSuffix() strict error = %v, expected a leading zero error", err)
+	}
+	if err := validateSuffix("alpha.01", SuffixLimits{LenientZero: true}); err != nil {
+		t.Errorf("validateSuffix() lenient unexpected error = %v", err)
+	}
+
+	// alpha.01 has the value 1, so it advances to alpha.2
+	next, err := calculateNextVersion(bump.TagScheme{}, "v1.0.0-alpha.01", "patch", bump.NextTagOptions{IncrementPrerelease: true})
+	if err != nil || next != "v1.0.0-alpha.2" {
+		t.Errorf("calculateNextVersion() = %q, %v; expected v1.0.0-alpha.2", next, err)
+	}
+}
+
+// TestValidateSuffixLimits tests suffixes at and over the length and identifier limits
+func TestValidateSuffixLimits(t *testing.T) {
+	limits := SuffixLimits{MaxLength: 12, MaxIdentifiers: 3}
+	tests := []struct {
+		name        string
+		suffix      string
+		limits      SuffixLimits
+		expectError string
+	}{
+		{name: "At length limit", suffix: "nightly.1234", limits: limits},
+		{name: "Over length limit", suffix: "nightly.12345", limits: limits, expectError: "13 characters exceeds the limit of 12"},
+		{name: "At identifier limit", suffix: "a.b.c", limits: limits},
+		{name: "Over identifier limit", suffix: "a.b.c.d", limits: limits, expectError: "4 identifiers exceeds the limit of 3"},
+		{name: "Zero limits are unlimited", suffix: strings.Repeat("a.", 50) + "z"},
+		{name: "Default rejects runaway template", suffix: strings.Repeat("20240601", 9), limits: defaultSuffixLimits, expectError: "exceeds the limit of 64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSuffix(tt.suffix, tt.limits)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("validateSuffix() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("validateSuffix() error = %v, expected to contain %q", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestParseVersionFile tests the pure function for reading a VERSION file
+func TestParseVersionFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    string
+		expectError bool
+	}{
+		{name: "Plain version", content: "1.2.3\n", expected: "v1.2.3"},
+		{name: "Prefixed version", content: "v2.0.0-rc.1", expected: "v2.0.0-rc.1"},
+		{name: "Empty file", content: "\n", expectError: true},
+		{name: "Not a version", content: "latest", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseVersionFile(bump.TagScheme{}, tt.content)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseVersionFile() error = %v, expectError %v", err, tt.expectError)
+			}
+			if result != tt.expected {
+				t.Errorf("parseVersionFile() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatVersionFile tests the pure function for rendering a VERSION file
+func TestFormatVersionFile(t *testing.T) {
+	if result := formatVersionFile(bump.TagScheme{}, "v1.3.0", "1.2.3\n"); result != "1.3.0\n" {
+		t.Errorf("formatVersionFile() = %q, expected %q", result, "1.3.0\n")
+	}
+	if result := formatVersionFile(bump.TagScheme{}, "v1.3.0", "v1.2.3"); result != "v1.3.0\n" {
+		t.Errorf("formatVersionFile() = %q, expected %q", result, "v1.3.0\n")
+	}
+}
+
+// TestIsBehindRemote tests the pure function for comparing local and remote latest tags
+func TestIsBehindRemote(t *testing.T) {
+	tests := []struct {
+		local    string
+		remote   string
+		expected bool
+	}{
+		{local: "v1.0.0", remote: "v1.1.0", expected: true},
+		{local: "v1.0.0", remote: "v1.0.0", expected: false},
+		{local: "v1.1.0", remote: "v1.0.0", expected: false},
+		{local: "v1.0.0-rc.1", remote: "v1.0.0", expected: true},
+		{local: "", remote: "v0.1.0", expected: true},
+		{local: "v1.0.0", remote: "", expected: false},
+	}
+	for _, tt := range tests {
+		if result := isBehindRemote(bump.TagScheme{}, tt.local, tt.remote); result != tt.expected {
+			t.Errorf("isBehindRemote(%q, %q) = %v, expected %v", tt.local, tt.remote, result, tt.expected)
+		}
+	}
+}
+
+// TestCheckSuffixPolicy tests the pure function validating suffixes against each policy permutation
+func TestCheckSuffixPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		bumpType    string
+		suffix      string
+		expectError string
+	}{
+		{name: "Empty policy allows suffix", policy: "", bumpType: "major", suffix: "rc.1"},
+		{name: "Stable major rejects suffix", policy: "major=stable", bumpType: "major", suffix: "rc.1", expectError: `suffix "rc.1" is not allowed on major bumps`},
+		{name: "Stable major allows plain major", policy: "major=stable", bumpType: "major"},
+		{name: "Stable major allows suffixed minor", policy: "major=stable", bumpType: "minor", suffix: "beta"},
+		{name: "Stable major allows suffixed patch", policy: "major=stable", bumpType: "patch", suffix: "beta"},
+		{name: "Any major allows suffix", policy: "major=any", bumpType: "major", suffix: "rc.1"},
+		{name: "Multiple entries", policy: "major=stable, minor=stable,patch=any", bumpType: "minor", suffix: "alpha", expectError: "not allowed on minor bumps"},
+		{name: "Multiple entries allow listed type", policy: "major=stable,minor=stable,patch=any", bumpType: "patch", suffix: "alpha"},
+		{name: "Later entry wins", policy: "patch=stable,patch=any", bumpType: "patch", suffix: "alpha"},
+		{name: "Unknown bump type", policy: "huge=stable", bumpType: "major", expectError: `invalid suffixPolicy entry "huge=stable"`},
+		{name: "Missing value", policy: "major", bumpType: "major", expectError: `invalid suffixPolicy entry "major"`},
+		{name: "Unknown value", policy: "major=never", bumpType: "minor", expectError: `invalid suffixPolicy value "never" for major`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSuffixPolicy(tt.policy, tt.bumpType, tt.suffix)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("checkSuffixPolicy() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("checkSuffixPolicy() error = %v, expected to contain %q", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestCheckTagNameOverride tests the pure function validating a --tag-name override
+func TestCheckTagNameOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagName     string
+		updateFile  bool
+		expectError string
+	}{
+		{name: "Irregular name", tagName: "2024-spring-release"},
+		{name: "Version name with update file", tagName: "v1.4.0-hotfix.2", updateFile: true},
+		{name: "Invalid characters", tagName: "my release", expectError: "invalid --tag-name"},
+		{name: "Invalid sequence", tagName: "v1..2", expectError: "invalid --tag-name"},
+		{name: "Not a version with update file", tagName: "2024-spring-release", updateFile: true, expectError: "cannot derive a version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTagNameOverride(bump.TagScheme{}, tt.tagName, tt.updateFile)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("checkTagNameOverride() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("checkTagNameOverride() error = %v, expected to contain %q", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestCheckPrereleaseBase tests the pure function rejecting core bumps off a pre-release base
+func TestCheckPrereleaseBase(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseTag     string
+		bumpType    string
+		increment   bool
+		expectError bool
+	}{
+		{name: "Release base", baseTag: "v1.2.0", bumpType: "minor"},
+		{name: "No tags", baseTag: "", bumpType: "patch"},
+		{name: "Pre-release base", baseTag: "v1.2.0-rc.1", bumpType: "minor", expectError: true},
+		{name: "Prerelease bump", baseTag: "v1.2.0-rc.1", bumpType: "prerelease"},
+		{name: "Increment mode", baseTag: "v1.2.0-rc.1", bumpType: "patch", increment: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPrereleaseBase(bump.TagScheme{}, tt.baseTag, tt.bumpType, tt.increment)
+			if (err != nil) != tt.expectError {
+				t.Errorf("checkPrereleaseBase() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestIsPromotion tests the pure function recognising a pre-release promoted on the same core
+func TestIsPromotion(t *testing.T) {
+	tests := []struct {
+		baseTag  string
+		nextTag  string
+		expected bool
+	}{
+		{baseTag: "v1.2.0-rc.1", nextTag: "v1.2.0", expected: true},
+		{baseTag: "v1.2.0-beta.2", nextTag: "v1.2.0-rc.1", expected: true},
+		{baseTag: "v1.2.0-rc.1", nextTag: "v1.2.0-rc.1", expected: false},
+		{baseTag: "v1.2.0-rc.1", nextTag: "v1.2.1", expected: false},
+		{baseTag: "v1.2.0", nextTag: "v1.2.1", expected: false},
+		{baseTag: "", nextTag: "v0.1.0", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseTag+" to "+tt.nextTag, func(t *testing.T) {
+			if got := isPromotion(bump.TagScheme{}, tt.baseTag, tt.nextTag); got != tt.expected {
+				t.Errorf("isPromotion(%q, %q) = %v, expected %v", tt.baseTag, tt.nextTag, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestResolveLightweight tests the pure function applying --annotated and --lightweight over the tagType default
+func TestResolveLightweight(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagType     string
+		annotated   bool
+		lightweight bool
+		expected    bool
+		expectError bool
+	}{
+		{name: "Unset default is annotated", expected: false},
+		{name: "Annotated default", tagType: "annotated", expected: false},
+		{name: "Lightweight default", tagType: "lightweight", expected: true},
+		{name: "Annotated overrides lightweight default", tagType: "lightweight", annotated: true, expected: false},
+		{name: "Lightweight overrides annotated default", tagType: "annotated", lightweight: true, expected: true},
+		{name: "Lightweight without default", lightweight: true, expected: true},
+		{name: "Flags conflict", annotated: true, lightweight: true, expectError: true},
+		{name: "Invalid default", tagType: "signed", expectError: true},
+		{name: "Flag bypasses invalid default", tagType: "signed", annotated: true, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resolveLightweight(tt.tagType, tt.annotated, tt.lightweight)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("resolveLightweight() error = %v, expectError %v", err, tt.expectError)
+			}
+			if result != tt.expected {
+				t.Errorf("resolveLightweight() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPreviewVersions tests the pure function computing every candidate next version
+func TestPreviewVersions(t *testing.T) {
+	tests := []struct {
+		name      string
+		latestTag string
+		suffix    string
+		expected  []VersionCandidate
+	}{
+		{
+			name:      "Release",
+			latestTag: "v1.2.3",
+			expected: []VersionCandidate{
+				{BumpType: "patch", Version: "v1.2.4"},
+				{BumpType: "minor", Version: "v1.3.0"},
+				{BumpType: "major", Version: "v2.0.0"},
+			},
+		},
+		{
+			name:      "Release with pre-release series",
+			latestTag: "v1.2.3",
+			suffix:    "rc",
+			expected: []VersionCandidate{
+				{BumpType: "patch", Version: "v1.2.4"},
+				{BumpType: "minor", Version: "v1.3.0"},
+				{BumpType: "major", Version: "v2.0.0"},
+				{BumpType: "prerelease", Version: "v1.2.4-rc.1"},
+			},
+		},
+		{
+			name:      "Pre-release",
+			latestTag: "v2.0.0-beta.2",
+			expected: []VersionCandidate{
+				{BumpType: "patch", Version: "v2.0.1"},
+				{BumpType: "minor", Version: "v2.1.0"},
+				{BumpType: "major", Version: "v3.0.0"},
+				{BumpType: "prerelease", Version: "v2.0.0-beta.3"},
+			},
+		},
+		{
+			name: "No tags",
+			expected: []VersionCandidate{
+				{BumpType: "patch", Version: "v0.1.0"},
+				{BumpType: "minor", Version: "v0.1.0"},
+				{BumpType: "major", Version: "v0.1.0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := previewVersions(bump.TagScheme{}, tt.latestTag, tt.suffix)
+			if err != nil {
+				t.Fatalf("previewVersions() unexpected error = %v", err)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("previewVersions() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestApplyNoOpPolicy tests the pure function mapping no-op outcomes under lenient and strict settings
+func TestApplyNoOpPolicy(t *testing.T) {
+	noChanges := fmt.Errorf("%w v1.0.0", ErrNoChanges)
+	failure := errors.New("push rejected")
+	tests := []struct {
+		name     string
+		result   *BumpResult
+		err      error
+		strict   bool
+		expected error
+	}{
+		{name: "Lenient idempotent no-op", result: &BumpResult{NoOp: true}, expected: nil},
+		{name: "Strict idempotent no-op", result: &BumpResult{NoOp: true}, strict: true, expected: ErrNoOp},
+		{name: "Lenient no changes", err: noChanges, expected: ErrNoChanges},
+		{name: "Strict no changes", err: noChanges, strict: true, expected: ErrNoOp},
+		{name: "Strict new tag", result: &BumpResult{NextTag: "v1.0.1"}, strict: true, expected: nil},
+		{name: "Strict other failure", err: failure, strict: true, expected: failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := applyNoOpPolicy(tt.result, tt.err, tt.strict)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("applyNoOpPolicy() = %v, expected nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("applyNoOpPolicy() = %v, expected %v", err, tt.expected)
+			}
+			if !tt.strict && errors.Is(err, ErrNoOp) {
+				t.Errorf("applyNoOpPolicy() = %v, lenient mode should not report ErrNoOp", err)
+			}
+		})
+	}
+}
+
+// TestDisplayVersion tests the pure function for rendering a tag for output
+func TestDisplayVersion(t *testing.T) {
+	if result := displayVersion(bump.TagScheme{}, "v1.2.3", true); result != "1.2.3" {
+		t.Errorf("displayVersion(strip) = %q, expected %q", result, "1.2.3")
+	}
+	if result := displayVersion(bump.TagScheme{}, "v1.2.3", false); result != "v1.2.3" {
+		t.Errorf("displayVersion() = %q, expected %q", result, "v1.2.3")
+	}
+}
+
+// TestIsPrerelease tests the pure function for detecting pre-release tags
+func TestIsPrerelease(t *testing.T) {
+	tests := map[string]bool{
+		"v1.0.0":        false,
+		"v1.0.0-rc.1":   true,
+		"v2.1.0-beta":   true,
+		"not-a-version": false,
+	}
+	for tag, expected := range tests {
+		if result := isPrerelease(bump.TagScheme{}, tag); result != expected {
+			t.Errorf("isPrerelease(%q) = %v, expected %v", tag, result, expected)
+		}
+	}
+}
+
 // TestFormatBumpMessage tests the pure function for formatting success messages
 func TestFormatBumpMessage(t *testing.T) {
 	tests := []struct {
@@ -198,6 +962,208 @@ func TestFormatBumpMessage(t *testing.T) {
 	}
 }
 
+// TestFormatChangelog tests the pure function for formatting release changelogs
+func TestFormatChangelog(t *testing.T) {
+	tests := []struct {
+		name        string
+		tag         string
+		previousTag string
+		commits     []CommitInfo
+		repoURL     string
+		expected    string
+	}{
+		{
+			name:        "Commits since previous tag",
+			tag:         "v1.1.0",
+			previousTag: "v1.0.0",
+			commits:     []CommitInfo{{Subject: "Add feature"}, {Subject: "Fix bug"}},
+			expected:    "Changes in v1.1.0 since v1.0.0:\n- Add feature\n- Fix bug\n",
+		},
+		{
+			name:     "First release",
+			tag:      "v0.1.0",
+			commits:  []CommitInfo{{Subject: "Initial commit"}},
+			expected: "Changes in v0.1.0:\n- Initial commit\n",
+		},
+		{
+			name:        "No commits",
+			tag:         "v1.0.1",
+			previousTag: "v1.0.0",
+			expected:    "No changes in v1.0.1 since v1.0.0\n",
+		},
+		{
+			name:        "Linked references",
+			tag:         "v1.1.0",
+			previousTag: "v1.0.0",
+			commits:     []CommitInfo{{Subject: "Add feature (#12)"}},
+			repoURL:     "https://github.com/acme/widgets",
+			expected:    "Changes in v1.1.0 since v1.0.0:\n- Add feature ([#12](https://github.com/acme/widgets/pull/12))\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatChangelog(tt.tag, tt.previousTag, tt.commits, tt.repoURL)
+			if result != tt.expected {
+				t.Errorf("formatChangelog() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestWithoutMerges tests the pure function for dropping merge commits from a range
+func TestWithoutMerges(t *testing.T) {
+	commits := []CommitInfo{
+		{Subject: "Merge branch 'feature'", IsMerge: true},
+		{Subject: "Add feature"},
+		{Subject: "Merge pull request #1", IsMerge: true},
+		{Subject: "Fix bug"},
+	}
+	got := withoutMerges(commits)
+	expected := []CommitInfo{{Subject: "Add feature"}, {Subject: "Fix bug"}}
+	if !slices.Equal(got, expected) {
+		t.Errorf("withoutMerges() = %+v, expected %+v", got, expected)
+	}
+	if got := withoutMerges(nil); len(got) != 0 {
+		t.Errorf("withoutMerges(nil) = %+v, expected none", got)
+	}
+}
+
+// TestLinkChangelogRefs tests the pure function for linking PR references and hashes in commit subjects
+func TestLinkChangelogRefs(t *testing.T) {
+	const repoURL = "https://github.com/acme/widgets"
+	tests := []struct {
+		name     string
+		subject  string
+		repoURL  string
+		expected string
+	}{
+		{
+			name:     "PR reference",
+			subject:  "Add widgets (#123)",
+			repoURL:  repoURL,
+			expected: "Add widgets ([#123](https://github.com/acme/widgets/pull/123))",
+		},
+		{
+			name:     "Short hash",
+			subject:  "Revert abc1234",
+			repoURL:  repoURL,
+			expected: "Revert [abc1234](https://github.com/acme/widgets/commit/abc1234)",
+		},
+		{
+			name:     "Full hash and PR reference",
+			subject:  "Revert 0123456789abcdef0123456789abcdef01234567 (#7)",
+			repoURL:  repoURL,
+			expected: "Revert [0123456789abcdef0123456789abcdef01234567](https://github.com/acme/widgets/commit/0123456789abcdef0123456789abcdef01234567) ([#7](https://github.com/acme/widgets/pull/7))",
+		},
+		{
+			name:     "Trailing slash and .git suffix",
+			subject:  "Fix bug (#4)",
+			repoURL:  "https://gitlab.com/acme/widgets.git/",
+			expected: "Fix bug ([#4](https://gitlab.com/acme/widgets/pull/4))",
+		},
+		{
+			name:     "Hex words and bare numbers are not hashes",
+			subject:  "Remove defaced assets from #9 and 1234567abcdefg",
+			repoURL:  repoURL,
+			expected: "Remove defaced assets from #9 and 1234567abcdefg",
+		},
+		{
+			name:     "No repo URL",
+			subject:  "Add widgets (#123) after abc1234",
+			expected: "Add widgets (#123) after abc1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := linkChangelogRefs(tt.subject, tt.repoURL); got != tt.expected {
+				t.Errorf("linkChangelogRefs() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConstantValues tests the pure function for resolving --constant specs
+func TestConstantValues(t *testing.T) {
+	got, err := constantValues([]string{"BuildVersion=release", " NextVersion = dev "}, "1.3.0", "1.3.1-dev")
+	if err != nil {
+		t.Fatalf("constantValues() unexpected error = %v", err)
+	}
+	expected := map[string]string{"BuildVersion": "1.3.0", "NextVersion": "1.3.1-dev"}
+	if !maps.Equal(got, expected) {
+		t.Errorf("constantValues() = %v, expected %v", got, expected)
+	}
+
+	for _, specs := range [][]string{{"BuildVersion"}, {"Build-Version=dev"}, {"BuildVersion=next"}, {"Version=dev"}, {"A=dev", "A=release"}} {
+		if _, err := constantValues(specs, "1.3.0", "1.3.1-dev"); err == nil {
+			t.Errorf("constantValues(%q) expected error", specs)
+		}
+	}
+}
+
+// TestFormatGitCommands tests the pure function for rendering git commands as shell lines
+func TestFormatGitCommands(t *testing.T) {
+	commands := [][]string{
+		{"git", "tag", "-s", "-m", "v1.2.3", "v1.2.3"},
+		{"git", "tag", "-F", "-", "v1.2.3", "HEAD~1"},
+		{"git", "tag", "-m", "it's", "v1.2.3"},
+	}
+	expected := "Would run:\n" +
+		"  git tag -s -m v1.2.3 v1.2.3\n" +
+		"  git tag -F - v1.2.3 'HEAD~1'\n" +
+		"  git tag -m 'it'\\''s' v1.2.3\n"
+	if got := formatGitCommands(commands); got != expected {
+		t.Errorf("formatGitCommands() = %q, expected %q", got, expected)
+	}
+}
+
+// TestFormatExplanation tests the pure function for describing how a version was computed
+func TestFormatExplanation(t *testing.T) {
+	tests := []struct {
+		name        string
+		explanation Explanation
+		expected    string
+	}{
+		{
+			name:        "Minor bump without suffix",
+			explanation: Explanation{LatestTag: "v1.2.3", TagCount: 14, BumpType: "minor", Result: "v1.3.0"},
+			expected:    "Latest tag: v1.2.3 (from 14 tags). Bump type: minor. Suffix: none. Result: v1.3.0.\n",
+		},
+		{
+			name:        "No tags with suffix",
+			explanation: Explanation{BumpType: "patch", Suffix: "rc.1", Result: "v0.1.0-rc.1"},
+			expected:    "Latest tag: none (from 0 tags). Bump type: patch. Suffix: rc.1. Result: v0.1.0-rc.1.\n",
+		},
+		{
+			name:        "Skipped tags and base file",
+			explanation: Explanation{LatestTag: "v1.0.0", TagCount: 1, Skipped: []string{"nightly"}, BaseTag: "v2.0.0", BaseFile: "VERSION", BumpType: "patch", Result: "v2.0.1"},
+			expected:    "Latest tag: v1.0.0 (from 1 tag; skipped as non-semver: nightly). Base version: v2.0.0 (from VERSION). Bump type: patch. Suffix: none. Result: v2.0.1.\n",
+		},
+		{
+			name:        "Tag name override",
+			explanation: Explanation{LatestTag: "v1.0.0", TagCount: 1, TagName: "spring", Result: "spring"},
+			expected:    "Latest tag: v1.0.0 (from 1 tag). Tag name: given by --tag-name. Result: spring.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExplanation(tt.explanation); got != tt.expected {
+				t.Errorf("formatExplanation() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestExplainTags tests counting distinct tags and listing those that are not semantic versions
+func TestExplainTags(t *testing.T) {
+	count, skipped := explainTags(bump.ScanTagNames([]string{"v1.0.0", "v1.0.0^{}", "nightly", "v1.1.0", "1.2.0"}))
+	if count != 4 || !slices.Equal(skipped, []string{"1.2.0", "nightly"}) {
+		t.Errorf("explainTags() = %d, %v; expected 4, [1.2.0 nightly]", count, skipped)
+	}
+}
+
 // TestFormatDryRunMessage tests the pure function for formatting dry-run messages
 func TestFormatDryRunMessage(t *testing.T) {
 	tests := []struct {
@@ -233,7 +1199,7 @@ func TestFormatDryRunMessage(t *testing.T) {
 			updateFile: "version.go",
 			expectedOutput: []string{
 				"Would create tag: v2.0.0",
-				"Would update file: version.go",
+				"Would update file version.go: Version -> 2.0.1-dev",
 			},
 		},
 		{
@@ -244,7 +1210,7 @@ func TestFormatDryRunMessage(t *testing.T) {
 			expectedOutput: []string{
 				"Would create tag: v0.5.0-beta",
 				"Would push tag to remote",
-				"Would update file: pkg/version/version.go",
+				"Would update file pkg/version/version.go: Version -> 0.5.1-dev",
 			},
 		},
 		{
@@ -260,7 +1226,7 @@ func TestFormatDryRunMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatDryRunMessage(tt.tag, tt.wouldPush, tt.updateFile)
+			result := formatDryRunMessage(bump.TagScheme{}, tt.tag, tt.wouldPush, tt.updateFile)
 
 			// Check that all expected substrings are present
 			for _, expected := range tt.expectedOutput {
@@ -278,7 +1244,7 @@ func TestFormatDryRunMessage(t *testing.T) {
 			}
 
 			// Verify updateFile message appears only when expected
-			if tt.updateFile != "" && !strings.Contains(result, fmt.Sprintf("Would update file: %s", tt.updateFile)) {
+			if tt.updateFile != "" && !strings.Contains(result, fmt.Sprintf("Would update file %s: Version -> ", tt.updateFile)) {
 				t.Errorf("formatDryRunMessage() missing file update message")
 			}
 			if tt.updateFile == "" && strings.Contains(result, "Would update file") {
@@ -287,3 +1253,61 @@ func TestFormatDryRunMessage(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatConfigDiff tests the pure function for rendering config change previews
+func TestFormatConfigDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		changes  []bump.ConfigChange
+		expected string
+	}{
+		{
+			name:     "No changes",
+			expected: "No config changes\n",
+		},
+		{
+			name:     "New key",
+			changes:  []bump.ConfigChange{{Section: "bump", Key: "defaultPush", NewValue: "true"}},
+			expected: "[bump]\n+ defaultPush = true\n",
+		},
+		{
+			name:     "Changed key",
+			changes:  []bump.ConfigChange{{Section: `tool "bump"`, Key: "defaultPush", OldValue: "true", NewValue: "false", WasSet: true}},
+			expected: "[tool \"bump\"]\n- defaultPush = true\n+ defaultPush = false\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatConfigDiff(tt.changes); result != tt.expected {
+				t.Errorf("formatConfigDiff() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatTimings tests the pure function for formatting phase durations
+func TestFormatTimings(t *testing.T) {
+	timings := BumpTimings{
+		TagEnumeration: 2 * time.Millisecond,
+		LatestTag:      500 * time.Microsecond,
+		TagCreation:    30 * time.Millisecond,
+		Push:           time.Second,
+		FileUpdate:     0,
+	}
+
+	result := formatTimings(timings)
+
+	expectedOutput := []string{
+		"tag_enumeration=2ms\n",
+		"latest_tag=500µs\n",
+		"tag_creation=30ms\n",
+		"push=1s\n",
+		"file_update=0s\n",
+	}
+	for _, expected := range expectedOutput {
+		if !strings.Contains(result, expected) {
+			t.Errorf("formatTimings() output missing expected substring:\nGot: %v\nExpected to contain: %v", result, expected)
+		}
+	}
+}
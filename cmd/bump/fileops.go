@@ -7,19 +7,73 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 // VersionFileUpdater handles parsing, updating, and writing Go files
 // that contain version constants. This struct isolates file operations
 // from git operations for better testability.
-type VersionFileUpdater struct{}
+type VersionFileUpdater struct {
+	field         []string // Variable name and keys locating a version field; empty for the Version constant
+	expectPackage string   // Package the file must declare; empty accepts any package
+	tomlPath      []string // Keys locating the version in a TOML file; empty for the Cargo.toml or pyproject.toml default
+}
 
 // NewVersionFileUpdater creates a new VersionFileUpdater instance.
 func NewVersionFileUpdater() *VersionFileUpdater {
 	return &VersionFileUpdater{}
 }
 
+// NewFieldVersionFileUpdater creates a VersionFileUpdater that reads and writes a
+// version field in a composite literal instead of the Version constant. The
+// selector names the variable followed by the keys leading to the field, so
+// "info.Version" selects Version in var info = BuildInfo{Version: "1.2.3"} and
+// "info.Meta.Version" selects it in a nested literal.
+func NewFieldVersionFileUpdater(selector string) (*VersionFileUpdater, error) {
+	field := strings.Split(selector, ".")
+	if len(field) < 2 {
+		return nil, fmt.Errorf("invalid version field %q: expected <var>.<Key>, e.g. info.Version", selector)
+	}
+	for _, name := range field {
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("invalid version field %q: %q is not a Go identifier", selector, name)
+		}
+	}
+	return &VersionFileUpdater{field: field}, nil
+}
+
+// ExpectPackage makes the updater reject files whose package clause does not name
+// the given package, catching an update file pointed at the wrong file.
+func (u *VersionFileUpdater) ExpectPackage(name string) error {
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("invalid package name %q: not a Go identifier", name)
+	}
+	u.expectPackage = name
+	return nil
+}
+
+// SetTOMLPath makes the updater read and write the version of a TOML file at the
+// given dotted key path, such as package.version, instead of the conventional key
+// for Cargo.toml or pyproject.toml.
+func (u *VersionFileUpdater) SetTOMLPath(path string) error {
+	keys, err := parseTOMLPath(path)
+	if err != nil {
+		return err
+	}
+	u.tomlPath = keys
+	return nil
+}
+
+// checkPackage returns an error if the file does not declare the expected package.
+func (u *VersionFileUpdater) checkPackage(node *ast.File) error {
+	if u.expectPackage != "" && node.Name.Name != u.expectPackage {
+		return fmt.Errorf("file declares package %s, expected package %s", node.Name.Name, u.expectPackage)
+	}
+	return nil
+}
+
 // ParseGoFile parses a Go source file and returns its AST representation.
 // This function is pure file I/O - no git operations.
 func (u *VersionFileUpdater) ParseGoFile(filePath string) (*ast.File, *token.FileSet, error) {
@@ -33,25 +87,132 @@ func (u *VersionFileUpdater) ParseGoFile(filePath string) (*ast.File, *token.Fil
 
 // UpdateVersionConstant finds and updates the "Version" constant in an AST.
 // It searches for a const declaration with a "Version" identifier and updates
-// its value to the provided newVersion string.
-// Returns an error if the Version constant is not found.
+// its value to the provided newVersion string. When the updater has a version
+// field, that field is updated instead.
+// Returns an error if the Version constant is not found or the file does not
+// declare the expected package.
 func (u *VersionFileUpdater) UpdateVersionConstant(node *ast.File, newVersion string) error {
-	updated := false
+	if err := u.checkPackage(node); err != nil {
+		return err
+	}
+	if len(u.field) > 0 {
+		kv, err := findVersionField(node, u.field)
+		if err != nil {
+			return err
+		}
+		kv.Value = &ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf(`"%s"`, newVersion),
+		}
+		return nil
+	}
+
+	return u.UpdateVersionConstants(node, map[string]string{"Version": newVersion})
+}
+
+// UpdateVersionConstants updates several string constants in one walk of the AST,
+// e.g. Version to the release and BuildVersion to the development version. values
+// maps each constant name to its new value. Returns an error naming every
+// constant that is not found; in that case the AST may be partially updated.
+func (u *VersionFileUpdater) UpdateVersionConstants(node *ast.File, values map[string]string) error {
+	if err := u.checkPackage(node); err != nil {
+		return err
+	}
+
+	found := make(map[string]bool, len(values))
+	ast.Inspect(node, func(n ast.Node) bool {
+		gen, ok := n.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			return true
+		}
+		for _, spec := range gen.Specs {
+			value, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, ident := range value.Names {
+				newValue, wanted := values[ident.Name]
+				if !wanted || found[ident.Name] || i >= len(value.Values) {
+					continue
+				}
+				value.Values[i] = &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf(`"%s"`, newValue),
+				}
+				found[ident.Name] = true
+			}
+		}
+		return false
+	})
+
+	var missing []string
+	for name := range values {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("version constant not found in file: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ReadVersionConstant returns the value of the "Version" constant in an AST
+// without modifying it. Returns an error if the constant is not found or is
+// not a string literal. When the updater has a version field, that field is read.
+func (u *VersionFileUpdater) ReadVersionConstant(node *ast.File) (string, error) {
+	if err := u.checkPackage(node); err != nil {
+		return "", err
+	}
+	var expr ast.Expr
+	if len(u.field) > 0 {
+		kv, err := findVersionField(node, u.field)
+		if err != nil {
+			return "", err
+		}
+		expr = kv.Value
+	} else {
+		value, i := findVersionSpec(node)
+		if value == nil {
+			return "", fmt.Errorf("version constant not found in file")
+		}
+		expr = value.Values[i]
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", fmt.Errorf("version constant is not a string literal")
+	}
+
+	version, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to read version constant: %w", err)
+	}
+
+	return version, nil
+}
+
+// findVersionSpec locates the const declaration holding the "Version" identifier.
+// It returns the value spec and the index of the identifier within it, or nil
+// if no such constant exists.
+func findVersionSpec(node *ast.File) (*ast.ValueSpec, int) {
+	var found *ast.ValueSpec
+	index := -1
 
 	ast.Inspect(node, func(n ast.Node) bool {
+		if found != nil {
+			return false // Stop searching
+		}
 		// Look for const declarations
 		if gen, ok := n.(*ast.GenDecl); ok && gen.Tok == token.CONST {
 			for _, spec := range gen.Specs {
 				if value, ok := spec.(*ast.ValueSpec); ok {
 					// Check each identifier in the const declaration
 					for i, ident := range value.Names {
-						if ident.Name == "Version" {
-							// Update the value with the new version
-							value.Values[i] = &ast.BasicLit{
-								Kind:  token.STRING,
-								Value: fmt.Sprintf(`"%s"`, newVersion),
-							}
-							updated = true
+						if ident.Name == "Version" && i < len(value.Values) {
+							found = value
+							index = i
 							return false // Stop searching
 						}
 					}
@@ -61,11 +222,66 @@ func (u *VersionFileUpdater) UpdateVersionConstant(node *ast.File, newVersion st
 		return true // Continue searching
 	})
 
-	if !updated {
-		return fmt.Errorf("version constant not found in file")
+	return found, index
+}
+
+// findVersionField locates the key-value pair selected by field: the first element
+// names a package-level variable initialized with a composite literal, and each
+// following element is a key, descending into nested literals.
+func findVersionField(node *ast.File, field []string) (*ast.KeyValueExpr, error) {
+	selector := strings.Join(field, ".")
+	var lit *ast.CompositeLit
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, ident := range value.Names {
+				if ident.Name == field[0] && i < len(value.Values) {
+					lit = compositeLit(value.Values[i])
+				}
+			}
+		}
+	}
+	if lit == nil {
+		return nil, fmt.Errorf("version field %s not found in file: no variable %s initialized with a composite literal", selector, field[0])
 	}
 
-	return nil
+	for depth, key := range field[1:] {
+		var found *ast.KeyValueExpr
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == key {
+					found = kv
+					break
+				}
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("version field %s not found in file: no key %s", selector, key)
+		}
+		if depth == len(field)-2 {
+			return found, nil
+		}
+		if lit = compositeLit(found.Value); lit == nil {
+			return nil, fmt.Errorf("version field %s not found in file: %s is not a composite literal", selector, key)
+		}
+	}
+	return nil, fmt.Errorf("version field %s not found in file", selector)
+}
+
+// compositeLit returns expr as a composite literal, looking through a leading &.
+func compositeLit(expr ast.Expr) *ast.CompositeLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, _ := expr.(*ast.CompositeLit)
+	return lit
 }
 
 // WriteFormattedFile formats an AST and writes it back to a file.
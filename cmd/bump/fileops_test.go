@@ -2,6 +2,7 @@ package main
 
 import (
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
 	"os"
@@ -222,6 +223,270 @@ const AppName = "test"
 	}
 }
 
+// TestUpdateVersionConstants tests updating two constants in one pass and reporting missing ones
+func TestUpdateVersionConstants(t *testing.T) {
+	const content = `package main
+
+const (
+	Version      = "1.0.1-dev"
+	BuildVersion = "1.0.0"
+)
+`
+	updater := NewVersionFileUpdater()
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse test fixture: %v", err)
+	}
+
+	if err := updater.UpdateVersionConstants(node, map[string]string{"Version": "1.1.1-dev", "BuildVersion": "1.1.0"}); err != nil {
+		t.Fatalf("UpdateVersionConstants() unexpected error = %v", err)
+	}
+	var buf strings.Builder
+	if err := format.Node(&buf, fset, node); err != nil {
+		t.Fatalf("failed to format AST: %v", err)
+	}
+	for _, want := range []string{`Version      = "1.1.1-dev"`, `BuildVersion = "1.1.0"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("updated file missing %s:\n%s", want, buf.String())
+		}
+	}
+
+	err = updater.UpdateVersionConstants(node, map[string]string{"Version": "1.2.0", "SchemaVersion": "2", "APIVersion": "v2"})
+	if err == nil || !strings.HasSuffix(err.Error(), "APIVersion, SchemaVersion") {
+		t.Errorf("UpdateVersionConstants() error = %v, expected to name both missing constants", err)
+	}
+}
+
+// TestUpdateVersionField tests updating a version field in struct literals, including nested ones
+func TestUpdateVersionField(t *testing.T) {
+	tests := []struct {
+		name         string
+		selector     string
+		content      string
+		expectError  bool
+		expectedCode string
+	}{
+		{
+			name:     "Struct literal field",
+			selector: "info.Version",
+			content: `package main
+
+var info = BuildInfo{Name: "app", Version: "1.2.3"}
+`,
+			expectedCode: `package main
+
+var info = BuildInfo{Name: "app", Version: "2.0.0"}
+`,
+		},
+		{
+			name:     "Nested literal field",
+			selector: "info.Meta.Version",
+			content: `package main
+
+var info = &BuildInfo{
+	Version: "keep",
+	Meta: Meta{
+		Version: "1.2.3",
+	},
+}
+`,
+			expectedCode: `package main
+
+var info = &BuildInfo{
+	Version: "keep",
+	Meta: Meta{
+		Version: "2.0.0",
+	},
+}
+`,
+		},
+		{
+			name:     "Missing key",
+			selector: "info.Release",
+			content: `package main
+
+var info = BuildInfo{Version: "1.2.3"}
+`,
+			expectError: true,
+		},
+		{
+			name:     "Variable is not a composite literal",
+			selector: "info.Version",
+			content: `package main
+
+var info = newBuildInfo()
+`,
+			expectError: true,
+		},
+		{
+			name:     "Nested key is not a composite literal",
+			selector: "info.Version.Major",
+			content: `package main
+
+var info = BuildInfo{Version: "1.2.3"}
+`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updater, err := NewFieldVersionFileUpdater(tt.selector)
+			if err != nil {
+				t.Fatalf("NewFieldVersionFileUpdater() unexpected error = %v", err)
+			}
+			fset := token.NewFileSet()
+			node, err := parser.ParseFile(fset, "test.go", tt.content, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse test fixture: %v", err)
+			}
+
+			err = updater.UpdateVersionConstant(node, "2.0.0")
+			if (err != nil) != tt.expectError {
+				t.Fatalf("UpdateVersionConstant() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+
+			var buf strings.Builder
+			if err := format.Node(&buf, fset, node); err != nil {
+				t.Fatalf("failed to format AST: %v", err)
+			}
+			if buf.String() != tt.expectedCode {
+				t.Errorf("updated code = %q, expected %q", buf.String(), tt.expectedCode)
+			}
+
+			version, err := updater.ReadVersionConstant(node)
+			if err != nil || version != "2.0.0" {
+				t.Errorf("ReadVersionConstant() = %q, %v, expected 2.0.0", version, err)
+			}
+		})
+	}
+}
+
+// TestNewFieldVersionFileUpdaterInvalid tests rejecting malformed version field selectors
+func TestNewFieldVersionFileUpdaterInvalid(t *testing.T) {
+	for _, selector := range []string{"Version", "info.", "info..Version", "info.1st"} {
+		if _, err := NewFieldVersionFileUpdater(selector); err == nil {
+			t.Errorf("NewFieldVersionFileUpdater(%q) should return an error", selector)
+		}
+	}
+}
+
+// TestExpectPackage tests rejecting a version file that declares another package
+func TestExpectPackage(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectPackage string
+		errMsg        string
+	}{
+		{name: "Matching package", expectPackage: "version"},
+		{name: "Mismatching package", expectPackage: "main", errMsg: "file declares package version, expected package main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := parser.ParseFile(token.NewFileSet(), "version.go", "package version\n\nconst Version = \"1.0.0\"\n", 0)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+			updater := NewVersionFileUpdater()
+			if err := updater.ExpectPackage(tt.expectPackage); err != nil {
+				t.Fatalf("ExpectPackage() unexpected error = %v", err)
+			}
+
+			_, readErr := updater.ReadVersionConstant(node)
+			updateErr := updater.UpdateVersionConstant(node, "1.0.1-dev")
+			for _, err := range []error{readErr, updateErr} {
+				if tt.errMsg == "" && err != nil {
+					t.Errorf("unexpected error = %v", err)
+				}
+				if tt.errMsg != "" && (err == nil || err.Error() != tt.errMsg) {
+					t.Errorf("error = %v, expected %q", err, tt.errMsg)
+				}
+			}
+		})
+	}
+
+	if err := NewVersionFileUpdater().ExpectPackage("not-a-package"); err == nil {
+		t.Error("ExpectPackage() should reject a name that is not a Go identifier")
+	}
+}
+
+// TestReadVersionConstant tests reading the Version constant without modifying it
+func TestReadVersionConstant(t *testing.T) {
+	updater := NewVersionFileUpdater()
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    string
+		expectError bool
+	}{
+		{
+			name: "Simple Version constant",
+			content: `package main
+
+const Version = "1.2.4-dev"
+`,
+			expected: "1.2.4-dev",
+		},
+		{
+			name: "Version in const block",
+			content: `package main
+
+const (
+	AppName = "test"
+	Version = "0.3.0"
+)
+`,
+			expected: "0.3.0",
+		},
+		{
+			name:     "Raw string literal",
+			content:  "package main\n\nconst Version = `2.0.0`\n",
+			expected: "2.0.0",
+		},
+		{
+			name: "No Version constant",
+			content: `package main
+
+const AppName = "test"
+`,
+			expectError: true,
+		},
+		{
+			name: "Version is not a string",
+			content: `package main
+
+const Version = 3
+`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			node, err := parser.ParseFile(fset, "test.go", tt.content, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse test fixture: %v", err)
+			}
+
+			version, err := updater.ReadVersionConstant(node)
+			if (err != nil) != tt.expectError {
+				t.Errorf("ReadVersionConstant() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if !tt.expectError && version != tt.expected {
+				t.Errorf("ReadVersionConstant() = %v, expected %v", version, tt.expected)
+			}
+		})
+	}
+}
+
 // TestWriteFormattedFile tests writing AST back to file
 func TestWriteFormattedFile(t *testing.T) {
 	updater := NewVersionFileUpdater()
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
@@ -17,17 +24,103 @@ type GitRepository interface {
 	// Tags returns an iterator over all tags in the repository
 	Tags() (storer.ReferenceIter, error)
 
-	// CreateTag creates a new annotated tag at HEAD
-	CreateTag(name string) error
+	// CreateTag creates a new annotated tag at HEAD using the given options
+	CreateTag(name string, opts bump.TagOptions) error
 
-	// PushTags pushes all tags to the remote repository
-	PushTags() error
+	// DeleteTag deletes a local tag
+	DeleteTag(name string) error
+
+	// ReplaceTag moves an existing local tag to the target in opts (HEAD by default)
+	ReplaceTag(name string, opts bump.TagOptions) error
+
+	// ForcePushTag pushes a single tag to the given remote, overwriting it there
+	ForcePushTag(remote, name string) error
+
+	// PushTag pushes a single tag to the given remote
+	PushTag(remote, name string) error
+
+	// PushTags pushes all tags to the given remote
+	PushTags(remote string) error
+
+	// Remotes returns the names of the configured remotes, sorted
+	Remotes() ([]string, error)
 
 	// Worktree returns the working tree for this repository
 	Worktree() (GitWorktree, error)
 
 	// Path returns the filesystem path to the repository
 	Path() string
+
+	// TagScheme returns the settings that decide which tags are versions and how they sort
+	TagScheme() bump.TagScheme
+
+	// RemoteTags returns the names of the tags published on the given remote
+	RemoteTags(remote string) ([]string, error)
+
+	// FetchTags fetches the tags published on the given remote
+	FetchTags(remote string) error
+
+	// UserIdentity returns the user.name and user.email git would use in this repository
+	UserIdentity() (name, email string, err error)
+
+	// CommitsSince returns the commits reachable from HEAD but not from the given
+	// tag, newest first. An empty tag returns the full history.
+	CommitsSince(tag string) ([]CommitInfo, error)
+
+	// RemoteURL returns the first URL configured for the named remote
+	RemoteURL(name string) (string, error)
+
+	// HeadHash returns the full hash of the commit HEAD points at
+	HeadHash() (string, error)
+
+	// HeadCommit returns the commit HEAD points at
+	HeadCommit() (*object.Commit, error)
+
+	// HeadPushed reports whether HEAD is reachable from any remote-tracking branch
+	HeadPushed() (bool, error)
+
+	// SigningFormat returns the configured tag signing format, validating its key setup
+	SigningFormat() (string, error)
+
+	// DetachedHead reports whether HEAD points directly at a commit rather than a branch
+	DetachedHead() (bool, error)
+
+	// TagSubject returns the subject line of the commit a tag points at
+	TagSubject(tag string) (string, error)
+
+	// TagCommit returns the full hash of the commit a tag points at
+	TagCommit(tag string) (string, error)
+
+	// TagsAt returns the names of the tags pointing at the given commit
+	TagsAt(hash string) ([]string, error)
+
+	// ExistingTagOptions returns the options that recreate an existing tag as it is
+	ExistingTagOptions(name string) (bump.TagOptions, error)
+
+	// RevertCommit commits the inverse of the given commit's changes with the given message
+	RevertCommit(hash, message string) error
+
+	// ResetHead moves the current branch to the given commit, resetting the index and working tree
+	ResetHead(hash string) error
+
+	// CreateBranch creates a new branch at HEAD and checks it out
+	CreateBranch(name string) error
+
+	// CurrentBranch returns the checked-out branch, or an empty string when HEAD is detached
+	CurrentBranch() (string, error)
+
+	// AbandonBranch checks out previous, a branch or commit hash, and deletes the branch name
+	AbandonBranch(name, previous string) error
+
+	// ProbeLock checks that the repository lock is free, failing with bump.ErrLockBusy when it is held
+	ProbeLock() error
+}
+
+// CommitInfo describes a single commit in the repository history.
+type CommitInfo struct {
+	Hash    string // Full commit hash
+	Subject string // First line of the commit message
+	IsMerge bool   // Whether the commit has more than one parent
 }
 
 // GitWorktree defines the interface for git working tree operations.
@@ -38,12 +131,65 @@ type GitWorktree interface {
 
 	// Commit creates a new commit with the staged changes
 	Commit(msg string, opts *git.CommitOptions) (plumbing.Hash, error)
+
+	// Status returns the staged and unstaged state of every changed file
+	Status() (git.Status, error)
+}
+
+// RepositoryOpener opens the git repository at the given path.
+// It lets the service reach other repositories, such as submodules, without
+// depending on go-git directly.
+type RepositoryOpener func(path string) (GitRepository, error)
+
+// openGoGitRepository is the default RepositoryOpener backed by go-git. The
+// repository's tags are read with its own tag settings.
+func openGoGitRepository(path string) (GitRepository, error) {
+	scheme, err := bump.ReadTagScheme(path)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := NewGoGitRepository(path)
+	if err != nil {
+		return nil, err
+	}
+	repo.scheme = scheme
+	return repo, nil
 }
 
 // GoGitRepository is the real implementation of GitRepository using go-git.
 type GoGitRepository struct {
-	repo *git.Repository
-	path string
+	repo   *git.Repository
+	path   string
+	scheme bump.TagScheme // Tag settings, the default unless set by the opener
+
+	identityOnce sync.Once // Guards the one-time read of the identity below
+	userName     string    // Cached user.name, read on first use by UserIdentity
+	userEmail    string    // Cached user.email, read on first use by UserIdentity
+	identityErr  error     // Error from reading the identity, returned on every call
+}
+
+// loadRepoConfig reads a repository's git config merged with the global and system
+// configs, local values taking precedence. It is a variable so tests can count reads.
+var loadRepoConfig = func(repo *git.Repository) (*config.Config, error) {
+	return repo.ConfigScoped(config.SystemScope)
+}
+
+// Placeholder identity for commits bump creates when user.name or user.email is unset.
+const (
+	placeholderAuthorName  = "Bump CLI"
+	placeholderAuthorEmail = "bump@localhost"
+)
+
+// commitSignature returns the author of a commit bump creates: the configured git
+// identity, with the placeholder filling in whichever part is unset.
+func commitSignature(name, email string, when time.Time) *object.Signature {
+	if name == "" {
+		name = placeholderAuthorName
+	}
+	if email == "" {
+		email = placeholderAuthorEmail
+	}
+	return &object.Signature{Name: name, Email: email, When: when}
 }
 
 // NewGoGitRepository creates a new GoGitRepository by opening an existing git repo.
@@ -65,13 +211,52 @@ func (r *GoGitRepository) Tags() (storer.ReferenceIter, error) {
 }
 
 // CreateTag creates a new annotated tag at HEAD using the bump package.
-func (r *GoGitRepository) CreateTag(name string) error {
-	return bump.CreateTag(name)
+func (r *GoGitRepository) CreateTag(name string, opts bump.TagOptions) error {
+	return bump.CreateTagInRepo(r.path, name, opts)
+}
+
+// ProbeLock checks that the repository lock is free using the bump package.
+func (r *GoGitRepository) ProbeLock() error {
+	return bump.ProbeLockInRepo(r.path)
+}
+
+// DeleteTag deletes a local tag using the bump package.
+func (r *GoGitRepository) DeleteTag(name string) error {
+	return bump.DeleteTagInRepo(r.path, name)
+}
+
+// ReplaceTag moves an existing local tag using the bump package.
+func (r *GoGitRepository) ReplaceTag(name string, opts bump.TagOptions) error {
+	return bump.ReplaceTagInRepo(r.path, name, opts)
 }
 
-// PushTags pushes all tags to the remote repository using the bump package.
-func (r *GoGitRepository) PushTags() error {
-	return bump.PushTag()
+// ForcePushTag force-pushes a single tag to the given remote using the bump package.
+func (r *GoGitRepository) ForcePushTag(remote, name string) error {
+	return bump.ForcePushTagInRepo(r.path, remote, name)
+}
+
+// PushTag pushes a single tag to the given remote using the bump package.
+func (r *GoGitRepository) PushTag(remote, name string) error {
+	return bump.PushSingleTagInRepo(r.path, remote, name)
+}
+
+// PushTags pushes all tags to the given remote using the bump package.
+func (r *GoGitRepository) PushTags(remote string) error {
+	return bump.PushTagInRepo(r.path, remote)
+}
+
+// Remotes returns the names of the configured remotes, sorted.
+func (r *GoGitRepository) Remotes() ([]string, error) {
+	remotes, err := r.repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	names := make([]string, 0, len(remotes))
+	for _, remote := range remotes {
+		names = append(names, remote.Config().Name)
+	}
+	sort.Strings(names)
+	return names, nil
 }
 
 // Worktree returns the working tree for this repository.
@@ -88,6 +273,386 @@ func (r *GoGitRepository) Path() string {
 	return r.path
 }
 
+// TagScheme returns the settings that decide which tags are versions and how they sort.
+func (r *GoGitRepository) TagScheme() bump.TagScheme {
+	return r.scheme
+}
+
+// RemoteTags returns the names of the tags published on the given remote.
+func (r *GoGitRepository) RemoteTags(remote string) ([]string, error) {
+	return bump.ListRemoteTags(r.path, remote)
+}
+
+// FetchTags fetches the tags published on the given remote using the bump package.
+func (r *GoGitRepository) FetchTags(remote string) error {
+	return bump.FetchTagsInRepo(r.path, remote)
+}
+
+// UserIdentity returns the user.name and user.email git would use in this repository.
+// The config is read once and cached, so operations that commit repeatedly, such
+// as a recursive bump, do not reread it.
+func (r *GoGitRepository) UserIdentity() (string, string, error) {
+	r.identityOnce.Do(func() {
+		cfg, err := loadRepoConfig(r.repo)
+		if err != nil {
+			r.identityErr = fmt.Errorf("failed to read git config: %w", err)
+			return
+		}
+		r.userName, r.userEmail = cfg.User.Name, cfg.User.Email
+	})
+	return r.userName, r.userEmail, r.identityErr
+}
+
+// RemoteURL returns the first URL configured for the named remote.
+func (r *GoGitRepository) RemoteURL(name string) (string, error) {
+	remote, err := r.repo.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to find remote %s: %w", name, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote %s has no URL", name)
+	}
+	return urls[0], nil
+}
+
+// HeadHash returns the full hash of the commit HEAD points at.
+func (r *GoGitRepository) HeadHash() (string, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return head.Hash().String(), nil
+}
+
+// CommitsSince returns the commits reachable from HEAD but not from the given tag.
+func (r *GoGitRepository) CommitsSince(tag string) ([]CommitInfo, error) {
+	var from string
+	if tag != "" {
+		tagCommit, err := r.resolveTagCommit(tag)
+		if err != nil {
+			return nil, err
+		}
+		from = tagCommit.String()
+	}
+
+	history, err := bump.CommitsBetween(r.repo, from, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	var commits []CommitInfo
+	for _, c := range history {
+		commits = append(commits, CommitInfo{
+			Hash:    c.Hash.String(),
+			Subject: strings.SplitN(strings.TrimSpace(c.Message), "\n", 2)[0],
+			IsMerge: c.NumParents() > 1,
+		})
+	}
+	return commits, nil
+}
+
+// HeadCommit returns the commit HEAD points at.
+func (r *GoGitRepository) HeadCommit() (*object.Commit, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return r.repo.CommitObject(head.Hash())
+}
+
+// HeadPushed reports whether the commit HEAD points at is reachable from any
+// remote-tracking branch, meaning rewriting it would diverge from a remote.
+func (r *GoGitRepository) HeadPushed() (bool, error) {
+	headCommit, err := r.HeadCommit()
+	if err != nil {
+		return false, err
+	}
+
+	refs, err := r.repo.References()
+	if err != nil {
+		return false, fmt.Errorf("failed to list references: %w", err)
+	}
+	defer refs.Close()
+
+	pushed := false
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if !ref.Name().IsRemote() || ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		remoteCommit, err := r.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil
+		}
+		if remoteCommit.Hash == headCommit.Hash {
+			pushed = true
+			return storer.ErrStop
+		}
+		if isAncestor, err := headCommit.IsAncestor(remoteCommit); err == nil && isAncestor {
+			pushed = true
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect remote branches: %w", err)
+	}
+	return pushed, nil
+}
+
+// SigningFormat returns the configured tag signing format using the bump package.
+func (r *GoGitRepository) SigningFormat() (string, error) {
+	return bump.SigningFormat(r.path)
+}
+
+// DetachedHead reports whether HEAD points directly at a commit rather than a
+// branch, as it does in many CI checkouts.
+func (r *GoGitRepository) DetachedHead() (bool, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return head.Name() == plumbing.HEAD, nil
+}
+
+// TagSubject returns the subject line of the commit a tag points at, peeling
+// annotated tags to their commit.
+func (r *GoGitRepository) TagSubject(tag string) (string, error) {
+	hash, err := r.resolveTagCommit(tag)
+	if err != nil {
+		return "", err
+	}
+	commit, err := r.repo.CommitObject(hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit for tag %s: %w", tag, err)
+	}
+	return strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0], nil
+}
+
+// TagCommit returns the full hash of the commit a tag points at.
+func (r *GoGitRepository) TagCommit(tag string) (string, error) {
+	hash, err := r.resolveTagCommit(tag)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// TagsAt returns the names of the tags pointing at the given commit. Only the
+// annotated tag objects among the refs are read; commits are never loaded.
+func (r *GoGitRepository) TagsAt(hash string) ([]string, error) {
+	target := plumbing.NewHash(hash)
+	refs, err := r.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer refs.Close()
+
+	var names []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		peeled := ref.Hash()
+		for peeled != target {
+			tagObj, err := r.repo.TagObject(peeled)
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				return nil // a lightweight tag, or a chain ending elsewhere
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read tag object %s: %w", peeled, err)
+			}
+			peeled = tagObj.Target
+		}
+		names = append(names, ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+	return names, nil
+}
+
+// ExistingTagOptions returns the options that recreate the named tag as it is: a
+// lightweight tag stays lightweight, and an annotated tag keeps its message and is
+// signed again when its tag object carries a signature.
+func (r *GoGitRepository) ExistingTagOptions(name string) (bump.TagOptions, error) {
+	ref, err := r.repo.Tag(name)
+	if err != nil {
+		return bump.TagOptions{}, fmt.Errorf("failed to resolve tag %s: %w", name, err)
+	}
+	tagObj, err := r.repo.TagObject(ref.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return bump.TagOptions{Lightweight: true}, nil
+	}
+	if err != nil {
+		return bump.TagOptions{}, fmt.Errorf("failed to read tag object %s: %w", name, err)
+	}
+	return bump.TagOptions{Message: tagObj.Message, Sign: tagObj.PGPSignature != ""}, nil
+}
+
+// RevertCommit restores every file the given commit changed to its content in the
+// commit's parent and commits the result with the given message. It refuses to
+// revert a file that was changed again after the commit, rather than merging.
+func (r *GoGitRepository) RevertCommit(hash, message string) error {
+	commit, err := r.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %w", hash, err)
+	}
+	if commit.NumParents() != 1 {
+		return fmt.Errorf("cannot revert %s: only commits with a single parent can be reverted", shortHash(hash))
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return fmt.Errorf("failed to read parent of %s: %w", shortHash(hash), err)
+	}
+	changes, err := parent.Patch(commit)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s: %w", shortHash(hash), err)
+	}
+	head, err := r.HeadCommit()
+	if err != nil {
+		return err
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD tree: %w", err)
+	}
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get working tree: %w", err)
+	}
+
+	for _, patch := range changes.FilePatches() {
+		before, after := patch.Files()
+		var name string
+		if after != nil {
+			name = after.Path()
+		} else {
+			name = before.Path()
+		}
+
+		// HEAD must still hold the commit's version of the file
+		headFile, err := headTree.FindEntry(name)
+		unchanged := err != nil // deleted by the commit and still absent
+		if after != nil {
+			unchanged = err == nil && headFile.Hash == after.Hash()
+		}
+		if !unchanged {
+			return fmt.Errorf("cannot revert %s: %s was changed by a later commit", shortHash(hash), name)
+		}
+
+		absPath := filepath.Join(r.path, filepath.FromSlash(name))
+		if before == nil {
+			if _, err := wt.Remove(name); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+			continue
+		}
+		file, err := parent.File(before.Path())
+		if err != nil {
+			return fmt.Errorf("failed to read %s from %s: %w", name, shortHash(parent.Hash.String()), err)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to read %s from %s: %w", name, shortHash(parent.Hash.String()), err)
+		}
+		mode, err := file.Mode.ToOSFileMode()
+		if err != nil {
+			return fmt.Errorf("unsupported mode for %s: %w", name, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(absPath, []byte(content), mode.Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", name, err)
+		}
+	}
+
+	name, email, err := r.UserIdentity()
+	if err != nil {
+		return err
+	}
+	_, err = (&GoGitWorktree{worktree: wt}).Commit(message, &git.CommitOptions{Author: commitSignature(name, email, time.Now())})
+	if err != nil {
+		return fmt.Errorf("failed to commit revert: %w", err)
+	}
+	return nil
+}
+
+// ResetHead hard-resets the current branch, index, and working tree to the given commit.
+func (r *GoGitRepository) ResetHead(hash string) error {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get working tree: %w", err)
+	}
+	if err := wt.Reset(&git.ResetOptions{Commit: plumbing.NewHash(hash), Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", shortHash(hash), err)
+	}
+	return nil
+}
+
+// CreateBranch creates a branch at HEAD and checks it out, keeping any changes in
+// the working tree. It fails if the branch already exists.
+func (r *GoGitRepository) CreateBranch(name string) error {
+	branch := plumbing.NewBranchReferenceName(name)
+	if _, err := r.repo.Reference(branch, false); err == nil {
+		return fmt.Errorf("branch %s already exists", name)
+	}
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get working tree: %w", err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: branch, Create: true, Keep: true}); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// CurrentBranch returns the name of the checked-out branch, or an empty string
+// when HEAD is detached.
+func (r *GoGitRepository) CurrentBranch() (string, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return "", nil
+	}
+	return head.Name().Short(), nil
+}
+
+// AbandonBranch checks out previous, a branch or the commit of a detached HEAD,
+// and deletes the branch name that CreateBranch cut from it. Files committed on
+// name are restored to their content in previous; other uncommitted changes in
+// the working tree are kept.
+func (r *GoGitRepository) AbandonBranch(name, previous string) error {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get working tree: %w", err)
+	}
+	checkout := &git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(previous)}
+	if _, err := r.repo.Reference(checkout.Branch, false); err != nil {
+		checkout = &git.CheckoutOptions{Hash: plumbing.NewHash(previous)}
+	}
+	if err := wt.Checkout(checkout); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", previous, err)
+	}
+	if err := r.repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(name)); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// resolveTagCommit returns the hash of the commit a tag points at, peeling
+// annotated tag objects.
+func (r *GoGitRepository) resolveTagCommit(tag string) (plumbing.Hash, error) {
+	ref, err := r.repo.Tag(tag)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve tag %s: %w", tag, err)
+	}
+	return bump.PeelTagRef(r.repo, ref)
+}
+
 // GoGitWorktree is the real implementation of GitWorktree using go-git.
 type GoGitWorktree struct {
 	worktree *git.Worktree
@@ -100,15 +665,14 @@ func (w *GoGitWorktree) Add(path string) (plumbing.Hash, error) {
 
 // Commit creates a new commit with the staged changes.
 func (w *GoGitWorktree) Commit(msg string, opts *git.CommitOptions) (plumbing.Hash, error) {
-	// If no options provided, use default author
+	// If no options provided, use the placeholder author
 	if opts == nil {
-		opts = &git.CommitOptions{
-			Author: &object.Signature{
-				Name:  "Bump CLI",
-				Email: "bump@localhost",
-				When:  time.Now(),
-			},
-		}
+		opts = &git.CommitOptions{Author: commitSignature("", "", time.Now())}
 	}
 	return w.worktree.Commit(msg, opts)
 }
+
+// Status returns the staged and unstaged state of every changed file.
+func (w *GoGitWorktree) Status() (git.Status, error) {
+	return w.worktree.Status()
+}
@@ -5,16 +5,44 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/klauern/bump"
 )
 
 // MockGitRepository is a mock implementation of GitRepository for testing.
 type MockGitRepository struct {
-	TagsFunc      func() (storer.ReferenceIter, error)
-	CreateTagFunc func(string) error
-	PushTagsFunc  func() error
-	WorktreeFunc  func() (GitWorktree, error)
-	PathFunc      func() string
+	TagsFunc               func() (storer.ReferenceIter, error)
+	CreateTagFunc          func(string, bump.TagOptions) error
+	DeleteTagFunc          func(string) error
+	ReplaceTagFunc         func(string, bump.TagOptions) error
+	ForcePushTagFunc       func(string, string) error
+	PushTagFunc            func(string, string) error
+	PushTagsFunc           func(string) error
+	RemotesFunc            func() ([]string, error)
+	WorktreeFunc           func() (GitWorktree, error)
+	PathFunc               func() string
+	TagSchemeFunc          func() bump.TagScheme
+	CommitsSinceFunc       func(string) ([]CommitInfo, error)
+	UserIdentityFunc       func() (string, string, error)
+	RemoteTagsFunc         func(string) ([]string, error)
+	FetchTagsFunc          func(string) error
+	RemoteURLFunc          func(string) (string, error)
+	HeadHashFunc           func() (string, error)
+	TagCommitFunc          func(string) (string, error)
+	TagsAtFunc             func(string) ([]string, error)
+	TagSubjectFunc         func(string) (string, error)
+	ExistingTagOptionsFunc func(string) (bump.TagOptions, error)
+	DetachedHeadFunc       func() (bool, error)
+	HeadCommitFunc         func() (*object.Commit, error)
+	HeadPushedFunc         func() (bool, error)
+	SigningFormatFunc      func() (string, error)
+	RevertCommitFunc       func(string, string) error
+	ResetHeadFunc          func(string) error
+	CreateBranchFunc       func(string) error
+	ProbeLockFunc          func() error
+	CurrentBranchFunc      func() (string, error)
+	AbandonBranchFunc      func(string, string) error
 }
 
 // Tags calls the mock function if set, otherwise returns nil.
@@ -26,21 +54,61 @@ func (m *MockGitRepository) Tags() (storer.ReferenceIter, error) {
 }
 
 // CreateTag calls the mock function if set, otherwise returns nil.
-func (m *MockGitRepository) CreateTag(name string) error {
+func (m *MockGitRepository) CreateTag(name string, opts bump.TagOptions) error {
 	if m.CreateTagFunc != nil {
-		return m.CreateTagFunc(name)
+		return m.CreateTagFunc(name, opts)
+	}
+	return nil
+}
+
+// DeleteTag calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) DeleteTag(name string) error {
+	if m.DeleteTagFunc != nil {
+		return m.DeleteTagFunc(name)
+	}
+	return nil
+}
+
+// ReplaceTag calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) ReplaceTag(name string, opts bump.TagOptions) error {
+	if m.ReplaceTagFunc != nil {
+		return m.ReplaceTagFunc(name, opts)
+	}
+	return nil
+}
+
+// ForcePushTag calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) ForcePushTag(remote, name string) error {
+	if m.ForcePushTagFunc != nil {
+		return m.ForcePushTagFunc(remote, name)
+	}
+	return nil
+}
+
+// PushTag calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) PushTag(remote, name string) error {
+	if m.PushTagFunc != nil {
+		return m.PushTagFunc(remote, name)
 	}
 	return nil
 }
 
 // PushTags calls the mock function if set, otherwise returns nil.
-func (m *MockGitRepository) PushTags() error {
+func (m *MockGitRepository) PushTags(remote string) error {
 	if m.PushTagsFunc != nil {
-		return m.PushTagsFunc()
+		return m.PushTagsFunc(remote)
 	}
 	return nil
 }
 
+// Remotes calls the mock function if set, otherwise returns a single "origin" remote.
+func (m *MockGitRepository) Remotes() ([]string, error) {
+	if m.RemotesFunc != nil {
+		return m.RemotesFunc()
+	}
+	return []string{"origin"}, nil
+}
+
 // Worktree calls the mock function if set, otherwise returns a mock worktree.
 func (m *MockGitRepository) Worktree() (GitWorktree, error) {
 	if m.WorktreeFunc != nil {
@@ -57,10 +125,201 @@ func (m *MockGitRepository) Path() string {
 	return "/mock/repo"
 }
 
+// TagScheme calls the mock function if set, otherwise returns the default scheme.
+func (m *MockGitRepository) TagScheme() bump.TagScheme {
+	if m.TagSchemeFunc != nil {
+		return m.TagSchemeFunc()
+	}
+	return bump.TagScheme{}
+}
+
+// RemoteTags calls the mock function if set, otherwise returns no tags.
+func (m *MockGitRepository) RemoteTags(remote string) ([]string, error) {
+	if m.RemoteTagsFunc != nil {
+		return m.RemoteTagsFunc(remote)
+	}
+	return nil, nil
+}
+
+// FetchTags calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) FetchTags(remote string) error {
+	if m.FetchTagsFunc != nil {
+		return m.FetchTagsFunc(remote)
+	}
+	return nil
+}
+
+// UserIdentity calls the mock function if set, otherwise returns a fixed identity.
+func (m *MockGitRepository) UserIdentity() (string, string, error) {
+	if m.UserIdentityFunc != nil {
+		return m.UserIdentityFunc()
+	}
+	return "Mock User", "mock@example.com", nil
+}
+
+// CommitsSince calls the mock function if set, otherwise returns a single
+// placeholder commit so bumps are not rejected as empty.
+func (m *MockGitRepository) CommitsSince(tag string) ([]CommitInfo, error) {
+	if m.CommitsSinceFunc != nil {
+		return m.CommitsSinceFunc(tag)
+	}
+	return []CommitInfo{{Hash: plumbing.ZeroHash.String(), Subject: "Mock commit"}}, nil
+}
+
+// RemoteURL calls the mock function if set, otherwise returns a fixed GitHub URL.
+func (m *MockGitRepository) RemoteURL(name string) (string, error) {
+	if m.RemoteURLFunc != nil {
+		return m.RemoteURLFunc(name)
+	}
+	return "git@github.com:mock/repo.git", nil
+}
+
+// HeadHash calls the mock function if set, otherwise returns a fixed hash.
+func (m *MockGitRepository) HeadHash() (string, error) {
+	if m.HeadHashFunc != nil {
+		return m.HeadHashFunc()
+	}
+	return "0123456789abcdef0123456789abcdef01234567", nil
+}
+
+// HeadCommit calls the mock function if set, otherwise returns a placeholder commit.
+func (m *MockGitRepository) HeadCommit() (*object.Commit, error) {
+	if m.HeadCommitFunc != nil {
+		return m.HeadCommitFunc()
+	}
+	return &object.Commit{
+		Message: "Mock commit\n",
+		Author:  object.Signature{Name: "Mock Author", Email: "mock@example.com"},
+	}, nil
+}
+
+// HeadPushed calls the mock function if set, otherwise reports HEAD as unpushed.
+func (m *MockGitRepository) HeadPushed() (bool, error) {
+	if m.HeadPushedFunc != nil {
+		return m.HeadPushedFunc()
+	}
+	return false, nil
+}
+
+// SigningFormat calls the mock function if set, otherwise returns "openpgp".
+func (m *MockGitRepository) SigningFormat() (string, error) {
+	if m.SigningFormatFunc != nil {
+		return m.SigningFormatFunc()
+	}
+	return "openpgp", nil
+}
+
+// DetachedHead calls the mock function if set, otherwise reports HEAD on a branch.
+func (m *MockGitRepository) DetachedHead() (bool, error) {
+	if m.DetachedHeadFunc != nil {
+		return m.DetachedHeadFunc()
+	}
+	return false, nil
+}
+
+// TagSubject calls the mock function if set, otherwise returns a placeholder subject.
+func (m *MockGitRepository) TagSubject(tag string) (string, error) {
+	if m.TagSubjectFunc != nil {
+		return m.TagSubjectFunc(tag)
+	}
+	return "Mock commit", nil
+}
+
+// TagCommit calls the mock function if set, otherwise returns a fixed hash that
+// differs from the default HEAD, so tags are not considered to be at HEAD.
+func (m *MockGitRepository) TagCommit(tag string) (string, error) {
+	if m.TagCommitFunc != nil {
+		return m.TagCommitFunc(tag)
+	}
+	return "fedcba9876543210fedcba9876543210fedcba98", nil
+}
+
+// TagsAt calls the mock function if set, otherwise returns the tags from Tags
+// whose TagCommit matches the given hash.
+func (m *MockGitRepository) TagsAt(hash string) ([]string, error) {
+	if m.TagsAtFunc != nil {
+		return m.TagsAtFunc(hash)
+	}
+	refs, err := m.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		tagCommit, err := m.TagCommit(ref.Name().Short())
+		if err != nil {
+			return err
+		}
+		if tagCommit == hash {
+			names = append(names, ref.Name().Short())
+		}
+		return nil
+	})
+	return names, err
+}
+
+// ExistingTagOptions calls the mock function if set, otherwise describes an
+// unsigned annotated tag annotated with its own name.
+func (m *MockGitRepository) ExistingTagOptions(name string) (bump.TagOptions, error) {
+	if m.ExistingTagOptionsFunc != nil {
+		return m.ExistingTagOptionsFunc(name)
+	}
+	return bump.TagOptions{Message: name}, nil
+}
+
+// RevertCommit calls the mock function if set, otherwise does nothing.
+func (m *MockGitRepository) RevertCommit(hash, message string) error {
+	if m.RevertCommitFunc != nil {
+		return m.RevertCommitFunc(hash, message)
+	}
+	return nil
+}
+
+// ResetHead calls the mock function if set, otherwise does nothing.
+func (m *MockGitRepository) ResetHead(hash string) error {
+	if m.ResetHeadFunc != nil {
+		return m.ResetHeadFunc(hash)
+	}
+	return nil
+}
+
+// CreateBranch calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) CreateBranch(name string) error {
+	if m.CreateBranchFunc != nil {
+		return m.CreateBranchFunc(name)
+	}
+	return nil
+}
+
+// CurrentBranch calls the mock function if set, otherwise returns "main".
+func (m *MockGitRepository) CurrentBranch() (string, error) {
+	if m.CurrentBranchFunc != nil {
+		return m.CurrentBranchFunc()
+	}
+	return "main", nil
+}
+
+// AbandonBranch calls the mock function if set, otherwise returns nil.
+func (m *MockGitRepository) AbandonBranch(name, previous string) error {
+	if m.AbandonBranchFunc != nil {
+		return m.AbandonBranchFunc(name, previous)
+	}
+	return nil
+}
+
+// ProbeLock calls the mock function if set, otherwise reports the lock as free.
+func (m *MockGitRepository) ProbeLock() error {
+	if m.ProbeLockFunc != nil {
+		return m.ProbeLockFunc()
+	}
+	return nil
+}
+
 // MockGitWorktree is a mock implementation of GitWorktree for testing.
 type MockGitWorktree struct {
 	AddFunc    func(string) (plumbing.Hash, error)
 	CommitFunc func(string, *git.CommitOptions) (plumbing.Hash, error)
+	StatusFunc func() (git.Status, error)
 }
 
 // Add calls the mock function if set, otherwise returns a zero hash.
@@ -79,6 +338,14 @@ func (m *MockGitWorktree) Commit(msg string, opts *git.CommitOptions) (plumbing.
 	return plumbing.ZeroHash, nil
 }
 
+// Status calls the mock function if set, otherwise returns a clean status.
+func (m *MockGitWorktree) Status() (git.Status, error) {
+	if m.StatusFunc != nil {
+		return m.StatusFunc()
+	}
+	return git.Status{}, nil
+}
+
 // MockTagIterator is a mock implementation of storer.ReferenceIter for testing.
 type MockTagIterator struct {
 	tags  []string
@@ -150,10 +417,10 @@ func NewMockRepoWithError(tagsErr, createErr, pushErr error) *MockGitRepository
 			}
 			return NewMockTagIterator([]string{}), nil
 		},
-		CreateTagFunc: func(string) error {
+		CreateTagFunc: func(string, bump.TagOptions) error {
 			return createErr
 		},
-		PushTagsFunc: func() error {
+		PushTagsFunc: func(string) error {
 			return pushErr
 		},
 	}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+// newGitRepoWithCommits creates a real git repository in a temp directory and
+// returns a helper for running git commands in it.
+func newGitRepoWithCommits(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v; output: %s", args, err, string(output))
+		}
+		return string(output)
+	}
+
+	runGit("init")
+	runGit("config", "user.name", "Test User")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "tag.gpgSign", "false")
+	runGit("config", "commit.gpgSign", "false")
+
+	return repoDir, runGit
+}
+
+// commitFile writes a file and commits it with the given message.
+func commitFile(t *testing.T, repoDir string, runGit func(args ...string) string, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(repoDir, name), []byte(message), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	runGit("add", name)
+	runGit("commit", "-m", message)
+}
+
+// TestGoGitRepositoryUserIdentityCached tests that the git identity is read once and
+// used as the author of every commit bump creates
+func TestGoGitRepositoryUserIdentityCached(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Initial commit")
+	runGit("tag", "v1.0.0")
+	commitFile(t, repoDir, runGit, "a.txt", "Add feature")
+
+	reads := 0
+	original := loadRepoConfig
+	loadRepoConfig = func(repo *git.Repository) (*config.Config, error) {
+		reads++
+		return original(repo)
+	}
+	t.Cleanup(func() { loadRepoConfig = original })
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	opts := BumpOptions{BumpType: "patch", ReleaseCommit: true, UpdateFile: "version.go", Signoff: true}
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+
+	if reads != 1 {
+		t.Errorf("git config read %d times, expected once", reads)
+	}
+	authors := strings.Split(strings.TrimSpace(runGit("log", "-2", "--format=%an <%ae>")), "\n")
+	for _, author := range authors {
+		if author != "Test User <test@example.com>" {
+			t.Errorf("commit author = %q, expected the configured identity", author)
+		}
+	}
+	if len(authors) != 2 {
+		t.Errorf("expected the release and version file commits, got authors %v", authors)
+	}
+}
+
+// TestCommitSignature tests falling back to the placeholder only for unset identity parts
+func TestCommitSignature(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name, email string
+		expected    string
+	}{
+		{name: "Jane", email: "jane@example.com", expected: "Jane <jane@example.com>"},
+		{name: "Jane", expected: "Jane <bump@localhost>"},
+		{expected: "Bump CLI <bump@localhost>"},
+	}
+
+	for _, tt := range tests {
+		sig := commitSignature(tt.name, tt.email, when)
+		if got := sig.Name + " <" + sig.Email + ">"; got != tt.expected || !sig.When.Equal(when) {
+			t.Errorf("commitSignature(%q, %q) = %s at %v, expected %s", tt.name, tt.email, got, sig.When, tt.expected)
+		}
+	}
+}
+
+// TestGoGitRepositoryCommitsSince tests reading commits between a tag and HEAD
+func TestGoGitRepositoryCommitsSince(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+	runGit("tag", "-a", "-m", "v1.0.0", "v1.0.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+	commitFile(t, repoDir, runGit, "c.txt", "Third commit\n\nWith a body")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+
+	commits, err := repo.CommitsSince("v1.0.0")
+	if err != nil {
+		t.Fatalf("CommitsSince() error = %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits since v1.0.0, got %d: %+v", len(commits), commits)
+	}
+	if commits[0].Subject != "Third commit" || commits[1].Subject != "Second commit" {
+		t.Errorf("unexpected commit subjects: %q, %q", commits[0].Subject, commits[1].Subject)
+	}
+
+	all, err := repo.CommitsSince("")
+	if err != nil {
+		t.Fatalf("CommitsSince(\"\") error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected full history of 3 commits, got %d", len(all))
+	}
+
+	if _, err := repo.CommitsSince("v9.9.9"); err == nil {
+		t.Error("CommitsSince() should error for an unknown tag")
+	}
+}
+
+// TestGoGitRepositoryRemoteURL tests reading a remote's URL from the repository config
+func TestGoGitRepositoryRemoteURL(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	runGit("remote", "add", "origin", "git@github.com:acme/widgets.git")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+
+	url, err := repo.RemoteURL("origin")
+	if err != nil {
+		t.Fatalf("RemoteURL() error = %v", err)
+	}
+	if url != "git@github.com:acme/widgets.git" {
+		t.Errorf("RemoteURL() = %q, expected git@github.com:acme/widgets.git", url)
+	}
+
+	if _, err := repo.RemoteURL("upstream"); err == nil {
+		t.Error("RemoteURL() for missing remote expected error, got nil")
+	}
+}
+
+// TestGoGitRepositoryDetachedHead tests detecting a detached HEAD checkout
+func TestGoGitRepositoryDetachedHead(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	if detached, err := repo.DetachedHead(); err != nil || detached {
+		t.Errorf("DetachedHead() on a branch = %v, %v, expected false", detached, err)
+	}
+
+	runGit("checkout", "--detach")
+	if detached, err := repo.DetachedHead(); err != nil || !detached {
+		t.Errorf("DetachedHead() after detaching = %v, %v, expected true", detached, err)
+	}
+}
+
+// TestGoGitRepositoryTagsAt tests finding the lightweight and annotated tags at a commit
+func TestGoGitRepositoryTagsAt(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+	runGit("tag", "v1.0.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+	runGit("tag", "v1.1.0")
+	runGit("tag", "-a", "v1.1.1", "-m", "Release v1.1.1")
+	runGit("tag", "-a", "nested", "-m", "Tag of a tag", "v1.1.1")
+	head := strings.TrimSpace(runGit("rev-parse", "HEAD"))
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	names, err := repo.TagsAt(head)
+	if err != nil {
+		t.Fatalf("TagsAt() error = %v", err)
+	}
+	sort.Strings(names)
+	if got := strings.Join(names, " "); got != "nested v1.1.0 v1.1.1" {
+		t.Errorf("TagsAt(HEAD) = %q, expected %q", got, "nested v1.1.0 v1.1.1")
+	}
+}
+
+// TestGoGitRepositoryHeadPushed tests detecting whether HEAD is on a remote-tracking branch
+func TestGoGitRepositoryHeadPushed(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	if pushed, err := repo.HeadPushed(); err != nil || pushed {
+		t.Errorf("HeadPushed() without remotes = %v, %v, expected false", pushed, err)
+	}
+
+	runGit("update-ref", "refs/remotes/origin/main", "HEAD")
+	if pushed, err := repo.HeadPushed(); err != nil || !pushed {
+		t.Errorf("HeadPushed() at remote branch = %v, %v, expected true", pushed, err)
+	}
+
+	commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+	if pushed, err := repo.HeadPushed(); err != nil || pushed {
+		t.Errorf("HeadPushed() ahead of remote branch = %v, %v, expected false", pushed, err)
+	}
+}
+
+// TestGoGitRepositoryRemotes tests listing configured remotes
+func TestGoGitRepositoryRemotes(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	runGit("remote", "add", "upstream", "https://example.com/upstream.git")
+	runGit("remote", "add", "origin", "https://example.com/origin.git")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+
+	remotes, err := repo.Remotes()
+	if err != nil {
+		t.Fatalf("Remotes() error = %v", err)
+	}
+	if strings.Join(remotes, ",") != "origin,upstream" {
+		t.Errorf("Remotes() = %v, expected [origin upstream]", remotes)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// githubTokenEnv names the environment variable holding the GitHub API token.
+	githubTokenEnv = "BUMP_TOKEN"
+
+	// defaultGitHubAPIURL is the base URL of the public GitHub REST API.
+	defaultGitHubAPIURL = "https://api.github.com"
+)
+
+// GitHubRelease is the payload sent to the GitHub API to create a release.
+type GitHubRelease struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// GitHubClient creates releases through the GitHub REST API.
+// The HTTP client and base URL are injectable so tests can use a stub server.
+type GitHubClient struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+// NewGitHubClient creates a GitHubClient authenticating with the given token.
+// A nil httpClient uses a client with a default timeout.
+func NewGitHubClient(token string, httpClient *http.Client) *GitHubClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &GitHubClient{
+		httpClient: httpClient,
+		baseURL:    defaultGitHubAPIURL,
+		token:      token,
+	}
+}
+
+// CreateRelease creates a release in the owner/repo repository and returns its URL.
+func (c *GitHubClient) CreateRelease(owner, repo string, release GitHubRelease) (string, error) {
+	if c.token == "" {
+		return "", fmt.Errorf("%s is not set", githubTokenEnv)
+	}
+
+	payload, err := json.Marshal(release)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode release: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", strings.TrimSuffix(c.baseURL, "/"), owner, repo)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("GitHub API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var created struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return created.HTMLURL, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newGitHubStub starts a stub GitHub API server that records the release payload
+// and responds with the given status and body.
+func newGitHubStub(t *testing.T, status int, body string, got *GitHubRelease, gotPath, gotAuth *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotPath = r.Method + " " + r.URL.Path
+		*gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestGitHubClientCreateRelease tests the release request sent to the GitHub API
+func TestGitHubClientCreateRelease(t *testing.T) {
+	var got GitHubRelease
+	var gotPath, gotAuth string
+	server := newGitHubStub(t, http.StatusCreated, `{"html_url":"https://github.com/acme/widgets/releases/tag/v1.2.0"}`, &got, &gotPath, &gotAuth)
+
+	client := NewGitHubClient("secret", server.Client())
+	client.baseURL = server.URL
+
+	release := GitHubRelease{TagName: "v1.2.0", Name: "v1.2.0", Body: "Changes in v1.2.0:\n- Add widgets\n", Prerelease: false}
+	url, err := client.CreateRelease("acme", "widgets", release)
+	if err != nil {
+		t.Fatalf("CreateRelease() unexpected error = %v", err)
+	}
+
+	if url != "https://github.com/acme/widgets/releases/tag/v1.2.0" {
+		t.Errorf("CreateRelease() url = %q", url)
+	}
+	if gotPath != "POST /repos/acme/widgets/releases" {
+		t.Errorf("request = %q, expected POST /repos/acme/widgets/releases", gotPath)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization = %q, expected bearer token", gotAuth)
+	}
+	if got != release {
+		t.Errorf("payload = %+v, expected %+v", got, release)
+	}
+}
+
+// TestGitHubClientCreateReleaseErrors tests API failures and a missing token
+func TestGitHubClientCreateReleaseErrors(t *testing.T) {
+	var got GitHubRelease
+	var gotPath, gotAuth string
+	server := newGitHubStub(t, http.StatusUnprocessableEntity, `{"message":"Validation Failed"}`, &got, &gotPath, &gotAuth)
+
+	client := NewGitHubClient("secret", server.Client())
+	client.baseURL = server.URL
+	_, err := client.CreateRelease("acme", "widgets", GitHubRelease{TagName: "v1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "Validation Failed") {
+		t.Errorf("CreateRelease() error = %v, expected API message", err)
+	}
+
+	_, err = NewGitHubClient("", nil).CreateRelease("acme", "widgets", GitHubRelease{TagName: "v1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), githubTokenEnv) {
+		t.Errorf("CreateRelease() without token error = %v, expected mention of %s", err, githubTokenEnv)
+	}
+}
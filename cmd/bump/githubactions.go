@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// githubOutputEnv names the file GitHub Actions reads step outputs from.
+const githubOutputEnv = "GITHUB_OUTPUT"
+
+// Output modes selectable with --output.
+const (
+	outputText          = "text"
+	outputJSON          = "json"
+	outputGitHubActions = "github-actions"
+)
+
+// resolveOutputMode returns the output mode for --output, with --json as a
+// shorthand for --output=json.
+func resolveOutputMode(mode string, jsonFlag bool) (string, error) {
+	if jsonFlag {
+		if mode != "" && mode != outputJSON {
+			return "", fmt.Errorf("--json cannot be used with --output=%s", mode)
+		}
+		return outputJSON, nil
+	}
+	switch mode {
+	case "", outputText:
+		return outputText, nil
+	case outputJSON, outputGitHubActions:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid --output %q: expected %s, %s, or %s", mode, outputText, outputJSON, outputGitHubActions)
+	}
+}
+
+// formatGitHubOutput renders the bump result as GitHub Actions step outputs, one
+// name=value line each.
+func formatGitHubOutput(result *BumpResult, bumpType string) string {
+	return fmt.Sprintf("tag=%s\nprevious_tag=%s\npushed=%t\nbump_type=%s\n",
+		result.NextTag, result.PreviousTag, result.Pushed, bumpType)
+}
+
+// writeGitHubOutput appends the step outputs to the file named by $GITHUB_OUTPUT,
+// or writes them to w when the variable is unset (outside of GitHub Actions).
+func writeGitHubOutput(w io.Writer, result *BumpResult, bumpType string) error {
+	outputs := formatGitHubOutput(result, bumpType)
+	path := os.Getenv(githubOutputEnv)
+	if path == "" {
+		if _, err := io.WriteString(w, outputs); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", githubOutputEnv, err)
+	}
+	if _, err := io.WriteString(file, outputs); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", githubOutputEnv, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", githubOutputEnv, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWriteGitHubOutput tests appending step outputs to $GITHUB_OUTPUT and the stdout fallback
+func TestWriteGitHubOutput(t *testing.T) {
+	result := &BumpResult{NextTag: "v1.2.3", PreviousTag: "v1.2.2", Pushed: true}
+	expected := "tag=v1.2.3\nprevious_tag=v1.2.2\npushed=true\nbump_type=patch\n"
+
+	t.Run("GITHUB_OUTPUT set", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "github_output")
+		if err := os.WriteFile(outputFile, []byte("earlier=step\n"), 0o644); err != nil {
+			t.Fatalf("failed to write output file: %v", err)
+		}
+		t.Setenv(githubOutputEnv, outputFile)
+
+		stdout := &bytes.Buffer{}
+		if err := writeGitHubOutput(stdout, result, "patch"); err != nil {
+			t.Fatalf("writeGitHubOutput() unexpected error = %v", err)
+		}
+		got, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("failed to read output file: %v", err)
+		}
+		if string(got) != "earlier=step\n"+expected {
+			t.Errorf("%s content = %q, expected earlier outputs followed by %q", githubOutputEnv, got, expected)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("stdout = %q, expected nothing", stdout.String())
+		}
+	})
+
+	t.Run("GITHUB_OUTPUT unset", func(t *testing.T) {
+		t.Setenv(githubOutputEnv, "")
+
+		stdout := &bytes.Buffer{}
+		if err := writeGitHubOutput(stdout, result, "patch"); err != nil {
+			t.Fatalf("writeGitHubOutput() unexpected error = %v", err)
+		}
+		if stdout.String() != expected {
+			t.Errorf("stdout = %q, expected %q", stdout.String(), expected)
+		}
+	})
+}
+
+// TestResolveOutputMode tests selecting the output mode from --output and --json
+func TestResolveOutputMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		jsonFlag bool
+		expected string
+		errMsg   string
+	}{
+		{name: "Default", expected: outputText},
+		{name: "JSON flag", jsonFlag: true, expected: outputJSON},
+		{name: "JSON flag with matching output", mode: "json", jsonFlag: true, expected: outputJSON},
+		{name: "GitHub Actions", mode: "github-actions", expected: outputGitHubActions},
+		{name: "JSON flag conflicts", mode: "github-actions", jsonFlag: true, errMsg: "--json cannot be used"},
+		{name: "Unknown mode", mode: "yaml", errMsg: "invalid --output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveOutputMode(tt.mode, tt.jsonFlag)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("resolveOutputMode() error = %v, expected %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("resolveOutputMode() = %q, %v; expected %q", got, err, tt.expected)
+			}
+		})
+	}
+}
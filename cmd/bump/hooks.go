@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauern/bump"
+)
+
+// prePushHook is the git pre-push hook written by "bump hooks install". It hands
+// the refs being pushed to "bump hooks pre-push", so tags are checked with the
+// same version parser bump uses to create them.
+const prePushHook = `#!/bin/sh
+# Installed by "bump hooks install": reject pushed tags that are not semantic versions.
+exec bump hooks pre-push "$@"
+`
+
+// tagRefPrefix is the ref namespace git stores tags under.
+const tagRefPrefix = "refs/tags/"
+
+// invalidPushedTags returns the tags in git's pre-push input that are not semantic
+// versions. Each input line is "<local ref> <local sha> <remote ref> <remote sha>";
+// branches and tag deletions are ignored.
+func invalidPushedTags(scheme bump.TagScheme, input string) []string {
+	var invalid []string
+	for _, line := range strings.Split(input, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[0] == "(delete)" {
+			continue
+		}
+		tag, ok := strings.CutPrefix(fields[2], tagRefPrefix)
+		if !ok {
+			continue
+		}
+		if _, ok := scheme.ParseTagVersion(tag); !ok {
+			invalid = append(invalid, tag)
+		}
+	}
+	return invalid
+}
+
+// checkPrePush reads git's pre-push input from r and fails if any pushed tag is
+// not a semantic version under scheme.
+func checkPrePush(scheme bump.TagScheme, r io.Reader) error {
+	var input strings.Builder
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		input.WriteString(scanner.Text())
+		input.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read pushed refs: %w", err)
+	}
+	if invalid := invalidPushedTags(scheme, input.String()); len(invalid) > 0 {
+		return fmt.Errorf("refusing to push tags that are not semantic versions: %s (use git push --no-verify to push anyway)", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// installPrePushHook writes the pre-push hook into the hooks directory git uses for
+// the repository: core.hooksPath when set, otherwise the hooks directory of the
+// shared git directory, so worktrees and submodules are covered. An existing hook
+// is only replaced with force, after being renamed to pre-push.bak; reinstalling
+// the same hook leaves it untouched.
+func installPrePushHook(w io.Writer, repoPath string, force bool) error {
+	hooksDir, err := bump.GitPath(repoPath, "hooks")
+	if err != nil {
+		return fmt.Errorf("failed to find the hooks directory: %w", err)
+	}
+	hookPath := filepath.Join(hooksDir, "pre-push")
+
+	existing, err := os.ReadFile(hookPath)
+	switch {
+	case err == nil && bytes.Equal(existing, []byte(prePushHook)):
+		if _, err := fmt.Fprintf(w, "pre-push hook already installed at %s\n", hookPath); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	case err == nil && !force:
+		return fmt.Errorf("%s already exists; pass --force to replace it (the old hook is kept as pre-push.bak)", hookPath)
+	case err == nil:
+		backupPath := hookPath + ".bak"
+		if err := os.Rename(hookPath, backupPath); err != nil {
+			return fmt.Errorf("failed to back up existing hook: %w", err)
+		}
+		if _, err := fmt.Fprintf(w, "Backed up existing hook to %s\n", backupPath); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read existing hook: %w", err)
+	}
+
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(hookPath, []byte(prePushHook), 0o755); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+	// WriteFile's mode is filtered by the umask, and git skips hooks that are not executable
+	if err := os.Chmod(hookPath, 0o755); err != nil {
+		return fmt.Errorf("failed to make hook executable: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "Installed pre-push hook at %s\n", hookPath); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
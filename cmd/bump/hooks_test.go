@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauern/bump"
+)
+
+// TestInstallPrePushHook tests writing the hook and replacing an existing one
+func TestInstallPrePushHook(t *testing.T) {
+	repoDir, _ := newGitRepoWithCommits(t)
+	hookPath := filepath.Join(repoDir, ".git", "hooks", "pre-push")
+	if err := os.WriteFile(hookPath, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("failed to write existing hook: %v", err)
+	}
+
+	if err := installPrePushHook(&bytes.Buffer{}, repoDir, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("installPrePushHook() over an existing hook error = %v, expected to require --force", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := installPrePushHook(out, repoDir, true); err != nil {
+		t.Fatalf("installPrePushHook() unexpected error = %v", err)
+	}
+	content, err := os.ReadFile(hookPath)
+	if err != nil {
+		t.Fatalf("failed to read hook: %v", err)
+	}
+	if string(content) != prePushHook {
+		t.Errorf("hook content = %q, expected %q", content, prePushHook)
+	}
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		t.Fatalf("failed to stat hook: %v", err)
+	}
+	if info.Mode().Perm()&0o111 != 0o111 {
+		t.Errorf("hook mode = %v, expected it to be executable", info.Mode().Perm())
+	}
+	backup, err := os.ReadFile(hookPath + ".bak")
+	if err != nil || string(backup) != "#!/bin/sh\nexit 0\n" {
+		t.Errorf("backup = %q (err %v), expected the previous hook", backup, err)
+	}
+	if !strings.Contains(out.String(), "Backed up existing hook") {
+		t.Errorf("output = %q, expected a backup notice", out.String())
+	}
+
+	// Reinstalling the same hook needs no --force and leaves the backup alone
+	if err := installPrePushHook(&bytes.Buffer{}, repoDir, false); err != nil {
+		t.Errorf("installPrePushHook() reinstall unexpected error = %v", err)
+	}
+}
+
+// TestInstallPrePushHookLocation tests that the hook goes where git looks for it in
+// a linked worktree and with core.hooksPath set
+func TestInstallPrePushHookLocation(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "Initial commit")
+
+	worktreeDir := filepath.Join(t.TempDir(), "wt")
+	runGit("worktree", "add", "-q", worktreeDir)
+	if err := installPrePushHook(&bytes.Buffer{}, worktreeDir, false); err != nil {
+		t.Fatalf("installPrePushHook() in a worktree unexpected error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, ".git", "hooks", "pre-push")); err != nil {
+		t.Errorf("hook not installed in the shared hooks directory: %v", err)
+	}
+
+	runGit("config", "core.hooksPath", ".githooks")
+	if err := installPrePushHook(&bytes.Buffer{}, repoDir, false); err != nil {
+		t.Fatalf("installPrePushHook() with core.hooksPath unexpected error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, ".githooks", "pre-push")); err != nil {
+		t.Errorf("hook not installed in core.hooksPath: %v", err)
+	}
+}
+
+// TestInvalidPushedTags tests the pure function for checking git's pre-push input
+func TestInvalidPushedTags(t *testing.T) {
+	const sha = "1111111111111111111111111111111111111111"
+	const zero = "0000000000000000000000000000000000000000"
+	input := strings.Join([]string{
+		"refs/heads/main " + sha + " refs/heads/main " + zero,
+		"refs/tags/v1.2.0 " + sha + " refs/tags/v1.2.0 " + zero,
+		"refs/tags/v1.3.0-rc.1 " + sha + " refs/tags/v1.3.0-rc.1 " + zero,
+		"refs/tags/release-1 " + sha + " refs/tags/release-1 " + zero,
+		"refs/tags/wip " + sha + " refs/tags/wip " + zero,
+		"(delete) " + zero + " refs/tags/old-tag " + sha,
+	}, "\n") + "\n"
+
+	got := invalidPushedTags(bump.TagScheme{}, input)
+	expected := []string{"release-1", "wip"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("invalidPushedTags() = %v, expected %v", got, expected)
+	}
+	if err := checkPrePush(bump.TagScheme{}, strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), "release-1, wip") {
+		t.Errorf("checkPrePush() error = %v, expected to name the invalid tags", err)
+	}
+	if err := checkPrePush(bump.TagScheme{}, strings.NewReader("refs/tags/v1.2.0 "+sha+" refs/tags/v1.2.0 "+zero+"\n")); err != nil {
+		t.Errorf("checkPrePush() unexpected error = %v", err)
+	}
+}
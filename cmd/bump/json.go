@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauern/bump"
+)
+
+// jsonSchemaVersion is the version of the --json output contract. Bump it whenever a
+// field is renamed, removed, or changes meaning; adding a field does not require it.
+const jsonSchemaVersion = 1
+
+// JSONResult is the document printed by --json: the bump result, flattened, plus the
+// version of the output contract so consumers can detect breaking changes.
+type JSONResult struct {
+	SchemaVersion int `json:"schemaVersion"` // Version of the JSON output contract
+	*BumpResult
+}
+
+// writeJSONResult writes the bump result to w as an indented JSONResult document.
+func writeJSONResult(w io.Writer, result *BumpResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(JSONResult{SchemaVersion: jsonSchemaVersion, BumpResult: result}); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// LatestVersion is the document printed by bump latest --json: the latest tag split
+// into its SemVer components, plus how many tags were considered.
+type LatestVersion struct {
+	SchemaVersion int    `json:"schemaVersion"` // Version of the JSON output contract
+	Tag           string `json:"tag"`           // The latest tag as written in git
+	Major         int    `json:"major"`         // Major version number
+	Minor         int    `json:"minor"`         // Minor version number
+	Patch         int    `json:"patch"`         // Patch version number
+	Suffix        string `json:"suffix"`        // Pre-release identifiers without the separator ("rc.1"); empty for a release
+	Build         string `json:"build"`         // Build metadata without the "+" ("build.42"); empty when absent
+	TagCount      int    `json:"tagCount"`      // Distinct tags considered, including those that are not versions
+	ScannedCount  int    `json:"scannedCount"`  // Tag references examined, including peeled duplicates
+	SkippedCount  int    `json:"skippedCount"`  // Distinct tags ignored as not semantic versions or not matching the tag pattern
+}
+
+// newLatestVersion describes latestTag, which must be a semantic version under
+// scheme, as a LatestVersion document.
+func newLatestVersion(scheme bump.TagScheme, latestTag string, scan bump.TagScan) (LatestVersion, error) {
+	version, ok := scheme.ParseTagVersion(latestTag)
+	if !ok {
+		return LatestVersion{}, fmt.Errorf("failed to parse tag: %s", latestTag)
+	}
+	latest := LatestVersion{
+		SchemaVersion: jsonSchemaVersion,
+		Tag:           latestTag,
+		Major:         version.Major,
+		Minor:         version.Minor,
+		Patch:         version.Patch,
+		Build:         strings.TrimPrefix(version.Build, "+"),
+		TagCount:      scan.Versions + len(scan.Skipped),
+		ScannedCount:  scan.Scanned,
+		SkippedCount:  len(scan.Skipped),
+	}
+	if version.Suffix != "" {
+		latest.Suffix = version.Suffix[1:]
+	}
+	return latest, nil
+}
+
+// writeJSONLatest writes the latest version to w as an indented JSON document.
+func writeJSONLatest(w io.Writer, latest LatestVersion) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(latest); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// bumpResultSchema is the JSON Schema for the --json output, printed by --json-schema.
+// Durations are reported in nanoseconds.
+const bumpResultSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/klauern/bump/schema/bump-result-v1.json",
+  "title": "BumpResult",
+  "type": "object",
+  "required": ["schemaVersion", "nextTag", "pushed", "fileUpdated", "wouldPush", "wouldUpdate", "previousTag", "noOp", "timings"],
+  "properties": {
+    "schemaVersion": {"type": "integer", "const": 1},
+    "nextTag": {"type": "string"},
+    "pushed": {"type": "boolean"},
+    "fileUpdated": {"type": "boolean"},
+    "wouldPush": {"type": "boolean"},
+    "wouldUpdate": {"type": "boolean"},
+    "previousTag": {"type": "string"},
+    "noOp": {"type": "boolean"},
+    "timings": {"$ref": "#/$defs/timings"},
+    "submodules": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["path", "result"],
+        "properties": {
+          "path": {"type": "string"},
+          "result": {"$ref": "#/$defs/result"}
+        }
+      }
+    },
+    "releaseURL": {"type": "string"},
+    "tagScan": {"$ref": "#/$defs/tagScan"}
+  },
+  "$defs": {
+    "result": {
+      "type": "object",
+      "properties": {
+        "nextTag": {"type": "string"},
+        "pushed": {"type": "boolean"},
+        "fileUpdated": {"type": "boolean"},
+        "wouldPush": {"type": "boolean"},
+        "wouldUpdate": {"type": "boolean"},
+        "previousTag": {"type": "string"},
+        "noOp": {"type": "boolean"},
+        "timings": {"$ref": "#/$defs/timings"},
+        "submodules": {"type": "array"},
+        "releaseURL": {"type": "string"},
+        "tagScan": {"$ref": "#/$defs/tagScan"}
+      }
+    },
+    "tagScan": {
+      "type": "object",
+      "properties": {
+        "scanned": {"type": "integer"},
+        "versions": {"type": "integer"},
+        "skipped": {"type": "integer"}
+      }
+    },
+    "timings": {
+      "type": "object",
+      "properties": {
+        "tagEnumeration": {"type": "integer"},
+        "latestTag": {"type": "integer"},
+        "tagCreation": {"type": "integer"},
+        "push": {"type": "integer"},
+        "fileUpdate": {"type": "integer"}
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sortedKeys returns the keys of a decoded JSON object in sorted order
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TestJSONResultFields locks the field set of the --json output; changing it must be deliberate
+func TestJSONResultFields(t *testing.T) {
+	result := &BumpResult{
+		NextTag:     "v1.1.0",
+		PreviousTag: "v1.0.0",
+		Pushed:      true,
+		ReleaseURL:  "https://github.com/acme/widgets/releases/tag/v1.1.0",
+		Submodules:  []SubmoduleResult{{Path: "lib", Result: &BumpResult{NextTag: "v0.2.0"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONResult(&buf, result); err != nil {
+		t.Fatalf("writeJSONResult() unexpected error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	golden := map[string][]string{
+		"result":    {"fileUpdated", "nextTag", "noOp", "previousTag", "pushed", "releaseURL", "schemaVersion", "submodules", "tagScan", "timings", "wouldPush", "wouldUpdate"},
+		"submodule": {"path", "result"},
+		"tagScan":   {"scanned", "skipped", "versions"},
+		"timings":   {"fileUpdate", "latestTag", "push", "tagCreation", "tagEnumeration"},
+	}
+	submodule := doc["submodules"].([]any)[0].(map[string]any)
+	got := map[string][]string{
+		"result":    sortedKeys(doc),
+		"submodule": sortedKeys(submodule),
+		"tagScan":   sortedKeys(doc["tagScan"].(map[string]any)),
+		"timings":   sortedKeys(doc["timings"].(map[string]any)),
+	}
+	if !reflect.DeepEqual(got, golden) {
+		t.Errorf("JSON fields = %v, expected %v (update jsonSchemaVersion and bumpResultSchema when changing them)", got, golden)
+	}
+	if doc["schemaVersion"] != float64(jsonSchemaVersion) {
+		t.Errorf("schemaVersion = %v, expected %d", doc["schemaVersion"], jsonSchemaVersion)
+	}
+}
+
+// TestBumpResultSchema tests that the published schema describes every output field
+func TestBumpResultSchema(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Const any `json:"const"`
+		} `json:"properties"`
+		Defs struct {
+			Timings struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"timings"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal([]byte(bumpResultSchema), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONResult(&buf, &BumpResult{ReleaseURL: "x", Submodules: []SubmoduleResult{{}}}); err != nil {
+		t.Fatalf("writeJSONResult() unexpected error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	for key := range doc {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Errorf("schema is missing property %q", key)
+		}
+	}
+	for key := range doc["timings"].(map[string]any) {
+		if _, ok := schema.Defs.Timings.Properties[key]; !ok {
+			t.Errorf("schema is missing timings property %q", key)
+		}
+	}
+	if schema.Properties["schemaVersion"].Const != float64(jsonSchemaVersion) {
+		t.Errorf("schema schemaVersion const = %v, expected %d", schema.Properties["schemaVersion"].Const, jsonSchemaVersion)
+	}
+}
+
+// TestLatestJSON compares bump latest --json for a known latest tag against its golden document
+func TestLatestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	repo := NewMockRepoWithTags([]string{"v1.2.2", "v1.2.3-rc.1+build.7", "nightly"})
+	latest, err := NewBumpService(repo, nil, &buf).Latest("", false, true)
+	if err != nil {
+		t.Fatalf("Latest() unexpected error = %v", err)
+	}
+	if latest != "v1.2.3-rc.1+build.7" {
+		t.Errorf("Latest() = %q, expected v1.2.3-rc.1+build.7", latest)
+	}
+
+	golden := `{
+  "schemaVersion": 1,
+  "tag": "v1.2.3-rc.1+build.7",
+  "major": 1,
+  "minor": 2,
+  "patch": 3,
+  "suffix": "rc.1",
+  "build": "build.7",
+  "tagCount": 3,
+  "scannedCount": 3,
+  "skippedCount": 1
+}
+`
+	if buf.String() != golden {
+		t.Errorf("Latest() JSON =\n%s\nexpected\n%s", buf.String(), golden)
+	}
+}
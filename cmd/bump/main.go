@@ -1,13 +1,19 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/klauern/bump"
+	"github.com/muesli/termenv"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,109 +21,1193 @@ func init() {
 	if os.Getenv("DEBUG") != "" {
 		log.SetLevel(log.DebugLevel)
 	}
+	configureLogColor(log.Default(), os.Stderr, false)
+	if section := os.Getenv("BUMP_CONFIG_SECTION"); section != "" {
+		bump.SetConfigSection(section)
+	}
 }
 
 func main() {
+	cli.VersionPrinter = func(c *cli.Context) {
+		if err := printVersion(c.App.Writer); err != nil {
+			log.Error(err)
+		}
+	}
 	app := &cli.App{
-		Name:  "bump",
-		Usage: "Bump the version of your project",
+		Name:    "bump",
+		Usage:   "Bump the version of your project",
+		Version: Version,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json-schema",
+				Usage: "Print the JSON Schema of the --json output and exit",
+			},
+			&cli.StringFlag{
+				Name:  "config-file",
+				Usage: "Read bump settings from this file (git config syntax) before .git/config",
+			},
+			&cli.BoolFlag{
+				Name:  "no-color",
+				Usage: "Disable ANSI colors in log messages (also set by NO_COLOR)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y", "non-interactive"},
+				Usage:   "Answer yes to every confirmation; without it, prompts are declined when stdin is not a terminal",
+				EnvVars: []string{"BUMP_ASSUME_YES"},
+			},
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "Log debug details, such as how many tags were scanned and skipped (also set by DEBUG)",
+			},
+			&cli.StringFlag{
+				Name:  "tag-pattern",
+				Usage: "Only count tags matching this regular expression as releases (default: tagPattern setting)",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			bump.SetConfigFile(c.String("config-file"))
+			configureLogColor(log.Default(), os.Stderr, c.Bool("no-color"))
+			if c.Bool("verbose") {
+				log.SetLevel(log.DebugLevel)
+			}
+			return nil
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("json-schema") {
+				_, err := fmt.Fprint(os.Stdout, bumpResultSchema)
+				return err
+			}
+			return cli.ShowAppHelp(c)
+		},
 		Commands: []*cli.Command{
 			createCommand("patch", "p", "Bump the patch version"),
 			createCommand("minor", "m", "Bump the minor version"),
 			createCommand("major", "M", "Bump the major version"),
+			createCommand("prerelease", "pre", "Advance the pre-release counter without changing the core version"),
 			{
 				Name:  "push",
 				Usage: "Push tags to remote",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "remote",
+						Usage: "Remote to push to (default: the only remote, or origin)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repoPath, err := findGitRoot(".")
+					if err != nil {
+						return fmt.Errorf("failed to find git root: %v", err)
+					}
+					repo, err := NewGoGitRepository(repoPath)
+					if err != nil {
+						return err
+					}
+					return NewBumpService(repo, nil, os.Stdout).Push(c.String("remote"))
+				},
+			},
+			{
+				Name:  "latest",
+				Usage: "Print the latest version tag",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "remote",
+						Usage: "Query the tags published on this remote instead of local tags",
+					},
+					&cli.BoolFlag{
+						Name:  "strip-prefix",
+						Usage: "Print the version without its tag prefix (v1.2.3 -> 1.2.3)",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the tag and its parsed major, minor, patch, suffix, and build as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					_, err = NewBumpService(repo, nil, os.Stdout).Latest(c.String("remote"), c.Bool("strip-prefix"), c.Bool("json"))
+					return err
+				},
+			},
+			{
+				Name:  "status",
+				Usage: "Summarize the latest tag, next versions, working tree, and bump settings",
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					return printStatus(os.Stdout, repo)
+				},
+			},
+			{
+				Name:  "preview",
+				Usage: "Print the next version for each bump type without creating a tag",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "suffix",
+						Usage: "Also show the next pre-release in this series (e.g. rc)",
+					},
+					&cli.BoolFlag{
+						Name:  "strip-prefix",
+						Usage: "Print the versions without their tag prefix (v1.2.3 -> 1.2.3)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					_, err = NewBumpService(repo, nil, os.Stdout).Preview(c.String("suffix"), c.Bool("strip-prefix"))
+					return err
+				},
+			},
+			{
+				Name:  "tags",
+				Usage: "List version tags, newest first",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Output format: name, or subject to add each tagged commit's subject",
+						Value: "name",
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "Show only the newest N tags (0 for all)",
+					},
+					&cli.BoolFlag{
+						Name:  "grouped",
+						Usage: "Show only the newest tag of each major.minor release line",
+					},
+					&cli.BoolFlag{
+						Name:  "head",
+						Usage: "Show only the tags on the current commit; exits non-zero if there are none",
+					},
+					&cli.BoolFlag{
+						Name:  "strip-prefix",
+						Usage: "Print the tags without their tag prefix (v1.2.3 -> 1.2.3)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.Bool("grouped") && c.IsSet("format") {
+						return fmt.Errorf("--grouped cannot be used with --format")
+					}
+					if c.Bool("head") && (c.Bool("grouped") || c.IsSet("limit")) {
+						return fmt.Errorf("--head cannot be used with --grouped or --limit")
+					}
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					svc := NewBumpService(repo, nil, os.Stdout)
+					if c.Bool("head") {
+						_, err = svc.ListHeadTags(c.String("format"), c.Bool("strip-prefix"))
+						return err
+					}
+					if c.Bool("grouped") {
+						_, err = svc.ListReleaseLines(c.Int("limit"), c.Bool("strip-prefix"))
+						return err
+					}
+					_, err = svc.ListTags(c.String("format"), c.Int("limit"), c.Bool("strip-prefix"))
+					return err
+				},
+			},
+			{
+				Name:  "check",
+				Usage: "Verify a version file matches the latest tag",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "update-file",
+						Usage:    "Go file containing the Version constant, or a package.json or TOML manifest, to check",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "version-field",
+						Usage: "Check a field in a composite literal instead of the Version constant (e.g. info.Version)",
+					},
+					&cli.StringFlag{
+						Name:  "toml-path",
+						Usage: "Dotted key of the version in a TOML --update-file (default: package.version for Cargo.toml, project.version or tool.poetry.version for pyproject.toml)",
+					},
+					&cli.StringFlag{
+						Name:  "expect-package",
+						Usage: "Fail unless the version file declares this Go package",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					updater, err := versionFileUpdater(c.String("version-field"), c.String("expect-package"), c.String("toml-path"))
+					if err != nil {
+						return err
+					}
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					return NewBumpService(repo, updater, os.Stdout).Check(c.String("update-file"))
+				},
+			},
+			{
+				Name:  "sync-file",
+				Usage: "Update a version file that has drifted from the latest tag to the matching development version",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "update-file",
+						Usage:    "Go file containing the Version constant, or a package.json or TOML manifest, to sync",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "version-field",
+						Usage: "Sync a field in a composite literal instead of the Version constant (e.g. info.Version)",
+					},
+					&cli.StringFlag{
+						Name:  "toml-path",
+						Usage: "Dotted key of the version in a TOML --update-file (default: package.version for Cargo.toml, project.version or tool.poetry.version for pyproject.toml)",
+					},
+					&cli.StringFlag{
+						Name:  "expect-package",
+						Usage: "Fail unless the version file declares this Go package",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the change without writing or committing it",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					updater, err := versionFileUpdater(c.String("version-field"), c.String("expect-package"), c.String("toml-path"))
+					if err != nil {
+						return err
+					}
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					_, err = NewBumpService(repo, updater, os.Stdout).SyncFile(c.String("update-file"), c.Bool("dry-run"))
+					return err
+				},
+			},
+			{
+				Name:  "rollback-dev",
+				Usage: "Undo the development version commit made by --update-file, e.g. after aborting a release",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "reset",
+						Usage: "Reset the branch to the commit's parent instead of reverting it (the commit must be HEAD)",
+					},
+					&cli.StringFlag{
+						Name:  "pattern",
+						Usage: "Regular expression matching the commit subject (default: devCommitPattern setting or " + defaultDevCommitPattern + ")",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show which commit would be undone without changing anything",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					pattern := defaultDevCommitPattern
+					if val, isSet, err := bump.GetConfigString(repo.Path(), "devCommitPattern"); err != nil {
+						return fmt.Errorf("failed to read the devCommitPattern setting: %w", err)
+					} else if isSet {
+						pattern = val
+					}
+					if c.IsSet("pattern") {
+						pattern = c.String("pattern")
+					}
+					re, err := regexp.Compile(pattern)
+					if err != nil {
+						return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+					}
+					return NewBumpService(repo, nil, os.Stdout).RollbackDev(re, c.Bool("reset"), c.Bool("dry-run"))
+				},
+			},
+			{
+				Name:  "dev",
+				Usage: "Tag HEAD with a development snapshot version (v1.2.4-dev.N, N commits past the latest release)",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "push",
+						Usage: "Push the new dev tag",
+					},
+					&cli.StringFlag{
+						Name:  "remote",
+						Usage: "Remote to push to (default: the only remote, or origin)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Print the dev tag without creating it",
+					},
+				},
 				Action: func(c *cli.Context) error {
-					if err := bump.PushTag(); err != nil {
-						return fmt.Errorf("failed to push tags: %v", err)
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
 					}
-					fmt.Println("Successfully pushed tags to remote.")
-					return nil
+					_, err = NewBumpService(repo, nil, os.Stdout).Dev(c.String("remote"), c.Bool("push"), c.Bool("dry-run"))
+					return err
+				},
+			},
+			{
+				Name:  "version",
+				Usage: "Print the version of bump and the Go toolchain and commit it was built from",
+				Action: func(c *cli.Context) error {
+					return printVersion(os.Stdout)
+				},
+			},
+			{
+				Name:  "lint-tags",
+				Usage: "Report version-like tags that violate strict SemVer, without changing anything",
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					_, err = NewBumpService(repo, nil, os.Stdout).LintTags()
+					return err
+				},
+			},
+			{
+				Name:  "normalize",
+				Usage: "Create canonical vX.Y.Z tags for non-canonical version tags",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "apply",
+						Usage: "Create the tags instead of only previewing the plan",
+					},
+					&cli.BoolFlag{
+						Name:  "delete-old",
+						Usage: "Delete the original tags after creating canonical ones",
+					},
 				},
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					_, err = NewBumpService(repo, nil, os.Stdout).Normalize(c.Bool("apply"), c.Bool("delete-old"))
+					return err
+				},
+			},
+			{
+				Name:      "retag",
+				Usage:     "Move an existing version tag to the current HEAD",
+				ArgsUsage: "<version>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "remote",
+						Usage: "Also replace the tag on this remote",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Confirm rewriting the existing tag",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return fmt.Errorf("retag requires exactly one version argument")
+					}
+					repoPath, err := findGitRoot(".")
+					if err != nil {
+						return fmt.Errorf("failed to find git root: %v", err)
+					}
+					repo, err := NewGoGitRepository(repoPath)
+					if err != nil {
+						return err
+					}
+					return NewBumpService(repo, nil, os.Stdout).Retag(c.Args().First(), c.String("remote"), c.Bool("force"))
+				},
+			},
+			{
+				Name:  "retag-from-remote",
+				Usage: "Report version tags on the remote newer than the latest local tag, and fetch them with --fetch",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "remote",
+						Usage: "Remote to compare with (default: the only remote, or origin)",
+					},
+					&cli.BoolFlag{
+						Name:  "fetch",
+						Usage: "Fetch the remote's tags when it is ahead, so the next bump starts from its latest",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repo, err := openTagRepository(c, os.Stderr, ".")
+					if err != nil {
+						return err
+					}
+					_, err = NewBumpService(repo, nil, os.Stdout).RetagFromRemote(c.String("remote"), c.Bool("fetch"))
+					return err
+				},
+			},
+			{
+				Name:  "unlock",
+				Usage: "Remove a stale lock file left behind by a crashed bump",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Remove the lock without asking, even if its holder is still running",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repoPath, err := findGitRoot(".")
+					if err != nil {
+						return fmt.Errorf("failed to find git root: %v", err)
+					}
+					return unlockRepo(os.Stdout, os.Stdin, repoPath, c.Bool("force"), assumeYes(c))
+				},
+			},
+			{
+				Name:  "migrate-config",
+				Usage: "Copy the bump config section from another repo or a template file into this repo",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "from",
+						Usage:    "Repository path or git config file to copy the settings from",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "overwrite",
+						Usage: "Replace settings this repo already has with the source's values",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the config changes without writing them",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					repoPath, err := findGitRoot(".")
+					if err != nil {
+						return fmt.Errorf("failed to find git root: %v", err)
+					}
+					return migrateConfig(os.Stdout, repoPath, c.String("from"), c.Bool("overwrite"), c.Bool("dry-run"))
+				},
+			},
+			{
+				Name:  "hooks",
+				Usage: "Manage git hooks that enforce bump's tag conventions",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "install",
+						Usage: "Install a pre-push hook that rejects tags which are not semantic versions",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Replace an existing pre-push hook, keeping it as pre-push.bak",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							repoPath, err := findGitRoot(".")
+							if err != nil {
+								return fmt.Errorf("failed to find git root: %v", err)
+							}
+							return installPrePushHook(os.Stdout, repoPath, c.Bool("force"))
+						},
+					},
+					{
+						Name:   "pre-push",
+						Usage:  "Check the refs git is about to push; run by the installed pre-push hook",
+						Hidden: true,
+						Action: func(c *cli.Context) error {
+							repo, err := openTagRepository(c, os.Stderr, ".")
+							if err != nil {
+								return err
+							}
+							return checkPrePush(repo.TagScheme(), os.Stdin)
+						},
+					},
+				},
+			},
+			{
+				Name:  "config",
+				Usage: "Configure bump settings for this repo",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "default-push",
+						Usage: "Set default to push tags after bumping",
+					},
+					&cli.StringFlag{
+						Name:  "prefix",
+						Usage: "Set the tag prefix (e.g. v or api/v); rejected if it would produce invalid tag names",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the config changes without writing them",
+					},
+					&cli.BoolFlag{
+						Name:  "path",
+						Usage: "Print the config files bump reads settings from, highest precedence first",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.Bool("path") {
+						return printConfigPath(os.Stdout, ".")
+					}
+					repoPath, err := findGitRoot(".")
+					if err != nil {
+						return fmt.Errorf("failed to find git root: %v", err)
+					}
+					if c.IsSet("default-push") {
+						val := c.Bool("default-push")
+						if c.Bool("dry-run") {
+							changes, err := bump.PreviewDefaultPushPreference(repoPath, val)
+							if err != nil {
+								return fmt.Errorf("failed to preview default push: %v", err)
+							}
+							fmt.Print(formatConfigDiff(changes))
+							return nil
+						}
+						changed, err := bump.SetDefaultPushPreference(repoPath, val)
+						if err != nil {
+							return fmt.Errorf("failed to set default push: %v", err)
+						}
+						if !changed {
+							fmt.Printf("Default push is already %v for this repo.\n", val)
+							return nil
+						}
+						fmt.Printf("Set default push to %v for this repo.\n", val)
+						return nil
+					}
+					if c.IsSet("prefix") {
+						prefix := c.String("prefix")
+						if c.Bool("dry-run") {
+							changes, err := bump.PreviewTagPrefix(repoPath, prefix)
+							if err != nil {
+								return fmt.Errorf("failed to preview prefix: %v", err)
+							}
+							fmt.Print(formatConfigDiff(changes))
+							return nil
+						}
+						changed, err := bump.SetTagPrefix(repoPath, prefix)
+						if err != nil {
+							return fmt.Errorf("failed to set prefix: %v", err)
+						}
+						if !changed {
+							fmt.Printf("Tag prefix is already %q for this repo.\n", strings.TrimSpace(prefix))
+							return nil
+						}
+						fmt.Printf("Set tag prefix to %q for this repo.\n", strings.TrimSpace(prefix))
+						return nil
+					}
+					return cli.ShowSubcommandHelp(c)
+				},
+			},
+		},
+	}
+
+	err := app.Run(os.Args)
+	if err != nil && !errors.Is(err, ErrNoOp) {
+		log.Error(err)
+	}
+	os.Exit(exitCode(err))
+}
+
+// Exit codes of the bump command.
+const (
+	exitOK    = 0 // Success, including no-ops unless --strict-noop is set
+	exitError = 1 // Any failure
+	exitNoOp  = 5 // --strict-noop: nothing was tagged
+)
+
+// ErrNoOp is returned under --strict-noop when a run created no tag, either
+// because HEAD already carries the latest tag or because nothing was committed.
+var ErrNoOp = errors.New("nothing to do")
+
+// exitCode maps the error returned by the app to the process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, ErrNoOp):
+		return exitNoOp
+	default:
+		return exitError
+	}
+}
+
+func createCommand(name, alias, usage string) *cli.Command {
+	return &cli.Command{
+		Name:    name,
+		Aliases: []string{alias},
+		Usage:   usage,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "suffix",
+				Usage: "Add a suffix to the version",
+			},
+			&cli.StringFlag{
+				Name:  "tag-name",
+				Usage: "Tag exactly this name instead of computing the next version (advanced)",
+			},
+			&cli.StringFlag{
+				Name:  "update-file",
+				Usage: "Update a file with the next dev version",
+			},
+			&cli.StringFlag{
+				Name:  "version-field",
+				Usage: "Update a field in a composite literal instead of the Version constant (e.g. info.Version)",
+			},
+			&cli.StringFlag{
+				Name:  "toml-path",
+				Usage: "Dotted key of the version in a TOML --update-file (default: package.version for Cargo.toml, project.version or tool.poetry.version for pyproject.toml)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "constant",
+				Usage: "Also set this constant in --update-file to the released or dev version (Name=release or Name=dev; repeatable)",
+			},
+			&cli.StringFlag{
+				Name:  "expect-package",
+				Usage: "Fail unless the --update-file Go file declares this package",
+			},
+			&cli.BoolFlag{
+				Name:  "push",
+				Usage: "Push the tag to remote after creating it",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what version would be created without making changes",
+			},
+			&cli.BoolFlag{
+				Name:  "show-commands",
+				Usage: "Print the exact git commands that would create and push the tag (implies --dry-run)",
+			},
+			&cli.StringFlag{
+				Name:  "remote",
+				Usage: "Remote to push to (default: the only remote, or origin)",
+			},
+			&cli.StringFlag{
+				Name:  "webhook",
+				Usage: "POST a JSON notification (tag, previous tag, repo path, timestamp) to this URL after bumping",
+			},
+			&cli.BoolFlag{
+				Name:  "webhook-required",
+				Usage: "Fail the bump if the webhook notification fails",
+			},
+			&cli.BoolFlag{
+				Name:  "sign",
+				Usage: "Sign the tag using the format in gpg.format (openpgp, x509, or ssh)",
+			},
+			&cli.IntFlag{
+				Name:  "max-suffix-length",
+				Usage: "Reject suffixes longer than this many characters (default 64, or bump.maxSuffixLength)",
+			},
+			&cli.IntFlag{
+				Name:  "max-suffix-identifiers",
+				Usage: "Reject suffixes with more dot-separated identifiers than this (default 8, or bump.maxSuffixIdentifiers)",
+			},
+			&cli.BoolFlag{
+				Name:  "lenient-zero",
+				Usage: "Accept numeric suffix identifiers with leading zeros (alpha.01) for legacy tags (or bump.lenientZero)",
+			},
+			&cli.BoolFlag{
+				Name:  "require-clean-index",
+				Usage: "Refuse to bump when changes are staged but not committed",
+			},
+			&cli.BoolFlag{
+				Name:  "require-clean-worktree",
+				Usage: "Refuse to bump when any tracked file is staged or modified",
+			},
+			&cli.BoolFlag{
+				Name:  "annotated",
+				Usage: "Create an annotated tag, overriding a lightweight tagType default",
+			},
+			&cli.BoolFlag{
+				Name:  "lightweight",
+				Usage: "Create a lightweight tag, overriding an annotated tagType default",
+			},
+			&cli.BoolFlag{
+				Name:  "check-remote",
+				Usage: "Warn if the remote has a newer version tag than the local repository",
+			},
+			&cli.BoolFlag{
+				Name:  "amend",
+				Usage: "Amend the --update-before-tag change into HEAD instead of creating a new commit",
+			},
+			&cli.StringFlag{
+				Name:  "file-version",
+				Usage: "Version --update-file writes: dev (the next -dev version, after tagging) or release (the tagged version, committed before tagging like --update-before-tag)",
+				Value: fileVersionDev,
+			},
+			&cli.BoolFlag{
+				Name:    "update-before-tag",
+				Aliases: []string{"commit-version-file-before-tag"},
+				Usage:   "Commit the released version to --update-file first and tag that commit",
+			},
+			&cli.BoolFlag{
+				Name:  "explain",
+				Usage: "Print how the next version was computed: the latest tag, tags skipped, bump type, and suffix",
+			},
+			&cli.StringFlag{
+				Name:  "dirty-suffix",
+				Usage: "Append this marker to the dev version written by --update-file when the worktree has uncommitted changes (e.g. dirty)",
+			},
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "Fail instead of warning when HEAD is detached or already has a version tag",
+			},
+			&cli.BoolFlag{
+				Name:  "only-new",
+				Usage: "Push only the newly created tag instead of all local tags",
+			},
+			&cli.BoolFlag{
+				Name:  "no-push-on-prerelease",
+				Usage: "Keep pre-release tags local unless --push is given explicitly",
+			},
+			&cli.BoolFlag{
+				Name:  "allow-prerelease-as-base",
+				Value: true,
+				Usage: "Allow bumping the version of a pre-release latest tag (v1.2.0-rc.1 minor -> v1.3.0); =false makes that an error",
+			},
+			&cli.StringFlag{
+				Name:  "base-from-file",
+				Usage: "Read the current version from a VERSION file and write the new version back to it",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-on-downgrade",
+				Usage: "Refuse to create a tag that is not newer than the latest tag, however it was chosen (default: failOnDowngrade setting)",
+			},
+			&cli.StringFlag{
+				Name:  "tag-message-file",
+				Usage: "Read the tag annotation from a file",
+			},
+			&cli.BoolFlag{
+				Name:  "no-suffix-reset",
+				Usage: "Keep the latest tag's suffix unless --suffix is given",
+			},
+			&cli.BoolFlag{
+				Name:  "print-changelog-after-bump",
+				Usage: "Print the commits included in the new tag",
+			},
+			&cli.BoolFlag{
+				Name:  "include-merge-commits",
+				Usage: "List merge commits in the changelog and GitHub release notes",
+			},
+			&cli.StringFlag{
+				Name:  "repo-url",
+				Usage: "Link (#123) and commit hashes in the changelog to this repository URL (e.g. https://github.com/acme/widgets)",
 			},
-			{
-				Name:  "config",
-				Usage: "Configure bump settings for this repo",
-				Flags: []cli.Flag{
-					&cli.BoolFlag{
-						Name:  "default-push",
-						Usage: "Set default to push tags after bumping",
-					},
-				},
-				Action: func(c *cli.Context) error {
-					repoPath, err := findGitRoot(".")
-					if err != nil {
-						return fmt.Errorf("failed to find git root: %v", err)
-					}
-					if c.IsSet("default-push") {
-						val := c.Bool("default-push")
-						err := bump.SetDefaultPushPreference(repoPath, val)
-						if err != nil {
-							return fmt.Errorf("failed to set default push: %v", err)
-						}
-						fmt.Printf("Set default push to %v for this repo.\n", val)
-						return nil
-					}
-					return cli.ShowSubcommandHelp(c)
-				},
+			&cli.BoolFlag{
+				Name:  "allow-empty",
+				Usage: "Create the tag even if there are no commits since the latest tag",
 			},
-		},
-	}
-
-	err := app.Run(os.Args)
-	if err != nil {
-		log.Fatal(err)
-	}
-}
-
-func createCommand(name, alias, usage string) *cli.Command {
-	return &cli.Command{
-		Name:    name,
-		Aliases: []string{alias},
-		Usage:   usage,
-		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:  "suffix",
-				Usage: "Add a suffix to the version",
+				Name:  "since",
+				Usage: "Count new commits from this tag instead of the latest tag",
+			},
+			&cli.BoolFlag{
+				Name:  "recursive",
+				Usage: "Also bump every version-tagged git submodule",
+			},
+			&cli.BoolFlag{
+				Name:  "signoff",
+				Usage: "Add a Signed-off-by trailer to the tag annotation",
+			},
+			&cli.StringSliceFlag{
+				Name:  "trailer",
+				Usage: "Add a key=value trailer to the tag annotation (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "release-branch",
+				Usage: "Create and switch to a release/<tag> branch, then commit and tag on it (asks first; the global --yes confirms)",
+			},
+			&cli.BoolFlag{
+				Name:    "release-commit",
+				Aliases: []string{"commit"},
+				Usage:   "Create an empty release commit and tag it instead of the current HEAD (requires a clean working tree)",
 			},
 			&cli.StringFlag{
-				Name:  "update-file",
-				Usage: "Update a file with the next dev version",
+				Name:  "release-commit-message",
+				Value: defaultReleaseCommitMessage,
+				Usage: "Message template for --release-commit; {{.Tag}} and {{.Version}} expand to the new tag and version",
+			},
+			&cli.StringSliceFlag{
+				Name:  "also-tag",
+				Usage: "Also point this moving tag (e.g. v1 or latest) at the new tag's commit, replacing it if it exists (repeatable)",
 			},
 			&cli.BoolFlag{
-				Name:  "push",
-				Usage: "Push the tag to remote after creating it",
+				Name:  "github-release",
+				Usage: "Create a GitHub release for the pushed tag (token from BUMP_TOKEN)",
 			},
 			&cli.BoolFlag{
-				Name:  "dry-run",
-				Usage: "Show what version would be created without making changes",
+				Name:  "alpha",
+				Usage: "Tag an alpha pre-release (suffix alpha.N)",
+			},
+			&cli.BoolFlag{
+				Name:  "beta",
+				Usage: "Tag a beta pre-release (suffix beta.N)",
+			},
+			&cli.BoolFlag{
+				Name:  "rc",
+				Usage: "Tag a release candidate (suffix rc.N)",
+			},
+			&cli.BoolFlag{
+				Name:  "increment-prerelease",
+				Usage: "Advance the latest pre-release (rc.1 -> rc.2) instead of bumping the version",
+			},
+			&cli.BoolFlag{
+				Name:  "idempotent",
+				Usage: "Do nothing if HEAD already carries the latest tag",
+			},
+			&cli.BoolFlag{
+				Name:  "single-writer",
+				Usage: "Exit successfully without tagging when another bump holds the repository lock (CI matrix jobs)",
+			},
+			&cli.BoolFlag{
+				Name:  "strict-noop",
+				Usage: "Exit with status 5 when nothing is tagged (existing tag at HEAD or no new commits)",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the result as JSON on stdout (human-readable output goes to stderr)",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Result format: text, json, or github-actions (step outputs written to $GITHUB_OUTPUT)",
+			},
+			&cli.BoolFlag{
+				Name:  "increment-build-metadata",
+				Usage: "Re-tag the latest version with its build counter advanced (+build.42 -> +build.43)",
+			},
+			&cli.BoolFlag{
+				Name:  "timings",
+				Usage: "Print the duration of each phase of the bump",
 			},
 		},
 		Action: func(c *cli.Context) error {
 			pushFlag := c.Bool("push")
 			pushSet := c.IsSet("push")
-			repoPath, err := findGitRoot(".")
+			repo, err := openTagRepository(c, os.Stderr, ".")
+			if err != nil {
+				return err
+			}
+			repoPath := repo.Path()
+			channel, err := prereleaseChannel(c)
 			if err != nil {
-				return fmt.Errorf("failed to find git root: %v", err)
+				return err
+			}
+			if err := checkTagNameFlags(c); err != nil {
+				return err
+			}
+			// A configured default suffix applies only when nothing else picks the version
+			suffix := c.String("suffix")
+			if !c.IsSet("suffix") && channel == "" && !c.IsSet("tag-name") && !c.Bool("increment-prerelease") && !c.Bool("increment-build-metadata") {
+				if suffix, err = stringSetting(c, repoPath, "suffix", "suffix"); err != nil {
+					return err
+				}
+			}
+			updateFile, err := stringSetting(c, repoPath, "update-file", "updateFile")
+			if err != nil {
+				return err
+			}
+			suffixPolicy, _, err := bump.GetConfigString(repoPath, "suffixPolicy")
+			if err != nil {
+				return fmt.Errorf("failed to read the suffixPolicy setting: %w", err)
 			}
 			var doPush bool
 			if pushSet {
 				doPush = pushFlag
 			} else {
-				// Not set on CLI, check repo default
+				// Not set on CLI, check repo default (false when not configured)
 				val, isSet, err := bump.GetDefaultPushPreference(repoPath)
-				if err == nil && isSet {
-					doPush = val // Use explicitly configured value
-				} else {
-					doPush = false // Use default (false) when not configured or error
+				if err != nil {
+					return fmt.Errorf("failed to read the defaultPush setting: %w", err)
+				}
+				doPush = isSet && val
+			}
+			// Pre-releases stay local under the policy unless --push was given explicitly
+			skipPrereleasePush := false
+			if !pushSet {
+				if skipPrereleasePush, err = boolSetting(c, repoPath, "no-push-on-prerelease", "noPushOnPrerelease"); err != nil {
+					return err
 				}
 			}
-			return bumpVersion(name, c.String("suffix"), c.String("update-file"), doPush, c.Bool("dry-run"))
+			allowPrereleaseBase, err := boolSetting(c, repoPath, "allow-prerelease-as-base", "allowPrereleaseAsBase")
+			if err != nil {
+				return err
+			}
+			failOnDowngrade, err := boolSetting(c, repoPath, "fail-on-downgrade", "failOnDowngrade")
+			if err != nil {
+				return err
+			}
+			tagType, _, err := bump.GetConfigString(repoPath, "tagType")
+			if err != nil {
+				return fmt.Errorf("failed to read the tagType setting: %w", err)
+			}
+			lightweight, err := resolveLightweight(tagType, c.Bool("annotated"), c.Bool("lightweight"))
+			if err != nil {
+				return err
+			}
+			updater, err := versionFileUpdater(c.String("version-field"), c.String("expect-package"), c.String("toml-path"))
+			if err != nil {
+				return err
+			}
+			limits, err := suffixLimits(c, repoPath)
+			if err != nil {
+				return err
+			}
+			outputMode, err := resolveOutputMode(c.String("output"), c.Bool("json"))
+			if err != nil {
+				return err
+			}
+			auditLog, _, err := bump.GetConfigString(repoPath, "auditLog")
+			if err != nil {
+				return fmt.Errorf("failed to read the auditLog setting: %w", err)
+			}
+			dirtySuffix, err := stringSetting(c, repoPath, "dirty-suffix", "dirtySuffix")
+			if err != nil {
+				return err
+			}
+			repoURL, err := stringSetting(c, repoPath, "repo-url", "repoUrl")
+			if err != nil {
+				return err
+			}
+			fileVersion, err := stringSetting(c, repoPath, "file-version", "fileVersion")
+			if err != nil {
+				return err
+			}
+			commitMessage, err := stringSetting(c, repoPath, "release-commit-message", "releaseCommitMessage")
+			if err != nil {
+				return err
+			}
+			return bumpVersion(repo, BumpOptions{
+				BumpType:            name,
+				Suffix:              suffix,
+				UpdateFile:          updateFile,
+				Push:                doPush,
+				DryRun:              c.Bool("dry-run") || c.Bool("show-commands"),
+				ShowCommands:        c.Bool("show-commands"),
+				Timings:             c.Bool("timings"),
+				TagMessageFile:      c.String("tag-message-file"),
+				Recursive:           c.Bool("recursive"),
+				PrintChangelog:      c.Bool("print-changelog-after-bump"),
+				IncludeMerges:       c.Bool("include-merge-commits"),
+				RepoURL:             repoURL,
+				AllowEmpty:          c.Bool("allow-empty"),
+				Since:               c.String("since"),
+				Signoff:             c.Bool("signoff"),
+				Trailers:            c.StringSlice("trailer"),
+				AlsoTag:             c.StringSlice("also-tag"),
+				ReleaseBranch:       c.Bool("release-branch"),
+				ReleaseCommit:       c.Bool("release-commit"),
+				CommitMessage:       commitMessage,
+				Confirm:             promptConfirm(c),
+				GitHubRelease:       c.Bool("github-release"),
+				Channel:             channel,
+				IncrementPrerelease: c.Bool("increment-prerelease"),
+				SkipPrereleasePush:  skipPrereleasePush,
+				BaseFromFile:        c.String("base-from-file"),
+				Remote:              c.String("remote"),
+				Idempotent:          c.Bool("idempotent"),
+				OnlyNew:             c.Bool("only-new"),
+				Strict:              c.Bool("strict"),
+				RequireCleanIndex:   c.Bool("require-clean-index"),
+				RequireCleanTree:    c.Bool("require-clean-worktree"),
+				Amend:               c.Bool("amend"),
+				UpdateBeforeTag:     c.Bool("update-before-tag"),
+				FileVersion:         fileVersion,
+				CheckRemote:         c.Bool("check-remote"),
+				Sign:                c.Bool("sign"),
+				Lightweight:         lightweight,
+				SuffixLimits:        limits,
+				Webhook:             c.String("webhook"),
+				WebhookRequired:     c.Bool("webhook-required"),
+				IncrementBuild:      c.Bool("increment-build-metadata"),
+				TagName:             c.String("tag-name"),
+				SingleWriter:        c.Bool("single-writer"),
+				AuditLog:            auditLog,
+				DirtySuffix:         dirtySuffix,
+				Constants:           c.StringSlice("constant"),
+				Explain:             c.Bool("explain"),
+				NoPrereleaseBase:    !allowPrereleaseBase,
+				FailOnDowngrade:     failOnDowngrade,
+				SuffixPolicy:        suffixPolicy,
+				// An explicit --suffix, even an empty one, overrides the preserved suffix
+				PreserveSuffix: c.Bool("no-suffix-reset") && !c.IsSet("suffix"),
+			}, updater, outputMode, c.Bool("strict-noop"))
 		},
 	}
 }
 
+// versionFlags lists the flags that shape the computed version, which --tag-name replaces.
+var versionFlags = []string{"suffix", "alpha", "beta", "rc", "increment-prerelease", "increment-build-metadata", "no-suffix-reset"}
+
+// checkTagNameFlags rejects --tag-name together with flags that only affect the
+// computed version, since they would be silently ignored.
+func checkTagNameFlags(c *cli.Context) error {
+	if !c.IsSet("tag-name") {
+		return nil
+	}
+	for _, name := range versionFlags {
+		if c.IsSet(name) {
+			return fmt.Errorf("--tag-name cannot be used with --%s", name)
+		}
+	}
+	return nil
+}
+
+// prereleaseChannels lists the pre-release channel flags in SemVer precedence order.
+var prereleaseChannels = []string{"alpha", "beta", "rc"}
+
+// prereleaseChannel returns the channel selected by --alpha, --beta, or --rc.
+// At most one channel may be given, and not together with --suffix.
+func prereleaseChannel(c *cli.Context) (string, error) {
+	var channel string
+	for _, name := range prereleaseChannels {
+		if !c.Bool(name) {
+			continue
+		}
+		if channel != "" {
+			return "", fmt.Errorf("--%s and --%s cannot be used together", channel, name)
+		}
+		channel = name
+	}
+	if channel != "" && c.IsSet("suffix") {
+		return "", fmt.Errorf("--%s cannot be used with --suffix", channel)
+	}
+	return channel, nil
+}
+
+// suffixLimits returns the suffix limits for a bump. Each limit comes from its flag,
+// then the maxSuffixLength, maxSuffixIdentifiers, or lenientZero setting, then the default.
+func suffixLimits(c *cli.Context, repoPath string) (SuffixLimits, error) {
+	maxLength, err := intSetting(c, repoPath, "max-suffix-length", "maxSuffixLength", defaultSuffixLimits.MaxLength)
+	if err != nil {
+		return SuffixLimits{}, err
+	}
+	maxIdentifiers, err := intSetting(c, repoPath, "max-suffix-identifiers", "maxSuffixIdentifiers", defaultSuffixLimits.MaxIdentifiers)
+	if err != nil {
+		return SuffixLimits{}, err
+	}
+	lenientZero, err := boolSetting(c, repoPath, "lenient-zero", "lenientZero")
+	if err != nil {
+		return SuffixLimits{}, err
+	}
+	return SuffixLimits{MaxLength: maxLength, MaxIdentifiers: maxIdentifiers, LenientZero: lenientZero}, nil
+}
+
+// openTagRepository opens the repository containing startPath for a command that
+// reads version tags, with the repository's tag settings read by tagScheme.
+// Commands that never read tags open the repository themselves, so a bad tag
+// setting does not get in their way.
+func openTagRepository(c *cli.Context, w io.Writer, startPath string) (*GoGitRepository, error) {
+	repoPath, err := findGitRoot(startPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find git root: %v", err)
+	}
+	scheme, err := tagScheme(c, w, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := NewGoGitRepository(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	repo.scheme = scheme
+	return repo, nil
+}
+
+// tagScheme reads the settings of the repository at repoPath that decide which
+// tags are versions and how they sort: the suffix separator, the tag pattern
+// (overridden by --tag-pattern), and the channel order. It warns on w when the
+// separator is not the SemVer-compliant "-".
+func tagScheme(c *cli.Context, w io.Writer, repoPath string) (bump.TagScheme, error) {
+	opts, err := bump.ReadTagSchemeOptions(repoPath)
+	if err != nil {
+		return bump.TagScheme{}, err
+	}
+	if c.IsSet("tag-pattern") {
+		opts.TagPattern = c.String("tag-pattern")
+	}
+	scheme, err := bump.NewTagScheme(opts)
+	if err != nil {
+		return bump.TagScheme{}, err
+	}
+	if sep := scheme.SuffixSeparator(); sep != bump.DefaultSuffixSeparator {
+		if _, err := fmt.Fprintf(w, "Warning: suffixSeparator %q is not SemVer-compliant; other tools may not recognize tags such as v1.2.3%sbeta.1\n", sep, sep); err != nil {
+			return bump.TagScheme{}, err
+		}
+	}
+	return scheme, nil
+}
+
+// stringSetting returns the value of the flag when given, otherwise the repository
+// setting key when set, otherwise the flag's default. A setting that cannot be read
+// is an error rather than a silent fallback to the default.
+func stringSetting(c *cli.Context, repoPath, flag, key string) (string, error) {
+	if c.IsSet(flag) {
+		return c.String(flag), nil
+	}
+	val, isSet, err := bump.GetConfigString(repoPath, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the %s setting: %w", key, err)
+	}
+	if !isSet {
+		return c.String(flag), nil
+	}
+	return val, nil
+}
+
+// boolSetting is stringSetting for boolean flags and settings.
+func boolSetting(c *cli.Context, repoPath, flag, key string) (bool, error) {
+	if c.IsSet(flag) {
+		return c.Bool(flag), nil
+	}
+	val, isSet, err := bump.GetConfigBool(repoPath, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the %s setting: %w", key, err)
+	}
+	if !isSet {
+		return c.Bool(flag), nil
+	}
+	return val, nil
+}
+
+// intSetting returns the non-negative value of the flag when given, otherwise the
+// repository setting key, otherwise def. A setting that cannot be read is an error
+// rather than a silent fallback to def.
+func intSetting(c *cli.Context, repoPath, flag, key string, def int) (int, error) {
+	value := def
+	if c.IsSet(flag) {
+		value = c.Int(flag)
+	} else if val, isSet, err := bump.GetConfigString(repoPath, key); err != nil {
+		return 0, fmt.Errorf("failed to read the %s setting: %w", key, err)
+	} else if isSet {
+		if value, err = strconv.Atoi(strings.TrimSpace(val)); err != nil {
+			return 0, fmt.Errorf("invalid %s %q: expected a number", key, val)
+		}
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("--%s must not be negative", flag)
+	}
+	return value, nil
+}
+
+// configureLogColor sets the color profile of logger, which writes to w. Colors are
+// disabled with noColor, when NO_COLOR is set, or when w is not a terminal, so CI
+// logs and redirected output carry no escape codes.
+func configureLogColor(logger *log.Logger, w io.Writer, noColor bool) {
+	logger.SetColorProfile(logColorProfile(w, noColor))
+}
+
+// logColorProfile returns the color profile for log output written to w.
+func logColorProfile(w io.Writer, noColor bool) termenv.Profile {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return termenv.Ascii
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return termenv.Ascii
+	}
+	if !isTerminal(f) {
+		return termenv.Ascii
+	}
+	return termenv.NewOutput(f).ColorProfile()
+}
+
 // findGitRoot walks up the directory tree from the given startPath until it finds a .git directory.
 // If no .git directory is found, it returns an error.
 func findGitRoot(startPath string) (string, error) {
@@ -139,37 +1229,241 @@ func findGitRoot(startPath string) (string, error) {
 	}
 }
 
-// bumpVersion bumps the version using the BumpService.
-func bumpVersion(bumpType, suffix, updateFile string, doPush, dryRun bool) error {
-	// Find git root
-	repoPath, err := findGitRoot(".")
+// printConfigPath writes the config files bump reads for the repository containing
+// startPath, highest precedence first: the --config-file, if any, then a .bumprc or
+// .versionrc at the repository root, then the git config.
+func printConfigPath(w io.Writer, startPath string) error {
+	repoPath, err := findGitRoot(startPath)
 	if err != nil {
 		return fmt.Errorf("failed to find git root: %v", err)
 	}
+	configPath, err := bump.ConfigPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %v", err)
+	}
+	if file := bump.ConfigFile(); file != "" {
+		if _, err := fmt.Fprintln(w, file); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	if rcPath := bump.RCFilePath(repoPath); rcPath != "" {
+		if _, err := fmt.Fprintln(w, rcPath); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	if _, err := fmt.Fprintln(w, configPath); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
 
-	// Open repository
-	repo, err := NewGoGitRepository(repoPath)
+// assumeYes reports whether confirmations should be answered automatically with the
+// global --yes (also set by BUMP_ASSUME_YES).
+func assumeYes(c *cli.Context) bool {
+	for _, ctx := range c.Lineage() {
+		if ctx.Bool("yes") {
+			return true
+		}
+	}
+	return false
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks question on w and reads a y/N answer from in. assumeYes answers yes
+// without reading; when in is a file that is not a terminal, such as stdin in CI,
+// the question is declined rather than waiting for input that never comes.
+// Every prompt goes through confirm so all of them honor --yes.
+func confirm(w io.Writer, in io.Reader, question string, assumeYes bool) (bool, error) {
+	if _, err := fmt.Fprintf(w, "%s [y/N] ", question); err != nil {
+		return false, fmt.Errorf("failed to write output: %w", err)
+	}
+	if assumeYes {
+		if _, err := fmt.Fprintln(w, "y (--yes)"); err != nil {
+			return false, fmt.Errorf("failed to write output: %w", err)
+		}
+		return true, nil
+	}
+	if f, ok := in.(*os.File); ok && !isTerminal(f) {
+		if _, err := fmt.Fprintln(w, "n (stdin is not a terminal; pass --yes to confirm)"); err != nil {
+			return false, fmt.Errorf("failed to write output: %w", err)
+		}
+		return false, nil
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// promptConfirm returns a ConfirmFunc that asks on stderr, keeping stdout free for
+// --json, and reads the answer from stdin through confirm.
+func promptConfirm(c *cli.Context) ConfirmFunc {
+	return func(question string) (bool, error) {
+		return confirm(os.Stderr, os.Stdin, question, assumeYes(c))
+	}
+}
+
+// unlockRepo reports the holder of the repository's lock file and removes it after
+// the user confirms on in, or right away with assumeYes. A lock whose holder is
+// still running is kept; force removes it regardless and skips the confirmation.
+func unlockRepo(w io.Writer, in io.Reader, repoPath string, force, assumeYes bool) error {
+	holder, err := bump.ReadLock(repoPath)
 	if err != nil {
 		return err
 	}
+	if holder == nil {
+		if _, err := fmt.Fprintln(w, "No lock file found"); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
 
-	// Create service
-	svc := NewBumpService(repo, nil, os.Stdout)
+	if _, err := fmt.Fprint(w, formatLockHolder(*holder)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if !force {
+		if holder.Alive {
+			return fmt.Errorf("process %d holding %s is still running; pass --force to remove the lock anyway", holder.PID, holder.Path)
+		}
+		confirmed, err := confirm(w, in, "Remove it?", assumeYes)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			if _, err := fmt.Fprintln(w, "Lock kept"); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+			return nil
+		}
+	}
+
+	if err := bump.RemoveLock(holder); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Removed %s\n", holder.Path); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
 
-	// Build options
-	opts := BumpOptions{
-		BumpType:   bumpType,
-		Suffix:     suffix,
-		UpdateFile: updateFile,
-		Push:       doPush,
-		DryRun:     dryRun,
+// migrateConfig copies the bump section of the repository or config file at from
+// into the repository at repoPath and prints the resulting config diff. Settings
+// the repository already has are kept unless overwrite is set.
+func migrateConfig(w io.Writer, repoPath, from string, overwrite, dryRun bool) error {
+	values, err := bump.ReadConfigSection(from)
+	if err != nil {
+		return fmt.Errorf("failed to read config from %s: %w", from, err)
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("%s has no [%s] settings to copy", from, bump.ConfigSection())
 	}
 
-	// Execute bump
-	_, err = svc.Bump(opts)
+	changes, skipped, err := bump.MigrateConfig(repoPath, values, overwrite, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to migrate config: %w", err)
+	}
+	if _, err := fmt.Fprint(w, formatConfigDiff(changes)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if len(skipped) > 0 {
+		if _, err := fmt.Fprintf(w, "Kept existing values for %s (pass --overwrite to replace them)\n", strings.Join(skipped, ", ")); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	if len(changes) > 0 && !dryRun {
+		if _, err := fmt.Fprintf(w, "Copied %d setting(s) from %s\n", len(changes), from); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return nil
+}
+
+// statusSettingKeys lists the settings shown by bump status, in display order.
+var statusSettingKeys = []string{"defaultPush", "noPushOnPrerelease", "prefix", "suffixPolicy", "tagType"}
+
+// printStatus writes the release status of repo.
+func printStatus(w io.Writer, repo *GoGitRepository) error {
+	repoPath := repo.Path()
+	settings := make([]ConfigSetting, len(statusSettingKeys))
+	for i, key := range statusSettingKeys {
+		settings[i].Key = key
+		val, isSet, err := bump.GetConfigString(repoPath, key)
+		if err != nil {
+			return fmt.Errorf("failed to read the %s setting: %w", key, err)
+		}
+		settings[i].Value, settings[i].Set = val, isSet
+	}
+
+	_, err := NewBumpService(repo, nil, w).Status(settings)
 	return err
 }
 
+// bumpVersion bumps the version of repo using the BumpService.
+// A nil updater updates the Version constant in --update-file.
+// With the json output mode, the result is printed as JSON on stdout; with
+// github-actions, it is written as step outputs to $GITHUB_OUTPUT, or to stdout
+// when that is unset. Whenever the result goes to stdout, the human-readable
+// messages are sent to stderr instead. With strictNoOp, a run that tags nothing
+// returns ErrNoOp.
+func bumpVersion(repo GitRepository, opts BumpOptions, updater *VersionFileUpdater, outputMode string, strictNoOp bool) error {
+	// Create service
+	output := io.Writer(os.Stdout)
+	if outputMode == outputJSON || (outputMode == outputGitHubActions && os.Getenv(githubOutputEnv) == "") {
+		output = os.Stderr
+	}
+	svc := NewBumpService(repo, updater, output)
+
+	// Execute bump
+	result, err := svc.Bump(opts)
+	if err == nil {
+		switch outputMode {
+		case outputJSON:
+			if err := writeJSONResult(os.Stdout, result); err != nil {
+				return err
+			}
+		case outputGitHubActions:
+			if err := writeGitHubOutput(os.Stdout, result, opts.BumpType); err != nil {
+				return err
+			}
+		}
+	}
+	return applyNoOpPolicy(result, err, strictNoOp)
+}
+
+// versionFileUpdater returns the updater for --version-field, --expect-package,
+// and --toml-path, or nil for the default updater of the Version constant when
+// none is given.
+func versionFileUpdater(field, expectPackage, tomlPath string) (*VersionFileUpdater, error) {
+	if field == "" && expectPackage == "" && tomlPath == "" {
+		return nil, nil
+	}
+	updater := NewVersionFileUpdater()
+	if field != "" {
+		var err error
+		if updater, err = NewFieldVersionFileUpdater(field); err != nil {
+			return nil, err
+		}
+	}
+	if expectPackage != "" {
+		if err := updater.ExpectPackage(expectPackage); err != nil {
+			return nil, err
+		}
+	}
+	if tomlPath != "" {
+		if err := updater.SetTOMLPath(tomlPath); err != nil {
+			return nil, err
+		}
+	}
+	return updater, nil
+}
+
 // validateFilePath performs comprehensive validation to prevent path traversal attacks
 func validateFilePath(filePath, repoPath string) error {
 	// Check for empty or whitespace-only paths
@@ -179,10 +1473,10 @@ func validateFilePath(filePath, repoPath string) error {
 
 	// Check for suspicious patterns that indicate path traversal attempts
 	suspiciousPatterns := []string{
-		"..",           // Directory traversal
-		"\x00",         // Null byte injection
-		"\r",           // Carriage return
-		"\n",           // Newline injection
+		"..",   // Directory traversal
+		"\x00", // Null byte injection
+		"\r",   // Carriage return
+		"\n",   // Newline injection
 	}
 
 	for _, pattern := range suspiciousPatterns {
@@ -193,7 +1487,7 @@ func validateFilePath(filePath, repoPath string) error {
 
 	// Clean the path and resolve to absolute path
 	cleanPath := filepath.Clean(filePath)
-	
+
 	// Prevent paths that would resolve outside the working directory
 	if filepath.IsAbs(cleanPath) {
 		return fmt.Errorf("absolute paths are not allowed")
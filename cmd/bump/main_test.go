@@ -1,9 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+	"github.com/urfave/cli/v2"
 )
 
 // TestFindGitRoot tests the findGitRoot function
@@ -24,9 +34,9 @@ func TestFindGitRoot(t *testing.T) {
 	}
 
 	tests := []struct {
-		name        string
-		startPath   string
-		expectError bool
+		name         string
+		startPath    string
+		expectError  bool
 		expectedRoot string
 	}{
 		{
@@ -214,29 +224,450 @@ func TestValidateFilePathBoundaryChecks(t *testing.T) {
 	}
 }
 
-// TestBumpVersionNoRepo tests bumpVersion when not in a git repository
-func TestBumpVersionNoRepo(t *testing.T) {
-	// Create a temp directory WITHOUT .git
-	tempDir := t.TempDir()
+// TestOpenTagRepository tests opening the repository for a tag command, which fails
+// outside a repository and when the tag settings cannot be read
+func TestOpenTagRepository(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	ctx := cli.NewContext(nil, flag.NewFlagSet("test", flag.ContinueOnError), nil)
+
+	var out bytes.Buffer
+	repo, err := openTagRepository(ctx, &out, filepath.Join(repoDir, "."))
+	if err != nil {
+		t.Fatalf("openTagRepository() unexpected error = %v", err)
+	}
+	if repo.Path() != repoDir {
+		t.Errorf("Path() = %s, expected %s", repo.Path(), repoDir)
+	}
+	if out.Len() != 0 {
+		t.Errorf("openTagRepository() output = %q, expected no warning", out.String())
+	}
+
+	runGit("config", "bump.suffixSeparator", "_")
+	if repo, err = openTagRepository(ctx, &out, repoDir); err != nil {
+		t.Fatalf("openTagRepository() unexpected error = %v", err)
+	}
+	if sep := repo.TagScheme().SuffixSeparator(); sep != "_" {
+		t.Errorf("TagScheme().SuffixSeparator() = %q, expected _", sep)
+	}
+	if strings.Count(out.String(), "not SemVer-compliant") != 1 {
+		t.Errorf("openTagRepository() output = %q, expected a single warning", out.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, ".bumprc"), []byte(`{"suffix": `), 0o644); err != nil {
+		t.Fatalf("write .bumprc: %v", err)
+	}
+	if _, err := openTagRepository(ctx, &out, repoDir); err == nil {
+		t.Error("openTagRepository() should fail when the settings cannot be read")
+	}
+
+	if _, err := openTagRepository(ctx, &out, t.TempDir()); err == nil {
+		t.Error("openTagRepository() should error outside a git repository")
+	}
+}
+
+// TestPrintConfigPath tests printing the config path from a repo subdirectory and outside a repo
+func TestPrintConfigPath(t *testing.T) {
+	repoDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0o755); err != nil {
+		t.Fatalf("failed to create .git dir: %v", err)
+	}
+	subDir := filepath.Join(repoDir, "pkg", "widgets")
+	if err := os.MkdirAll(subDir, 0o755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := printConfigPath(&out, subDir); err != nil {
+		t.Fatalf("printConfigPath() unexpected error = %v", err)
+	}
+	if expected := filepath.Join(repoDir, ".git", "config") + "\n"; out.String() != expected {
+		t.Errorf("printConfigPath() output = %q, expected %q", out.String(), expected)
+	}
+
+	out.Reset()
+	if err := printConfigPath(&out, t.TempDir()); err == nil {
+		t.Error("printConfigPath() should error outside a git repository")
+	}
+	if out.Len() != 0 {
+		t.Errorf("printConfigPath() wrote %q outside a git repository", out.String())
+	}
+}
+
+// TestBumpCommandRCFile tests that settings from a .bumprc take effect in a bump
+func TestBumpCommandRCFile(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Initial commit")
+	runGit("tag", "v1.0.0")
+	if err := os.WriteFile(filepath.Join(repoDir, ".bumprc"), []byte(`{"suffix": "beta", "push": false, "updateFiles": ["version.go"]}`), 0o644); err != nil {
+		t.Fatalf("failed to write .bumprc: %v", err)
+	}
+	runGit("add", ".bumprc")
+	runGit("commit", "-m", "Add .bumprc")
+	t.Chdir(repoDir)
+
+	var out bytes.Buffer
+	if err := printConfigPath(&out, "."); err != nil {
+		t.Fatalf("printConfigPath() unexpected error = %v", err)
+	}
+	if !strings.HasPrefix(out.String(), ".bumprc\n") {
+		t.Errorf("printConfigPath() output = %q, expected .bumprc before the git config", out.String())
+	}
+
+	app := &cli.App{Commands: []*cli.Command{createCommand("patch", "p", "Bump the patch version")}}
+	if err := app.Run([]string{"bump", "patch"}); err != nil {
+		t.Fatalf("bump patch unexpected error = %v", err)
+	}
+	if tags := strings.TrimSpace(runGit("tag", "--points-at", "HEAD~1")); tags != "v1.0.1-beta" {
+		t.Errorf("tags = %q, expected v1.0.1-beta from the .bumprc suffix", tags)
+	}
+	if subject := strings.TrimSpace(runGit("log", "-1", "--format=%s")); !strings.HasPrefix(subject, "Bump version to") {
+		t.Errorf("HEAD subject = %q, expected the .bumprc updateFiles commit", subject)
+	}
+
+	// A typo in the file fails the command instead of silently dropping every setting
+	if err := os.WriteFile(filepath.Join(repoDir, ".bumprc"), []byte(`{"suffix": "beta",}`), 0o644); err != nil {
+		t.Fatalf("failed to write .bumprc: %v", err)
+	}
+	err := app.Run([]string{"bump", "patch", "--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), ".bumprc") {
+		t.Errorf("bump patch with a malformed .bumprc error = %v, expected a parse error", err)
+	}
+}
+
+// TestPrintStatus tests the status overview of a temp repository with known state
+func TestPrintStatus(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "first")
+	runGit("tag", "-a", "v1.2.3", "-m", "v1.2.3")
+	commitFile(t, repoDir, runGit, "b.txt", "second")
+	commitFile(t, repoDir, runGit, "c.txt", "third")
+	if err := os.WriteFile(filepath.Join(repoDir, "a.txt"), []byte("changed"), 0o644); err != nil {
+		t.Fatalf("failed to modify a.txt: %v", err)
+	}
+	runGit("config", "bump.defaultPush", "true")
+	runGit("config", "bump.prefix", "v")
 
-	// Change to temp directory
-	origDir, err := os.Getwd()
+	repo, err := NewGoGitRepository(repoDir)
 	if err != nil {
-		t.Fatalf("failed to get current directory: %v", err)
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	var out bytes.Buffer
+	if err := printStatus(&out, repo); err != nil {
+		t.Fatalf("printStatus() unexpected error = %v", err)
+	}
+
+	for _, line := range []string{
+		"Latest tag:          v1.2.3\n",
+		"Next patch:          v1.2.4\n",
+		"Next minor:          v1.3.0\n",
+		"Next major:          v2.0.0\n",
+		"Working tree:        dirty (1 changed: a.txt)\n",
+		"Commits since tag:   2\n",
+		"defaultPush:         true\n",
+		"noPushOnPrerelease:  (not set)\n",
+		"prefix:              v\n",
+		"suffixPolicy:        (not set)\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("printStatus() output missing %q:\n%s", line, out.String())
+		}
+	}
+
+}
+
+// TestMigrateConfigCommand tests copying settings from a template file and from another repo
+func TestMigrateConfigCommand(t *testing.T) {
+	template := filepath.Join(t.TempDir(), "bump.ini")
+	if err := os.WriteFile(template, []byte("[bump]\n\tdefaultPush = true\n\tprefix = v\n"), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	repoDir, _ := newGitRepoWithCommits(t)
+
+	var out bytes.Buffer
+	if err := migrateConfig(&out, repoDir, template, false, false); err != nil {
+		t.Fatalf("migrateConfig() unexpected error = %v", err)
+	}
+	expected := "[bump]\n+ defaultPush = true\n+ prefix = v\nCopied 2 setting(s) from " + template + "\n"
+	if out.String() != expected {
+		t.Errorf("migrateConfig() output = %q, expected %q", out.String(), expected)
+	}
+
+	// The migrated repo can itself serve as the source
+	otherDir, runGit := newGitRepoWithCommits(t)
+	runGit("config", "bump.prefix", "release-")
+	out.Reset()
+	if err := migrateConfig(&out, otherDir, repoDir, false, false); err != nil {
+		t.Fatalf("migrateConfig() unexpected error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Kept existing values for prefix") {
+		t.Errorf("migrateConfig() output = %q, expected the kept prefix", out.String())
+	}
+	if prefix := strings.TrimSpace(runGit("config", "bump.prefix")); prefix != "release-" {
+		t.Errorf("prefix = %q, expected release- to be kept without --overwrite", prefix)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.ini")
+	if err := os.WriteFile(empty, []byte("[core]\n\tbare = false\n"), 0o644); err != nil {
+		t.Fatalf("failed to write empty config: %v", err)
+	}
+	if err := migrateConfig(&bytes.Buffer{}, repoDir, empty, false, false); err == nil || !strings.Contains(err.Error(), "no [bump] settings") {
+		t.Errorf("migrateConfig() error = %v, expected a missing section error", err)
+	}
+}
+
+// TestUnlockRepo tests removing a stale lock after confirmation and refusing one whose holder is running
+func TestUnlockRepo(t *testing.T) {
+	repoDir, _ := newGitRepoWithCommits(t)
+	lockPath := filepath.Join(repoDir, ".git", "bump.lock")
+	writeLock := func(pid int) {
+		t.Helper()
+		content := fmt.Sprintf("pid: %d\ntime: 2024-01-02T03:04:05Z\n", pid)
+		if err := os.WriteFile(lockPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write lock file: %v", err)
+		}
+	}
+	lockExists := func() bool {
+		_, err := os.Stat(lockPath)
+		return err == nil
+	}
+
+	// A stale lock is kept unless the removal is confirmed
+	writeLock(99999999)
+	var out bytes.Buffer
+	if err := unlockRepo(&out, strings.NewReader("n\n"), repoDir, false, false); err != nil {
+		t.Fatalf("unlockRepo() unexpected error = %v", err)
+	}
+	if !lockExists() {
+		t.Error("unlockRepo() removed the lock without confirmation")
+	}
+	if !strings.Contains(out.String(), "Held by: process 99999999 (not running)") || !strings.Contains(out.String(), "Taken at: 2024-01-02T03:04:05Z") {
+		t.Errorf("unlockRepo() output = %q, expected the holder details", out.String())
+	}
+	if err := unlockRepo(&bytes.Buffer{}, strings.NewReader("y\n"), repoDir, false, false); err != nil {
+		t.Fatalf("unlockRepo() unexpected error = %v", err)
+	}
+	if lockExists() {
+		t.Error("unlockRepo() kept a stale lock after confirmation")
+	}
+
+	// A lock held by a running process is refused unless forced
+	writeLock(os.Getpid())
+	if err := unlockRepo(&bytes.Buffer{}, strings.NewReader("y\n"), repoDir, false, false); err == nil || !strings.Contains(err.Error(), "still running") {
+		t.Errorf("unlockRepo() error = %v, expected to refuse a live lock", err)
+	}
+	if !lockExists() {
+		t.Error("unlockRepo() removed a live lock without --force")
+	}
+	if err := unlockRepo(&bytes.Buffer{}, strings.NewReader(""), repoDir, true, false); err != nil {
+		t.Fatalf("unlockRepo() with force unexpected error = %v", err)
+	}
+	if lockExists() {
+		t.Error("unlockRepo() with force kept the lock")
+	}
+
+	// --yes confirms the removal of a stale lock without reading an answer
+	writeLock(99999999)
+	if err := unlockRepo(&bytes.Buffer{}, strings.NewReader(""), repoDir, false, true); err != nil {
+		t.Fatalf("unlockRepo() with assumeYes unexpected error = %v", err)
+	}
+	if lockExists() {
+		t.Error("unlockRepo() with assumeYes kept a stale lock")
+	}
+
+	out.Reset()
+	if err := unlockRepo(&out, strings.NewReader(""), repoDir, false, false); err != nil || out.String() != "No lock file found\n" {
+		t.Errorf("unlockRepo() without a lock = %q, %v", out.String(), err)
+	}
+}
+
+// TestConfirm tests answering prompts from input, --yes, and a non-terminal stdin
+func TestConfirm(t *testing.T) {
+	// A file stands in for stdin redirected in CI; its "y" must never be read
+	notTerminal, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	if err != nil {
+		t.Fatalf("failed to create stdin file: %v", err)
+	}
+	defer notTerminal.Close()
+	if _, err := notTerminal.WriteString("y\n"); err != nil {
+		t.Fatalf("failed to write stdin file: %v", err)
+	}
+	if _, err := notTerminal.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("failed to rewind stdin file: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		in             io.Reader
+		assumeYes      bool
+		expected       bool
+		expectedOutput string
+	}{
+		{name: "yes answer", in: strings.NewReader("y\n"), expected: true, expectedOutput: "Proceed? [y/N] "},
+		{name: "no answer", in: strings.NewReader("n\n"), expected: false, expectedOutput: "Proceed? [y/N] "},
+		{name: "empty input", in: strings.NewReader(""), expected: false, expectedOutput: "Proceed? [y/N] "},
+		{name: "assume yes", in: strings.NewReader("n\n"), assumeYes: true, expected: true, expectedOutput: "Proceed? [y/N] y (--yes)\n"},
+		{name: "not a terminal", in: notTerminal, expected: false, expectedOutput: "Proceed? [y/N] n (stdin is not a terminal; pass --yes to confirm)\n"},
+		{name: "not a terminal with assume yes", in: notTerminal, assumeYes: true, expected: true, expectedOutput: "Proceed? [y/N] y (--yes)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := confirm(&out, tt.in, "Proceed?", tt.assumeYes)
+			if err != nil {
+				t.Fatalf("confirm() unexpected error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("confirm() = %v, expected %v", got, tt.expected)
+			}
+			if out.String() != tt.expectedOutput {
+				t.Errorf("confirm() output = %q, expected %q", out.String(), tt.expectedOutput)
+			}
+		})
+	}
+}
+
+// TestAssumeYes tests that the global --yes and BUMP_ASSUME_YES reach commands
+func TestAssumeYes(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      string
+		expected bool
+	}{
+		{name: "not set", args: []string{"bump", "run"}, expected: false},
+		{name: "global flag", args: []string{"bump", "--yes", "run"}, expected: true},
+		{name: "global short flag", args: []string{"bump", "-y", "run"}, expected: true},
+		{name: "global non-interactive", args: []string{"bump", "--non-interactive", "run"}, expected: true},
+		{name: "environment", args: []string{"bump", "run"}, env: "1", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BUMP_ASSUME_YES", tt.env)
+			var got bool
+			app := &cli.App{
+				Flags: []cli.Flag{&cli.BoolFlag{Name: "yes", Aliases: []string{"y", "non-interactive"}, EnvVars: []string{"BUMP_ASSUME_YES"}}},
+				Commands: []*cli.Command{{
+					Name:   "run",
+					Action: func(c *cli.Context) error { got = assumeYes(c); return nil },
+				}},
+			}
+			if err := app.Run(tt.args); err != nil {
+				t.Fatalf("app.Run() unexpected error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("assumeYes() = %v, expected %v", got, tt.expected)
+			}
+		})
 	}
-	defer func() {
-		if err := os.Chdir(origDir); err != nil {
-			t.Logf("warning: failed to restore original directory: %v", err)
+}
+
+// TestTagSchemeTagPattern tests reading the tag pattern from the flag or the tagPattern setting
+func TestTagSchemeTagPattern(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	names := []string{"v1.2.0", "v1.3.0-rc.1", "v2.0.0"}
+	runGit("config", "bump.tagPattern", `^v\d+\.\d+\.\d+$`)
+
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.String("tag-pattern", "", "")
+		if err := set.Parse(args); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+		return cli.NewContext(nil, set, nil)
+	}
+	latest := func(c *cli.Context) string {
+		t.Helper()
+		scheme, err := tagScheme(c, io.Discard, repoDir)
+		if err != nil {
+			t.Fatalf("tagScheme() unexpected error = %v", err)
 		}
-	}()
+		return scheme.LatestTagName(names)
+	}
+
+	if got := latest(newContext()); got != "v2.0.0" {
+		t.Errorf("latest with tagPattern setting = %s, expected v2.0.0", got)
+	}
+	if got := latest(newContext("--tag-pattern", "^v1\\.")); got != "v1.3.0-rc.1" {
+		t.Errorf("latest with --tag-pattern = %s, expected v1.3.0-rc.1", got)
+	}
+	if _, err := tagScheme(newContext("--tag-pattern", "("), io.Discard, repoDir); err == nil {
+		t.Error("tagScheme() should reject an invalid pattern")
+	}
+}
+
+// TestTagSchemeChannelOrder tests ranking pre-release channels from the channelOrder setting
+func TestTagSchemeChannelOrder(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	ctx := cli.NewContext(nil, flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	names := []string{"v2.0.0-dev.1", "v2.0.0-alpha.1"}
+
+	scheme, err := tagScheme(ctx, io.Discard, repoDir)
+	if err != nil {
+		t.Fatalf("tagScheme() unexpected error = %v", err)
+	}
+	if got := scheme.LatestTagName(names); got != "v2.0.0-dev.1" {
+		t.Errorf("latest without channelOrder = %s, expected lexical v2.0.0-dev.1", got)
+	}
+
+	runGit("config", "bump.channelOrder", "dev, alpha, beta, rc")
+	if scheme, err = tagScheme(ctx, io.Discard, repoDir); err != nil {
+		t.Fatalf("tagScheme() unexpected error = %v", err)
+	}
+	if got := scheme.LatestTagName(names); got != "v2.0.0-alpha.1" {
+		t.Errorf("latest with channelOrder = %s, expected v2.0.0-alpha.1", got)
+	}
+
+	runGit("config", "bump.channelOrder", "dev,,rc")
+	if _, err := tagScheme(ctx, io.Discard, repoDir); err == nil {
+		t.Error("tagScheme() should reject an empty channel name")
+	}
+}
+
+// TestTagSchemeSuffixSeparator tests parsing tags with the suffixSeparator setting
+func TestTagSchemeSuffixSeparator(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	ctx := cli.NewContext(nil, flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	names := []string{"v1.2.0_rc.1", "v1.1.0"}
+
+	var out bytes.Buffer
+	scheme, err := tagScheme(ctx, &out, repoDir)
+	if err != nil {
+		t.Fatalf("tagScheme() unexpected error = %v", err)
+	}
+	if got := scheme.LatestTagName(names); got != "v1.1.0" {
+		t.Errorf("latest without suffixSeparator = %s, expected v1.1.0", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("tagScheme() output = %q, expected no warning", out.String())
+	}
 
-	if err := os.Chdir(tempDir); err != nil {
-		t.Fatalf("failed to change directory: %v", err)
+	runGit("config", "bump.suffixSeparator", "_")
+	if scheme, err = tagScheme(ctx, &out, repoDir); err != nil {
+		t.Fatalf("tagScheme() unexpected error = %v", err)
+	}
+	if got := scheme.LatestTagName(names); got != "v1.2.0_rc.1" {
+		t.Errorf("latest with suffixSeparator _ = %s, expected v1.2.0_rc.1", got)
+	}
+	if !strings.Contains(out.String(), "not SemVer-compliant") {
+		t.Errorf("tagScheme() output = %q, expected a warning", out.String())
 	}
 
-	err = bumpVersion("patch", "", "", false, false)
-	if err == nil {
-		t.Error("bumpVersion should error when not in a git repository")
+	runGit("config", "bump.suffixSeparator", "+")
+	if _, err := tagScheme(ctx, &out, repoDir); err == nil {
+		t.Error("tagScheme() should reject an invalid separator")
+	}
+	runGit("config", "--unset", "bump.suffixSeparator")
+	if err := os.WriteFile(filepath.Join(repoDir, ".bumprc"), []byte(`{"suffix": `), 0o644); err != nil {
+		t.Fatalf("write .bumprc: %v", err)
+	}
+	if _, err := tagScheme(ctx, &out, repoDir); err == nil {
+		t.Error("tagScheme() should fail when the settings cannot be read")
 	}
 }
 
@@ -257,7 +688,7 @@ func TestCreateCommandStructure(t *testing.T) {
 	}
 
 	// Check flags exist
-	flagNames := []string{"suffix", "update-file", "push", "dry-run"}
+	flagNames := []string{"suffix", "update-file", "push", "dry-run", "timings"}
 	for _, flagName := range flagNames {
 		found := false
 		for _, flag := range cmd.Flags {
@@ -275,3 +706,97 @@ func TestCreateCommandStructure(t *testing.T) {
 		t.Error("expected Action to be set")
 	}
 }
+
+// TestPrereleaseChannel tests selecting a pre-release channel from the shorthand flags
+func TestPrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectError bool
+	}{
+		{name: "No channel", args: nil, expected: ""},
+		{name: "Alpha", args: []string{"--alpha"}, expected: "alpha"},
+		{name: "Release candidate", args: []string{"--rc"}, expected: "rc"},
+		{name: "Two channels", args: []string{"--alpha", "--beta"}, expectError: true},
+		{name: "Channel with suffix", args: []string{"--beta", "--suffix", "pre"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.String("suffix", "", "")
+			for _, name := range prereleaseChannels {
+				set.Bool(name, false, "")
+			}
+			if err := set.Parse(tt.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			channel, err := prereleaseChannel(cli.NewContext(nil, set, nil))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("prereleaseChannel() error = %v, expectError %v", err, tt.expectError)
+			}
+			if channel != tt.expected {
+				t.Errorf("prereleaseChannel() = %q, expected %q", channel, tt.expected)
+			}
+		})
+	}
+}
+
+// TestExitCode tests mapping errors to process exit codes
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Success", err: nil, expected: exitOK},
+		{name: "No-op", err: ErrNoOp, expected: exitNoOp},
+		{name: "Wrapped no-op", err: fmt.Errorf("%w: %w", ErrNoOp, ErrNoChanges), expected: exitNoOp},
+		{name: "No changes without strict", err: ErrNoChanges, expected: exitError},
+		{name: "Failure", err: errors.New("boom"), expected: exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := exitCode(tt.err); code != tt.expected {
+				t.Errorf("exitCode(%v) = %d, expected %d", tt.err, code, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConfigureLogColor tests that NO_COLOR and --no-color strip ANSI escapes from log messages
+func TestConfigureLogColor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(&buf)
+	logger.SetColorProfile(termenv.TrueColor)
+	logger.Error("colored")
+	if !strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("expected escape codes with a TrueColor profile, got %q", buf.String())
+	}
+
+	for _, tt := range []struct {
+		name    string
+		noColor string
+		flag    bool
+	}{
+		{name: "NO_COLOR set", noColor: "1"},
+		{name: "--no-color", flag: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			logger.SetColorProfile(termenv.TrueColor)
+			configureLogColor(logger, os.Stderr, tt.flag)
+			buf.Reset()
+			logger.Error("plain", "key", "value")
+			if strings.Contains(buf.String(), "\x1b[") {
+				t.Errorf("log output contains escape codes: %q", buf.String())
+			}
+		})
+	}
+
+	if profile := logColorProfile(&buf, false); profile != termenv.Ascii {
+		t.Errorf("logColorProfile() for a non-terminal writer = %v, expected Ascii", profile)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// packageJSONName is the npm manifest handled by --update-file instead of a Go file.
+	packageJSONName = "package.json"
+
+	// packageLockName is the npm lockfile kept in sync with package.json when present.
+	packageLockName = "package-lock.json"
+)
+
+// isPackageJSON reports whether filePath names an npm package.json manifest.
+func isPackageJSON(filePath string) bool {
+	return filepath.Base(filePath) == packageJSONName
+}
+
+// readPackageJSONVersion returns the top-level "version" of a package.json document.
+func readPackageJSONVersion(content []byte) (string, error) {
+	var manifest struct {
+		Version *string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	if manifest.Version == nil {
+		return "", fmt.Errorf("no version found in %s", packageJSONName)
+	}
+	return *manifest.Version, nil
+}
+
+// updatePackageJSONVersion sets the top-level "version" of a package.json document.
+// Only the bytes of the value change, so key order, indentation, and the trailing
+// newline are preserved exactly.
+func updatePackageJSONVersion(content []byte, version string) ([]byte, error) {
+	return replaceJSONString(content, []string{"version"}, version)
+}
+
+// updatePackageLockVersion sets the version of the root package in a
+// package-lock.json document: the top-level "version" and, in lockfile v2 and
+// later, packages[""].version. Formatting is preserved as in updatePackageJSONVersion.
+func updatePackageLockVersion(content []byte, version string) ([]byte, error) {
+	content, err := replaceJSONString(content, []string{"version"}, version)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := replaceJSONString(content, []string{"packages", "", "version"}, version)
+	if err != nil {
+		if errors.Is(err, errJSONKeyNotFound) {
+			return content, nil // lockfile v1 has no packages map
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// errJSONKeyNotFound reports a key path missing from a JSON document.
+var errJSONKeyNotFound = errors.New("key not found")
+
+// replaceJSONString replaces the string value at the object key path in content
+// with value, leaving every other byte untouched.
+func replaceJSONString(content []byte, path []string, value string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	start, end, err := findJSONString(dec, content, path)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %q: %w", value, err)
+	}
+	var out bytes.Buffer
+	out.Write(content[:start])
+	out.Write(encoded)
+	out.Write(content[end:])
+	return out.Bytes(), nil
+}
+
+// findJSONString returns the byte span, including quotes, of the string value at
+// the object key path, reading the object that starts at the decoder's position.
+func findJSONString(dec *json.Decoder, content []byte, path []string) (int, int, error) {
+	keyPath := strings.Join(path, ".")
+	if tok, err := dec.Token(); err != nil {
+		return 0, 0, fmt.Errorf("invalid JSON: %w", err)
+	} else if tok != json.Delim('{') {
+		return 0, 0, fmt.Errorf("invalid JSON: expected an object containing %s", keyPath)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if key, _ := tok.(string); key != path[0] {
+			if err := skipJSONValue(dec); err != nil {
+				return 0, 0, err
+			}
+			continue
+		}
+
+		if len(path) > 1 {
+			return findJSONString(dec, content, path[1:])
+		}
+		// The value starts at the first quote after the key's colon
+		afterKey := int(dec.InputOffset())
+		tok, err = dec.Token()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if _, ok := tok.(string); !ok {
+			return 0, 0, fmt.Errorf("%s is not a string", keyPath)
+		}
+		end := int(dec.InputOffset())
+		colon := bytes.IndexByte(content[afterKey:end], ':')
+		start := bytes.IndexByte(content[afterKey+colon:end], '"')
+		return afterKey + colon + start, end, nil
+	}
+	return 0, 0, fmt.Errorf("%w: %s", errJSONKeyNotFound, keyPath)
+}
+
+// skipJSONValue consumes the next value from the decoder, including any nested
+// objects or arrays.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return fmt.Errorf("invalid JSON: unexpected end of input")
+		}
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// writePackageJSONVersion sets the version in the package.json at absPath and in
+// the package-lock.json beside it, if there is one. It returns the paths written.
+func writePackageJSONVersion(absPath, version string) ([]string, error) {
+	written := []string{absPath}
+	if err := rewriteFile(absPath, func(content []byte) ([]byte, error) {
+		return updatePackageJSONVersion(content, version)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", packageJSONName, err)
+	}
+
+	lockPath := filepath.Join(filepath.Dir(absPath), packageLockName)
+	if _, err := os.Stat(lockPath); err != nil {
+		if os.IsNotExist(err) {
+			return written, nil
+		}
+		return nil, err
+	}
+	if err := rewriteFile(lockPath, func(content []byte) ([]byte, error) {
+		return updatePackageLockVersion(content, version)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", packageLockName, err)
+	}
+	return append(written, lockPath), nil
+}
+
+// rewriteFile replaces the content of the file at path with update's result,
+// keeping its permissions.
+func rewriteFile(path string, update func([]byte) ([]byte, error)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, err := update(content)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUpdatePackageJSONVersion tests that only the top-level version changes
+func TestUpdatePackageJSONVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "two space indent",
+			content:  "{\n  \"name\": \"widgets\",\n  \"version\": \"1.0.0\",\n  \"private\": true\n}\n",
+			expected: "{\n  \"name\": \"widgets\",\n  \"version\": \"1.0.1-dev\",\n  \"private\": true\n}\n",
+		},
+		{
+			name:     "four space indent without trailing newline",
+			content:  "{\n    \"version\": \"1.0.0\",\n    \"name\": \"widgets\"\n}",
+			expected: "{\n    \"version\": \"1.0.1-dev\",\n    \"name\": \"widgets\"\n}",
+		},
+		{
+			name:     "nested versions left alone",
+			content:  "{\n\t\"engines\": {\"version\": \"18\"},\n\t\"files\": [{\"version\": \"x\"}],\n\t\"version\" : \"1.0.0\"\n}\n",
+			expected: "{\n\t\"engines\": {\"version\": \"18\"},\n\t\"files\": [{\"version\": \"x\"}],\n\t\"version\" : \"1.0.1-dev\"\n}\n",
+		},
+		{
+			name:    "missing version",
+			content: `{"name": "widgets"}`,
+			errMsg:  "key not found: version",
+		},
+		{
+			name:    "version not a string",
+			content: `{"version": 1}`,
+			errMsg:  "version is not a string",
+		},
+		{
+			name:    "not an object",
+			content: `["version"]`,
+			errMsg:  "expected an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updatePackageJSONVersion([]byte(tt.content), "1.0.1-dev")
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("updatePackageJSONVersion() error = %v, expected %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("updatePackageJSONVersion() unexpected error = %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("updatePackageJSONVersion() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+			if version, err := readPackageJSONVersion(got); err != nil || version != "1.0.1-dev" {
+				t.Errorf("readPackageJSONVersion() = %q, %v", version, err)
+			}
+		})
+	}
+}
+
+// TestUpdatePackageLockVersion tests updating the root package in both lockfile layouts
+func TestUpdatePackageLockVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "lockfile v1",
+			content:  "{\n  \"name\": \"widgets\",\n  \"version\": \"1.0.0\",\n  \"lockfileVersion\": 1\n}\n",
+			expected: "{\n  \"name\": \"widgets\",\n  \"version\": \"1.0.1-dev\",\n  \"lockfileVersion\": 1\n}\n",
+		},
+		{
+			name: "lockfile v3",
+			content: "{\n  \"version\": \"1.0.0\",\n  \"packages\": {\n    \"node_modules/a\": {\"version\": \"2.0.0\"},\n" +
+				"    \"\": {\"name\": \"widgets\", \"version\": \"1.0.0\"}\n  }\n}\n",
+			expected: "{\n  \"version\": \"1.0.1-dev\",\n  \"packages\": {\n    \"node_modules/a\": {\"version\": \"2.0.0\"},\n" +
+				"    \"\": {\"name\": \"widgets\", \"version\": \"1.0.1-dev\"}\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updatePackageLockVersion([]byte(tt.content), "1.0.1-dev")
+			if err != nil {
+				t.Fatalf("updatePackageLockVersion() unexpected error = %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("updatePackageLockVersion() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
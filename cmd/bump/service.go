@@ -1,23 +1,46 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/log"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/klauern/bump"
 )
 
+// ErrNoChanges is returned by Bump when there are no commits since the base tag,
+// so the new tag would point at an already released commit.
+var ErrNoChanges = errors.New("no commits since latest tag")
+
+// ErrHeadNotTagged is returned by ListHeadTags when no version tag points at HEAD,
+// so the current commit has not been released.
+var ErrHeadNotTagged = errors.New("no version tag points at HEAD")
+
+// ErrNoRemote is returned when a remote is needed but the repository has none,
+// as in a freshly initialized repository.
+var ErrNoRemote = errors.New("no git remote configured")
+
 // BumpService coordinates version bumping operations using dependency injection.
 // This service layer separates business logic from I/O, making it fully testable.
 type BumpService struct {
-	repo    GitRepository
-	updater *VersionFileUpdater
-	output  io.Writer
+	repo     GitRepository
+	updater  *VersionFileUpdater
+	output   io.Writer
+	openRepo RepositoryOpener
+	github   *GitHubClient
+	webhook  *WebhookClient
+	now      func() time.Time
 }
 
 // NewBumpService creates a new BumpService with the given dependencies.
@@ -30,55 +53,462 @@ func NewBumpService(repo GitRepository, updater *VersionFileUpdater, output io.W
 	}
 
 	return &BumpService{
-		repo:    repo,
-		updater: updater,
-		output:  output,
+		repo:     repo,
+		updater:  updater,
+		output:   output,
+		openRepo: openGoGitRepository,
+		github:   NewGitHubClient(os.Getenv(githubTokenEnv), nil),
+		webhook:  NewWebhookClient(nil),
+		now:      time.Now,
 	}
 }
 
+// ConfirmFunc asks the user a yes/no question and reports whether they answered yes.
+type ConfirmFunc func(question string) (bool, error)
+
 // BumpOptions contains all options for a version bump operation.
 type BumpOptions struct {
-	BumpType   string // "patch", "minor", or "major"
-	Suffix     string // Optional pre-release suffix (e.g., "beta", "rc1")
-	UpdateFile string // Optional path to file containing Version constant
-	Push       bool   // Whether to push tags to remote
-	DryRun     bool   // Preview changes without making them
+	BumpType            string       // "patch", "minor", "major", or "prerelease"
+	Suffix              string       // Optional pre-release suffix (e.g., "beta", "rc1")
+	UpdateFile          string       // Optional path to file containing Version constant
+	Push                bool         // Whether to push tags to remote
+	DryRun              bool         // Preview changes without making them
+	Timings             bool         // Print the duration of each phase after the operation
+	TagMessageFile      string       // Optional path to a file whose contents become the tag annotation
+	Recursive           bool         // Apply the same bump to every version-tagged submodule
+	PreserveSuffix      bool         // Keep the latest tag's suffix when Suffix is empty
+	PrintChangelog      bool         // Print the commits included in the new tag after creating it
+	IncludeMerges       bool         // List merge commits in the changelog; they are left out by default
+	RepoURL             string       // Web URL of the repository used to link PR references and hashes in the changelog
+	AllowEmpty          bool         // Create the tag even when there are no commits since the base tag
+	Since               string       // Tag to count new commits from; defaults to the latest tag
+	Signoff             bool         // Append a Signed-off-by trailer from the git user identity
+	Trailers            []string     // Additional "key=value" trailers for the tag annotation
+	GitHubRelease       bool         // Create a GitHub release for the tag after pushing it
+	Channel             string       // Pre-release channel ("alpha", "beta", or "rc") for the new tag
+	IncrementPrerelease bool         // Advance the latest pre-release instead of bumping the core version
+	SkipPrereleasePush  bool         // Do not push when the new tag is a pre-release, even if Push is set
+	BaseFromFile        string       // Optional VERSION file to read the base version from and write the new version to
+	Remote              string       // Remote to push to; resolved from the configured remotes when empty
+	Idempotent          bool         // Succeed without changes when HEAD already carries the latest tag
+	OnlyNew             bool         // Push only the newly created tag instead of all local tags
+	Strict              bool         // Refuse to bump from a detached or already tagged HEAD instead of warning
+	RequireCleanIndex   bool         // Refuse to bump when changes are staged but not committed
+	RequireCleanTree    bool         // Refuse to bump when any tracked file is staged or modified
+	Amend               bool         // Amend the UpdateBeforeTag change into HEAD instead of committing it separately
+	UpdateBeforeTag     bool         // Commit the released version to UpdateFile before tagging so the tag includes it
+	FileVersion         string       // Version UpdateFile gets: "dev" (the default) after tagging, or "release" before tagging like UpdateBeforeTag
+	CheckRemote         bool         // Warn when the remote has a newer version tag than the local repository
+	Sign                bool         // Create a signed tag using the configured gpg.format
+	Lightweight         bool         // Create a lightweight tag instead of an annotated one
+	SuffixLimits        SuffixLimits // Maximum length and identifier count of the suffix; zero fields are unlimited
+	Webhook             string       // URL to POST a JSON notification to after a successful bump
+	WebhookRequired     bool         // Fail the bump when the webhook notification fails instead of warning
+	AuditLog            string       // File to append a JSON line describing the bump to; relative paths are resolved against the repo root
+	IncrementBuild      bool         // Advance the build metadata counter (+build.42 -> +build.43) instead of bumping
+	TagName             string       // Exact name to tag, bypassing version calculation
+	SingleWriter        bool         // Succeed as a no-op when another bump holds the repository lock
+	DirtySuffix         string       // Marker appended to the UpdateFile dev version when the worktree is dirty (1.2.4-dev.dirty)
+	Constants           []string     // Further UpdateFile constants to set, as "Name=release" or "Name=dev"
+	Explain             bool         // Print the inputs that determined the next version
+	ShowCommands        bool         // Dry-run: print the git commands that would create and push the tag
+	NoPrereleaseBase    bool         // Refuse to bump the version of a pre-release base tag unless the base is given explicitly
+	AlsoTag             []string     // Moving aliases (e.g. "v1", "latest") created at the same commit, replacing existing ones
+	ReleaseBranch       bool         // Create and check out release/<tag> before committing and tagging
+	ReleaseCommit       bool         // Tag a new empty "Release <tag>" commit instead of the current HEAD
+	CommitMessage       string       // Template for the ReleaseCommit message; defaults to defaultReleaseCommitMessage
+	Confirm             ConfirmFunc  // Asks the user to confirm actions that switch branches, such as ReleaseBranch; nil declines
+	FailOnDowngrade     bool         // Refuse a next tag that is not strictly newer than the latest tag
+	SuffixPolicy        string       // The suffixPolicy setting: bump types whose tags must not be pre-releases
 }
 
 // BumpResult contains the result of a bump operation.
 type BumpResult struct {
-	NextTag      string // The tag that was (or would be) created
-	Pushed       bool   // Whether the tag was pushed to remote
-	FileUpdated  bool   // Whether a file was updated
-	WouldPush    bool   // Dry-run: whether tag would be pushed
-	WouldUpdate  bool   // Dry-run: whether file would be updated
-	PreviousTag  string // The previous latest tag (empty if none)
+	NextTag     string            `json:"nextTag"`              // The tag that was (or would be) created
+	Pushed      bool              `json:"pushed"`               // Whether the tag was pushed to remote
+	FileUpdated bool              `json:"fileUpdated"`          // Whether a file was updated
+	WouldPush   bool              `json:"wouldPush"`            // Dry-run: whether tag would be pushed
+	WouldUpdate bool              `json:"wouldUpdate"`          // Dry-run: whether file would be updated
+	PreviousTag string            `json:"previousTag"`          // The previous latest tag (empty if none)
+	NoOp        bool              `json:"noOp"`                 // Idempotent mode: the tag already existed at HEAD, nothing was created
+	Timings     BumpTimings       `json:"timings"`              // Duration of each phase of the operation
+	Submodules  []SubmoduleResult `json:"submodules,omitempty"` // Results for submodules bumped in recursive mode
+	ReleaseURL  string            `json:"releaseURL,omitempty"` // URL of the GitHub release, if one was created
+	TagScan     TagScanCounts     `json:"tagScan"`              // How many tags were examined to find the previous tag
+}
+
+// TagScanCounts reports how many tag references were examined when looking for the
+// latest version, so tags that are not recognized (e.g. a prefix mismatch) show up.
+type TagScanCounts struct {
+	Scanned  int `json:"scanned"`  // References examined, including peeled duplicates
+	Versions int `json:"versions"` // Distinct tags recognized as release versions
+	Skipped  int `json:"skipped"`  // Distinct tags ignored as not semantic versions or not matching the tag pattern
+}
+
+// newTagScanCounts summarizes a tag scan for JSON output.
+func newTagScanCounts(scan bump.TagScan) TagScanCounts {
+	return TagScanCounts{Scanned: scan.Scanned, Versions: scan.Versions, Skipped: len(scan.Skipped)}
+}
+
+// SubmoduleResult pairs a submodule path with the outcome of bumping it.
+type SubmoduleResult struct {
+	Path   string      `json:"path"`   // Path of the submodule relative to the superproject
+	Result *BumpResult `json:"result"` // Result of the bump within the submodule
+}
+
+// BumpTimings records how long each phase of a bump operation took.
+// Phases that did not run are left at zero.
+type BumpTimings struct {
+	TagEnumeration time.Duration `json:"tagEnumeration"` // Listing tags from the repository
+	LatestTag      time.Duration `json:"latestTag"`      // Determining the latest semantic version tag
+	TagCreation    time.Duration `json:"tagCreation"`    // Creating the new tag
+	Push           time.Duration `json:"push"`           // Pushing tags to the remote
+	FileUpdate     time.Duration `json:"fileUpdate"`     // Updating and committing the version file
 }
 
 // Bump performs a version bump operation.
 // This is the main entry point for the service layer.
 func (s *BumpService) Bump(opts BumpOptions) (*BumpResult, error) {
+	var timings BumpTimings
+
 	// Get all tags from the repository
+	start := time.Now()
 	tagRefs, err := s.repo.Tags()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch tags: %w", err)
 	}
 	defer tagRefs.Close()
 
-	// Find the latest tag
-	latestTag, err := bump.GetLatestTag(tagRefs)
-	if err != nil {
+	// The iterator is lazy, so the references are only read by ForEach. Each tag is
+	// classified as it is read, timed separately from reading the references.
+	// Snapshots from bump dev are tracked apart so a core bump can skip them.
+	scheme := s.repo.TagScheme()
+	scanner := scheme.NewTagScanner(isDevTagIn(scheme))
+	var classify time.Duration
+	if err := tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		classifyStart := time.Now()
+		scanner.Add(ref.Name().Short())
+		classify += time.Since(classifyStart)
+		return nil
+	}); err != nil {
 		return nil, fmt.Errorf("failed to determine latest tag: %w", err)
 	}
+	scan := scanner.Done()
+	timings.TagEnumeration = time.Since(start) - classify
+	timings.LatestTag = classify
 
-	// Calculate the next version (pure function)
-	nextTag, err := calculateNextVersion(latestTag, opts.BumpType, opts.Suffix)
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine next tag: %w", err)
+	// Snapshots from bump dev sit between releases, so a core bump starts from the
+	// release before them; advancing a pre-release series still sees every tag
+	latestTag := scanner.Latest()
+	if opts.BumpType != "prerelease" && !opts.IncrementPrerelease && opts.Channel == "" {
+		latestTag = scanner.LatestIncluded()
+	}
+	tagScan := newTagScanCounts(scan)
+
+	// In idempotent mode, a re-run on a HEAD that already carries the latest tag is a no-op
+	if opts.Idempotent && latestTag != "" {
+		atHead, err := s.tagAtHead(latestTag)
+		if err != nil {
+			return nil, err
+		}
+		if atHead {
+			if _, err := fmt.Fprintf(s.output, "Tag %s already exists at HEAD — nothing to do\n", latestTag); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+			if err := s.printTimings(opts, timings); err != nil {
+				return nil, err
+			}
+			return &BumpResult{NextTag: latestTag, PreviousTag: latestTag, NoOp: true, Timings: timings, TagScan: tagScan}, nil
+		}
+	}
+
+	// Tagging a detached HEAD is fine, but version file commits would not land on a branch
+	if err := s.checkDetachedHead(opts); err != nil {
+		return nil, err
+	}
+
+	// Uncommitted changes would not be part of the tagged release
+	if opts.RequireCleanIndex || opts.RequireCleanTree {
+		if err := s.checkClean(opts.RequireCleanTree); err != nil {
+			return nil, err
+		}
+	}
+
+	// Warn before computing the next version from a stale local base
+	if opts.CheckRemote {
+		if err := s.checkRemoteFreshness(opts.Remote, latestTag); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read the base version from a VERSION file instead of the latest tag
+	baseTag := latestTag
+	if opts.BaseFromFile != "" {
+		if baseTag, err = s.readBaseVersion(opts.BaseFromFile); err != nil {
+			return nil, fmt.Errorf("failed to read base version: %w", err)
+		}
+	}
+
+	// Expand suffix templates and reject suffixes that are not valid SemVer
+	suffix := opts.Suffix
+	if suffix != "" {
+		if suffix, err = s.expandSuffix(suffix); err != nil {
+			return nil, err
+		}
+		if err := validateSuffix(suffix, opts.SuffixLimits); err != nil {
+			return nil, err
+		}
+	}
+	if opts.DirtySuffix != "" {
+		if err := validateSuffix(opts.DirtySuffix, SuffixLimits{}); err != nil {
+			return nil, fmt.Errorf("invalid --dirty-suffix: %w", err)
+		}
+	}
+
+	// Bumping off a release candidate is usually an accident under this policy
+	if opts.NoPrereleaseBase && opts.TagName == "" && opts.BaseFromFile == "" {
+		if err := checkPrereleaseBase(scheme, baseTag, opts.BumpType, opts.IncrementPrerelease || opts.IncrementBuild); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate the next version (pure function), unless the name is given outright
+	var nextTag string
+	if opts.TagName != "" {
+		if err := checkTagNameOverride(scheme, opts.TagName, opts.UpdateFile != ""); err != nil {
+			return nil, err
+		}
+		nextTag = opts.TagName
+	} else {
+		nextTag, err = calculateNextVersion(scheme, baseTag, opts.BumpType, bump.NextTagOptions{
+			Suffix:              suffix,
+			PreserveSuffix:      opts.PreserveSuffix,
+			Channel:             opts.Channel,
+			IncrementPrerelease: opts.IncrementPrerelease,
+			IncrementBuild:      opts.IncrementBuild,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine next tag: %w", err)
+		}
+	}
+
+	// The policy applies to the tag itself, whether its suffix came from --suffix, a
+	// channel, --increment-prerelease, or a pre-release base
+	if opts.SuffixPolicy != "" {
+		if err := checkSuffixPolicy(opts.SuffixPolicy, opts.BumpType, prereleaseSuffix(scheme, nextTag)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Whatever chose the next tag (a bump, --tag-name, or --base-from-file), it must
+	// not go backwards from the latest release
+	if opts.FailOnDowngrade {
+		if err := checkNotDowngrade(scheme, latestTag, nextTag); err != nil {
+			return nil, err
+		}
+	}
+
+	// Describe how the next version was reached
+	if opts.Explain {
+		explanation := Explanation{
+			LatestTag: latestTag,
+			BumpType:  opts.BumpType,
+			Suffix:    suffix,
+			TagName:   opts.TagName,
+			Result:    nextTag,
+		}
+		explanation.TagCount, explanation.Skipped = explainTags(scan)
+		if opts.BaseFromFile != "" {
+			explanation.BaseTag, explanation.BaseFile = baseTag, opts.BaseFromFile
+		}
+		switch {
+		case explanation.Suffix == "" && opts.Channel != "":
+			explanation.Suffix = opts.Channel + " channel"
+		case explanation.Suffix == "" && opts.PreserveSuffix:
+			explanation.Suffix = "preserved from the base version"
+		}
+		if opts.IncrementPrerelease {
+			explanation.BumpType += " (increment pre-release)"
+		} else if opts.IncrementBuild {
+			explanation.BumpType += " (increment build metadata)"
+		}
+		if _, err := fmt.Fprint(s.output, formatExplanation(explanation)); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	// Keep pre-releases local when the policy applies
+	push := opts.Push
+	if push && opts.SkipPrereleasePush && isPrerelease(scheme, nextTag) {
+		push = false
+		if _, err := fmt.Fprintf(s.output, "Not pushing pre-release %s (pass --push to push it)\n", nextTag); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	// Everything from here to the dry-run return only resolves and validates the
+	// options, so an ambiguous remote, a typo, or a bad spec fails before any
+	// commit, branch, or tag is made.
+
+	// Resolve the remote to push to
+	var remote string
+	if push {
+		if remote, err = s.resolvePushRemote(opts.Remote); err != nil {
+			return nil, err
+		}
+	}
+
+	// Resolve the GitHub repository for the release
+	var owner, repoName string
+	if opts.GitHubRelease {
+		if !push {
+			return nil, fmt.Errorf("--github-release requires the tag to be pushed (use --push)")
+		}
+		owner, repoName, err = s.githubRepository(remote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Refuse to create a release with no new commits, unless it promotes a
+	// pre-release, which usually tags the very commit the pre-release was cut from
+	sinceTag := latestTag
+	if opts.Since != "" {
+		sinceTag = opts.Since
+	}
+	allowEmpty := opts.AllowEmpty || isPromotion(scheme, baseTag, nextTag)
+	var commits []CommitInfo
+	if opts.PrintChangelog || opts.GitHubRelease || (!allowEmpty && sinceTag != "") {
+		commits, err = s.repo.CommitsSince(sinceTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commits since %s: %w", sinceTag, err)
+		}
+		if sinceTag != "" && len(commits) == 0 && !allowEmpty {
+			return nil, fmt.Errorf("%w %s (use --allow-empty to tag anyway)", ErrNoChanges, sinceTag)
+		}
+	}
+
+	// Merge commits only repeat the subjects of the commits they bring in
+	changelogCommits := commits
+	if !opts.IncludeMerges {
+		changelogCommits = withoutMerges(commits)
+	}
+
+	// A second version tag on an already tagged commit is usually a mistake,
+	// unless the tag goes on a new release commit
+	if !opts.ReleaseCommit {
+		if err := s.checkHeadTagged(opts, nextTag); err != nil {
+			return nil, err
+		}
+	}
+
+	// Render the release commit message and make sure no uncommitted changes
+	// would slip into the otherwise empty commit
+	var releaseMessage string
+	if opts.ReleaseCommit {
+		if releaseMessage, err = renderReleaseCommitMessage(scheme, opts.CommitMessage, nextTag); err != nil {
+			return nil, err
+		}
+		if err := s.checkReleaseCommitClean(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Lightweight tags have no tag object to hold an annotation or signature
+	if opts.Lightweight && (opts.Sign || opts.TagMessageFile != "" || opts.Signoff || len(opts.Trailers) > 0) {
+		return nil, fmt.Errorf("lightweight tags cannot be signed or annotated; pass --annotated to create an annotated tag")
+	}
+
+	// Cutting a release branch switches the checked-out branch, so it must be confirmed
+	releaseBranch := ""
+	if opts.ReleaseBranch {
+		releaseBranch = releaseBranchName(nextTag)
+		if !opts.DryRun {
+			confirmed := false
+			if opts.Confirm != nil {
+				var err error
+				if confirmed, err = opts.Confirm(fmt.Sprintf("Create and switch to %s?", releaseBranch)); err != nil {
+					return nil, err
+				}
+			}
+			if !confirmed {
+				return nil, fmt.Errorf("--release-branch creates and switches to %s; confirm the prompt or pass the global --yes to proceed", releaseBranch)
+			}
+		}
+	}
+
+	// Validate the file version
+	switch opts.FileVersion {
+	case "", fileVersionDev, fileVersionRelease:
+	default:
+		return nil, fmt.Errorf("invalid --file-version %q: expected %s or %s", opts.FileVersion, fileVersionRelease, fileVersionDev)
+	}
+	// A released version written after tagging would be left out of the tag, so it
+	// is committed first, exactly as with --update-before-tag
+	if opts.UpdateFile != "" && opts.FileVersion == fileVersionRelease {
+		opts.UpdateBeforeTag = true
+	}
+
+	// Amending HEAD after tagging would leave the tag on the replaced commit
+	if opts.Amend && !(opts.UpdateFile != "" && opts.UpdateBeforeTag) {
+		return nil, fmt.Errorf("--amend rewrites HEAD, which would leave the tag on the replaced commit; use it with --update-file and --update-before-tag")
+	}
+
+	// Validate the extra constants
+	if len(opts.Constants) > 0 {
+		if opts.UpdateFile == "" {
+			return nil, fmt.Errorf("--constant requires --update-file")
+		}
+		if _, err := constantValues(opts.Constants, "", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read the submodules, which must be inside the work tree
+	var submodules []string
+	if opts.Recursive {
+		if submodules, err = s.submodulePaths(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate the aliases
+	if err := checkTagAliases(nextTag, opts.AlsoTag); err != nil {
+		return nil, err
+	}
+
+	// Check that a signing key is configured
+	tagOpts := bump.TagOptions{Sign: opts.Sign, Lightweight: opts.Lightweight, Aliases: opts.AlsoTag}
+	if opts.Sign {
+		if _, err := s.repo.SigningFormat(); err != nil {
+			return nil, fmt.Errorf("cannot sign tag: %w", err)
+		}
+	}
+
+	// Read the tag annotation
+	if opts.TagMessageFile != "" {
+		message, err := s.readTagMessageFile(opts.TagMessageFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tag message file: %w", err)
+		}
+		tagOpts.Message = message
+	}
+
+	// Append trailers to the annotation, which defaults to the tag name
+	if opts.Signoff || len(opts.Trailers) > 0 {
+		trailers, err := s.buildTrailers(opts)
+		if err != nil {
+			return nil, err
+		}
+		message := tagOpts.Message
+		if message == "" {
+			message = nextTag
+		}
+		tagOpts.Message = composeTagMessage(message, trailers)
 	}
 
 	// Print starting message if no tags exist
-	if latestTag == "" {
+	if baseTag == "" {
 		if opts.DryRun {
 			if _, err := fmt.Fprintln(s.output, "No tags found, would start at v0.1.0"); err != nil {
 				return nil, fmt.Errorf("failed to write output: %w", err)
@@ -92,28 +522,169 @@ func (s *BumpService) Bump(opts BumpOptions) (*BumpResult, error) {
 
 	// Dry-run mode: preview without making changes
 	if opts.DryRun {
-		if _, err := fmt.Fprint(s.output, formatDryRunMessage(nextTag, opts.Push, opts.UpdateFile)); err != nil {
+		dryRunFile := opts.UpdateFile
+		if opts.UpdateBeforeTag {
+			dryRunFile = ""
+		}
+		if _, err := fmt.Fprint(s.output, formatDryRunMessage(scheme, nextTag, push, dryRunFile)); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+		if len(opts.AlsoTag) > 0 {
+			if _, err := fmt.Fprintf(s.output, "Would also tag: %s\n", strings.Join(opts.AlsoTag, ", ")); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if opts.ShowCommands {
+			commands := plannedGitCommands(nextTag, tagOpts, push, remote, opts.OnlyNew)
+			if _, err := fmt.Fprint(s.output, formatGitCommands(commands)); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if releaseBranch != "" {
+			if _, err := fmt.Fprintf(s.output, "Would create and switch to branch %s\n", releaseBranch); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if opts.ReleaseCommit {
+			if _, err := fmt.Fprintf(s.output, "Would create release commit %q\n", releaseMessage); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if opts.UpdateFile != "" && opts.UpdateBeforeTag {
+			if _, err := fmt.Fprintf(s.output, "Would update file %s before tagging: Version -> %s\n", opts.UpdateFile, s.repo.TagScheme().StripPrefix(nextTag)); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if len(opts.Constants) > 0 {
+			if _, err := fmt.Fprintf(s.output, "Would also set %s in %s\n", strings.Join(opts.Constants, ", "), opts.UpdateFile); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if opts.BaseFromFile != "" {
+			if _, err := fmt.Fprintf(s.output, "Would write %s to %s\n", s.repo.TagScheme().StripPrefix(nextTag), opts.BaseFromFile); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if opts.GitHubRelease {
+			if _, err := fmt.Fprintf(s.output, "Would create GitHub release in %s/%s\n", owner, repoName); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if err := s.printTimings(opts, timings); err != nil {
+			return nil, err
+		}
+		result := &BumpResult{
+			NextTag:     nextTag,
+			WouldPush:   push,
+			WouldUpdate: opts.UpdateFile != "" || opts.BaseFromFile != "",
+			PreviousTag: latestTag,
+			Timings:     timings,
+			TagScan:     tagScan,
+		}
+		if opts.Recursive {
+			if result.Submodules, err = s.bumpSubmodules(opts, submodules); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	// In a CI matrix, only the job that wins the lock should release, so a losing
+	// job stops before it commits or branches anything
+	if opts.SingleWriter {
+		if err := s.repo.ProbeLock(); err != nil {
+			if errors.Is(err, bump.ErrLockBusy) {
+				return s.lockBusyResult(&BumpResult{NextTag: nextTag, PreviousTag: latestTag, Timings: timings, TagScan: tagScan})
+			}
+			return nil, err
+		}
+	}
+
+	// Cut the release branch so the version commits and the tag land on it. Until
+	// the tag exists, a failure switches back and drops the branch, so a retry
+	// starts from where this run did.
+	tagCreated := false
+	if releaseBranch != "" {
+		previous, err := s.checkedOut()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.CreateBranch(releaseBranch); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if !tagCreated {
+				s.abandonReleaseBranch(releaseBranch, previous)
+			}
+		}()
+		if _, err := fmt.Fprintf(s.output, "Switched to new branch %s\n", releaseBranch); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	// Commit the new version to the base file first so the tag includes it
+	fileUpdated := false
+	if opts.BaseFromFile != "" {
+		start = time.Now()
+		if err := s.WriteBaseVersion(opts.BaseFromFile, nextTag); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", opts.BaseFromFile, err)
+		}
+		timings.FileUpdate = time.Since(start)
+		fileUpdated = true
+	}
+	if opts.UpdateFile != "" && opts.UpdateBeforeTag {
+		start = time.Now()
+		constants, err := s.constantValues(opts, nextTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update file: %w", err)
+		}
+		if err := s.writeVersionFile(opts.UpdateFile, s.repo.TagScheme().StripPrefix(nextTag), constants, opts.Amend); err != nil {
+			return nil, fmt.Errorf("failed to update file: %w", err)
+		}
+		timings.FileUpdate += time.Since(start)
+		fileUpdated = true
+	}
+
+	// Give the release its own commit for the tag to point at
+	if opts.ReleaseCommit {
+		if err := s.createReleaseCommit(releaseMessage); err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Fprintf(s.output, "Created release commit %q\n", releaseMessage); err != nil {
 			return nil, fmt.Errorf("failed to write output: %w", err)
 		}
-		return &BumpResult{
-			NextTag:      nextTag,
-			WouldPush:    opts.Push,
-			WouldUpdate:  opts.UpdateFile != "",
-			PreviousTag:  latestTag,
-		}, nil
 	}
 
 	// Create the tag
-	if err := s.repo.CreateTag(nextTag); err != nil {
+	start = time.Now()
+	if err := s.repo.CreateTag(nextTag, tagOpts); err != nil {
+		// Another job may still win the lock between the probe and the tag
+		if opts.SingleWriter && errors.Is(err, bump.ErrLockBusy) {
+			return s.lockBusyResult(&BumpResult{NextTag: nextTag, PreviousTag: latestTag, FileUpdated: fileUpdated, Timings: timings, TagScan: tagScan})
+		}
 		return nil, fmt.Errorf("failed to create tag: %w", err)
 	}
+	timings.TagCreation = time.Since(start)
+	tagCreated = true
 
-	// Push tags if requested
+	// Push tags if requested. Aliases move every release, so they are force-pushed
+	// first; a plain push would reject them as existing tags.
 	pushed := false
-	if opts.Push {
-		if err := s.repo.PushTags(); err != nil {
+	if push {
+		start = time.Now()
+		for _, alias := range opts.AlsoTag {
+			if err := s.repo.ForcePushTag(remote, alias); err != nil {
+				return nil, fmt.Errorf("failed to push alias %s: %w", alias, err)
+			}
+		}
+		if opts.OnlyNew {
+			if err := s.repo.PushTag(remote, nextTag); err != nil {
+				return nil, fmt.Errorf("failed to push tag: %w", err)
+			}
+		} else if err := s.repo.PushTags(remote); err != nil {
 			return nil, fmt.Errorf("failed to push tags: %w", err)
 		}
+		timings.Push = time.Since(start)
 		pushed = true
 	}
 
@@ -121,86 +692,1438 @@ func (s *BumpService) Bump(opts BumpOptions) (*BumpResult, error) {
 	if _, err := fmt.Fprintln(s.output, formatBumpMessage(nextTag, pushed)); err != nil {
 		return nil, fmt.Errorf("failed to write output: %w", err)
 	}
+	if len(opts.AlsoTag) > 0 {
+		if _, err := fmt.Fprintf(s.output, "Also tagged: %s\n", strings.Join(opts.AlsoTag, ", ")); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	// Create a GitHub release for the pushed tag if requested
+	var releaseURL string
+	if opts.GitHubRelease {
+		releaseURL, err = s.github.CreateRelease(owner, repoName, GitHubRelease{
+			TagName:    nextTag,
+			Name:       nextTag,
+			Body:       formatChangelog(nextTag, sinceTag, changelogCommits, opts.RepoURL),
+			Prerelease: isPrerelease(scheme, nextTag),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub release: %w", err)
+		}
+		if _, err := fmt.Fprintf(s.output, "Created GitHub release: %s\n", releaseURL); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	// Print the changelog for the new tag if requested
+	if opts.PrintChangelog {
+		if _, err := fmt.Fprint(s.output, formatChangelog(nextTag, sinceTag, changelogCommits, opts.RepoURL)); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
 
 	// Update version file if requested
-	fileUpdated := false
-	if opts.UpdateFile != "" {
-		if err := s.UpdateVersionFile(opts.UpdateFile, nextTag); err != nil {
+	if opts.UpdateFile != "" && !opts.UpdateBeforeTag {
+		start = time.Now()
+		version, err := s.devVersion(nextTag, opts.DirtySuffix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update file: %w", err)
+		}
+		constants, err := s.constantValues(opts, nextTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update file: %w", err)
+		}
+		if err := s.writeVersionFile(opts.UpdateFile, version, constants, opts.Amend); err != nil {
 			return nil, fmt.Errorf("failed to update file: %w", err)
 		}
+		timings.FileUpdate += time.Since(start)
 		fileUpdated = true
 	}
 
-	return &BumpResult{
-		NextTag:      nextTag,
-		Pushed:       pushed,
-		FileUpdated:  fileUpdated,
-		PreviousTag:  latestTag,
-	}, nil
-}
-
-// UpdateVersionFile updates a Go source file with a new development version.
-// This method handles path validation, file operations, and git operations.
-func (s *BumpService) UpdateVersionFile(filePath, nextTag string) error {
-	repoPath := s.repo.Path()
+	// Notify the webhook; failures only warn unless the notification is required
+	if opts.Webhook != "" {
+		if err := s.notifyWebhook(opts, nextTag, latestTag); err != nil {
+			return nil, err
+		}
+	}
 
-	// Validate file path to prevent security issues
-	if err := validateFilePath(filePath, repoPath); err != nil {
-		return fmt.Errorf("invalid file path: %w", err)
+	if opts.AuditLog != "" {
+		if err := s.writeAuditLog(opts.AuditLog, latestTag, nextTag, pushed); err != nil {
+			return nil, fmt.Errorf("failed to write audit log: %w", err)
+		}
 	}
 
-	// Clean the path
-	cleanPath := filepath.Clean(filePath)
+	if err := s.printTimings(opts, timings); err != nil {
+		return nil, err
+	}
 
-	// Resolve to absolute path for file operations
-	absPath := filepath.Join(repoPath, cleanPath)
+	result := &BumpResult{
+		NextTag:     nextTag,
+		Pushed:      pushed,
+		FileUpdated: fileUpdated,
+		PreviousTag: latestTag,
+		Timings:     timings,
+		ReleaseURL:  releaseURL,
+		TagScan:     tagScan,
+	}
+	if opts.Recursive {
+		if result.Submodules, err = s.bumpSubmodules(opts, submodules); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
 
-	// Calculate development version (pure function)
-	devVersion, err := calculateDevVersion(nextTag)
+// checkedOut returns the checked-out branch, or the HEAD commit when HEAD is
+// detached, for switching back to it later.
+func (s *BumpService) checkedOut() (string, error) {
+	branch, err := s.repo.CurrentBranch()
 	if err != nil {
-		return fmt.Errorf("failed to calculate dev version: %w", err)
+		return "", fmt.Errorf("failed to read the current branch: %w", err)
 	}
-
-	// Parse, update, and write the file (using absolute path)
-	node, fset, err := s.updater.ParseGoFile(absPath)
+	if branch != "" {
+		return branch, nil
+	}
+	head, err := s.repo.HeadHash()
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to read HEAD: %w", err)
 	}
+	return head, nil
+}
 
-	if err := s.updater.UpdateVersionConstant(node, devVersion); err != nil {
-		return err
+// abandonReleaseBranch switches from the release branch back to previous and
+// deletes it, after a run that did not get as far as the tag. Errors are logged
+// rather than returned, so they do not hide the failure that stopped the run.
+func (s *BumpService) abandonReleaseBranch(branch, previous string) {
+	if err := s.repo.AbandonBranch(branch, previous); err != nil {
+		log.Error("failed to abandon release branch", "branch", branch, "err", err)
+		return
+	}
+	if _, err := fmt.Fprintf(s.output, "Switched back to %s and deleted %s\n", previous, branch); err != nil {
+		log.Error("failed to write output", "err", err)
+	}
+}
+
+// lockBusyResult reports that another bump holds the repository lock and returns
+// result marked as a no-op, for a --single-writer job that lost the lock.
+func (s *BumpService) lockBusyResult(result *BumpResult) (*BumpResult, error) {
+	if _, err := fmt.Fprintln(s.output, "Another bump holds the repository lock — nothing to do"); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
 	}
+	result.NoOp = true
+	return result, nil
+}
 
-	if err := s.updater.WriteFormattedFile(absPath, fset, node); err != nil {
-		return err
+// notifyWebhook posts the created tag to the webhook URL. A failed notification
+// is returned as an error only when opts.WebhookRequired is set.
+func (s *BumpService) notifyWebhook(opts BumpOptions, nextTag, previousTag string) error {
+	err := s.webhook.Notify(opts.Webhook, WebhookPayload{
+		Tag:         nextTag,
+		PreviousTag: previousTag,
+		RepoPath:    s.repo.Path(),
+		Timestamp:   s.now().UTC().Format(time.RFC3339),
+	})
+	if err == nil {
+		return nil
+	}
+	if opts.WebhookRequired {
+		return fmt.Errorf("failed to notify webhook: %w", err)
 	}
+	if _, err := fmt.Fprintf(s.output, "Warning: failed to notify webhook: %v\n", err); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
 
-	// Stage and commit the file
-	worktree, err := s.repo.Worktree()
+// writeAuditLog records the bump from previousTag to nextTag in the audit log at path.
+func (s *BumpService) writeAuditLog(path, previousTag, nextTag string, pushed bool) error {
+	repoPath := s.repo.Path()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	name, email, err := s.repo.UserIdentity()
 	if err != nil {
-		return fmt.Errorf("failed to get working tree: %w", err)
+		return fmt.Errorf("failed to read git identity: %w", err)
 	}
+	who := name
+	if email != "" {
+		who = strings.TrimSpace(fmt.Sprintf("%s <%s>", name, email))
+	}
+	return appendAuditLog(path, AuditEntry{
+		Who:      who,
+		When:     s.now().UTC().Format(time.RFC3339),
+		From:     previousTag,
+		To:       nextTag,
+		Pushed:   pushed,
+		RepoPath: repoPath,
+	})
+}
 
-	// Get relative path for git operations
-	relPath, err := filepath.Rel(repoPath, absPath)
+// RetagFromRemote compares the latest local tag with the tags published on the
+// remote and reports the version tags the remote has beyond it. With fetch, those
+// tags are fetched so the next bump starts from the remote's latest release
+// instead of creating a version that already exists there. It returns the newer
+// remote tags, newest first.
+func (s *BumpService) RetagFromRemote(remote string, fetch bool) ([]string, error) {
+	remote, err := s.resolveRemote(remote)
+	if err != nil {
+		return nil, err
+	}
+	localTag, err := s.latestBaseTag()
+	if err != nil {
+		return nil, err
+	}
+	remoteNames, err := s.repo.RemoteTags(remote)
 	if err != nil {
-		return fmt.Errorf("failed to determine relative path: %w", err)
+		return nil, fmt.Errorf("failed to fetch tags from %s: %w", remote, err)
 	}
 
-	// Stage the file
-	if _, err := worktree.Add(relPath); err != nil {
-		return fmt.Errorf("failed to stage file: %w", err)
+	newer := newerRemoteTags(s.repo.TagScheme(), localTag, remoteNames)
+	if _, err := fmt.Fprint(s.output, formatRemoteGap(remote, localTag, newer)); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	if len(newer) == 0 {
+		return nil, nil
 	}
 
-	// Commit the change
-	commitMsg := fmt.Sprintf("Bump version to %s", devVersion)
-	_, err = worktree.Commit(commitMsg, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "Bump CLI",
-			Email: "bump@localhost",
-			When:  time.Now(),
-		},
-	})
+	if !fetch {
+		if _, err := fmt.Fprintf(s.output, "Pass --fetch (or run 'git fetch --tags %s') so the next bump starts from %s\n", remote, newer[0]); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+		return newer, nil
+	}
+	if err := s.repo.FetchTags(remote); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(s.output, "Fetched tags from %s; the next bump starts from %s\n", remote, newer[0]); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	return newer, nil
+}
+
+// checkRemoteFreshness compares the local latest tag with the remote's and warns,
+// suggesting a fetch, when the remote already has a newer version.
+func (s *BumpService) checkRemoteFreshness(remote, latestTag string) error {
+	remote, err := s.resolveRemote(remote)
+	if err != nil {
+		return err
+	}
+	tags, err := s.repo.RemoteTags(remote)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags from %s: %w", remote, err)
+	}
+	scheme := s.repo.TagScheme()
+	remoteLatest := latestBaseTagName(scheme, tags)
+	if !isBehindRemote(scheme, latestTag, remoteLatest) {
+		return nil
+	}
+	local := latestTag
+	if local == "" {
+		local = "none"
+	}
+	if _, err := fmt.Fprintf(s.output, "Warning: %s has %s but the latest local tag is %s; run 'git fetch --tags %s' to avoid reusing a version\n", remote, remoteLatest, local, remote); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// checkDetachedHead rejects a detached HEAD under strict mode. Otherwise it warns
+// when a version file commit is requested, since that commit will not be on any branch.
+func (s *BumpService) checkDetachedHead(opts BumpOptions) error {
+	detached, err := s.repo.DetachedHead()
+	if err != nil {
+		return fmt.Errorf("failed to inspect HEAD: %w", err)
+	}
+	if !detached {
+		return nil
+	}
+	if opts.Strict {
+		return fmt.Errorf("HEAD is detached; check out a branch or drop --strict")
+	}
+	if opts.UpdateFile != "" || opts.BaseFromFile != "" {
+		if _, err := fmt.Fprintln(s.output, "Warning: HEAD is detached; the version file commit will not be on any branch"); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkClean fails when the index has staged changes or, with wholeTree, when any
+// tracked file differs from HEAD.
+func (s *BumpService) checkClean(wholeTree bool) error {
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	paths := changedPaths(status, !wholeTree)
+	if len(paths) == 0 {
+		return nil
+	}
+	if wholeTree {
+		return fmt.Errorf("working tree has uncommitted changes: %s (commit or stash them, or drop --require-clean-worktree)", strings.Join(paths, ", "))
+	}
+	return fmt.Errorf("index has staged changes: %s (commit or unstage them, or drop --require-clean-index)", strings.Join(paths, ", "))
+}
+
+// commitAuthor returns the author for commits bump creates, from the repository's
+// git identity or the placeholder where it is unset.
+func (s *BumpService) commitAuthor() (*object.Signature, error) {
+	name, email, err := s.repo.UserIdentity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git identity: %w", err)
+	}
+	return commitSignature(name, email, s.now()), nil
+}
+
+// checkReleaseCommitClean refuses to create a release commit while tracked files
+// are staged or modified, since staged changes would be committed with it.
+func (s *BumpService) checkReleaseCommitClean() error {
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	if paths := changedPaths(status, false); len(paths) > 0 {
+		return fmt.Errorf("--release-commit requires a clean working tree, but these files have uncommitted changes: %s", strings.Join(paths, ", "))
+	}
+	return nil
+}
+
+// createReleaseCommit commits the index unchanged, so HEAD gets a dedicated,
+// empty commit that the release tag points at.
+func (s *BumpService) createReleaseCommit(message string) error {
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get working tree: %w", err)
+	}
+	author, err := s.commitAuthor()
+	if err != nil {
+		return err
+	}
+	_, err = worktree.Commit(message, &git.CommitOptions{Author: author, AllowEmptyCommits: true})
+	if err != nil {
+		return fmt.Errorf("failed to create release commit: %w", err)
+	}
+	return nil
+}
+
+// checkHeadTagged warns, or errors under opts.Strict, when a version tag already
+// points at HEAD, since nextTag would then name the same commit as that release.
+// A dev snapshot at HEAD is expected to be followed by a release, so it is ignored.
+func (s *BumpService) checkHeadTagged(opts BumpOptions, nextTag string) error {
+	tagged, err := s.headVersionTags()
+	if err != nil {
+		return err
+	}
+	tagged = slices.DeleteFunc(tagged, isDevTagIn(s.repo.TagScheme()))
+	if len(tagged) == 0 {
+		return nil
+	}
+	existing := strings.Join(tagged, ", ")
+	if opts.Strict {
+		return fmt.Errorf("HEAD is already tagged %s; commit new changes or drop --strict to tag it again", existing)
+	}
+	if _, err := fmt.Fprintf(s.output, "Warning: HEAD is already tagged %s; %s will point at the same commit\n", existing, nextTag); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// headVersionTags returns the semantic version tags pointing at HEAD, newest first.
+func (s *BumpService) headVersionTags() ([]string, error) {
+	head, err := s.repo.HeadHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	names, err := s.repo.TagsAt(head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the tags at HEAD: %w", err)
+	}
+	return s.repo.TagScheme().SortTagNames(names), nil
+}
+
+// tagAtHead reports whether the given tag points at the commit HEAD points at.
+func (s *BumpService) tagAtHead(tag string) (bool, error) {
+	head, err := s.repo.HeadHash()
+	if err != nil {
+		return false, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	tagCommit, err := s.repo.TagCommit(tag)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve tag %s: %w", tag, err)
+	}
+	return tagCommit == head, nil
+}
+
+// expandSuffix renders template tokens in a suffix using the current date and the
+// hash of HEAD.
+func (s *BumpService) expandSuffix(suffix string) (string, error) {
+	if !strings.Contains(suffix, "{{") {
+		return suffix, nil
+	}
+	sha, err := s.repo.HeadHash()
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD for suffix template: %w", err)
+	}
+	return renderSuffix(suffix, SuffixTemplateData{
+		Date:     s.now().UTC().Format("20060102"),
+		ShortSHA: shortHash(sha),
+		SHA:      sha,
+	})
+}
+
+// resolveRemote returns the remote to push to. An explicit remote is used as is;
+// otherwise the configured remotes decide (see selectDefaultRemote).
+func (s *BumpService) resolveRemote(remote string) (string, error) {
+	if remote != "" {
+		return remote, nil
+	}
+	remotes, err := s.repo.Remotes()
+	if err != nil {
+		return "", err
+	}
+	return selectDefaultRemote(remotes)
+}
+
+// resolvePushRemote resolves the remote like resolveRemote, but explains how to add
+// one when the repository has none rather than leaving git push to fail opaquely.
+func (s *BumpService) resolvePushRemote(remote string) (string, error) {
+	remote, err := s.resolveRemote(remote)
+	if errors.Is(err, ErrNoRemote) {
+		return "", fmt.Errorf("%w; cannot push (add one with: git remote add origin <url>)", err)
+	}
+	return remote, err
+}
+
+// Push pushes all tags to the given remote, resolving the default remote when it is empty.
+func (s *BumpService) Push(remote string) error {
+	remote, err := s.resolvePushRemote(remote)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.PushTags(remote); err != nil {
+		return fmt.Errorf("failed to push tags: %w", err)
+	}
+	if _, err := fmt.Fprintf(s.output, "Successfully pushed tags to %s.\n", remote); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// githubRepository determines the GitHub owner and repository name from the
+// given remote and checks that an API token is available.
+func (s *BumpService) githubRepository(remote string) (string, string, error) {
+	if s.github == nil || s.github.token == "" {
+		return "", "", fmt.Errorf("--github-release requires %s to be set", githubTokenEnv)
+	}
+	remoteURL, err := s.repo.RemoteURL(remote)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s remote: %w", remote, err)
+	}
+	owner, repo, err := parseGitHubRemote(remoteURL)
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+// submodulePaths returns the submodule paths listed in .gitmodules, rejecting any
+// that would lead outside the work tree, such as a crafted path = ../other.
+func (s *BumpService) submodulePaths() ([]string, error) {
+	repoPath := s.repo.Path()
+	paths, err := bump.SubmodulePaths(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read submodules: %w", err)
+	}
+	for _, path := range paths {
+		if err := validateFilePath(path, repoPath); err != nil {
+			return nil, fmt.Errorf("invalid submodule path %q: %w", path, err)
+		}
+	}
+	return paths, nil
+}
+
+// bumpSubmodules applies the bump to each submodule at paths that is itself
+// managed by bump, meaning it is a git repository with at least one semantic
+// version tag. Each submodule is tagged under its own lock. Options tied to files
+// in the superproject (--update-file, --tag-message-file) are not carried into
+// submodules.
+func (s *BumpService) bumpSubmodules(opts BumpOptions, paths []string) ([]SubmoduleResult, error) {
+	repoPath := s.repo.Path()
+	subOpts := opts
+	subOpts.UpdateFile = ""
+	subOpts.TagMessageFile = ""
+	subOpts.Since = ""
+	subOpts.BaseFromFile = ""
+	subOpts.Remote = ""
+
+	var results []SubmoduleResult
+	for _, path := range paths {
+		subRepo, err := s.openRepo(filepath.Join(repoPath, path))
+		if err != nil {
+			log.Debug("skipping submodule", "path", path, "err", err)
+			if _, err := fmt.Fprintf(s.output, "Skipping submodule %s: not a git repository\n", path); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+			continue
+		}
+
+		subSvc := &BumpService{repo: subRepo, updater: s.updater, output: s.output, openRepo: s.openRepo, github: s.github, webhook: s.webhook, now: s.now}
+		baseTag, err := subSvc.latestBaseTag()
+		if err != nil {
+			return nil, fmt.Errorf("submodule %s: %w", path, err)
+		}
+		if baseTag == "" {
+			if _, err := fmt.Fprintf(s.output, "Skipping submodule %s: no version tags\n", path); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(s.output, "Submodule %s:\n", path); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+		result, err := subSvc.Bump(subOpts)
+		if errors.Is(err, ErrNoChanges) {
+			if _, err := fmt.Fprintf(s.output, "Skipping submodule %s: no changes\n", path); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("submodule %s: %w", path, err)
+		}
+		results = append(results, SubmoduleResult{Path: path, Result: result})
+	}
+
+	return results, nil
+}
+
+// Check verifies that the Version constant in the given file is consistent with
+// the latest tag. It makes no changes and returns an error on any mismatch.
+func (s *BumpService) Check(filePath string) error {
+	repoPath := s.repo.Path()
+
+	// Validate file path to prevent security issues
+	if err := validateFilePath(filePath, repoPath); err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	absPath := filepath.Join(repoPath, filepath.Clean(filePath))
+
+	fileVersion, err := s.readFileVersion(absPath)
+	if err != nil {
+		return err
+	}
+
+	latestTag, err := s.latestBaseTag()
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersionConsistency(s.repo.TagScheme(), fileVersion, latestTag); err != nil {
+		return fmt.Errorf("%s is out of date: %w", filePath, err)
+	}
+
+	if _, err := fmt.Fprintf(s.output, "%s version %s is consistent with latest tag %s\n", filePath, fileVersion, latestTag); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// SyncFile heals a version file that has drifted from the latest tag, for example
+// a 1.2.4-dev left behind after v1.3.0 was tagged by a minor bump. A file that
+// Check would accept is left alone; otherwise the development version following
+// the latest tag is written and committed. With dryRun, only the planned change is
+// printed. It reports whether the file was (or would be) updated.
+func (s *BumpService) SyncFile(filePath string, dryRun bool) (bool, error) {
+	repoPath := s.repo.Path()
+
+	// Validate file path to prevent security issues
+	if err := validateFilePath(filePath, repoPath); err != nil {
+		return false, fmt.Errorf("invalid file path: %w", err)
+	}
+	absPath := filepath.Join(repoPath, filepath.Clean(filePath))
+
+	fileVersion, err := s.readFileVersion(absPath)
+	if err != nil {
+		return false, err
+	}
+
+	latestTag, err := s.latestBaseTag()
+	if err != nil {
+		return false, err
+	}
+	if latestTag == "" {
+		return false, fmt.Errorf("no semantic version tags found to sync %s with", filePath)
+	}
+
+	if checkVersionConsistency(s.repo.TagScheme(), fileVersion, latestTag) == nil {
+		if _, err := fmt.Fprintf(s.output, "%s version %s is already in sync with latest tag %s\n", filePath, fileVersion, latestTag); err != nil {
+			return false, fmt.Errorf("failed to write output: %w", err)
+		}
+		return false, nil
+	}
+
+	devVersion, err := calculateDevVersion(s.repo.TagScheme(), latestTag)
+	if err != nil {
+		return false, err
+	}
+	if dryRun {
+		if _, err := fmt.Fprintf(s.output, "Would update %s from %s to %s to follow latest tag %s\n", filePath, fileVersion, devVersion, latestTag); err != nil {
+			return false, fmt.Errorf("failed to write output: %w", err)
+		}
+		return true, nil
+	}
+
+	if err := s.writeVersionFile(filePath, devVersion, nil, false); err != nil {
+		return false, fmt.Errorf("failed to update file: %w", err)
+	}
+	if _, err := fmt.Fprintf(s.output, "Updated %s from %s to %s to follow latest tag %s\n", filePath, fileVersion, devVersion, latestTag); err != nil {
+		return false, fmt.Errorf("failed to write output: %w", err)
+	}
+	return true, nil
+}
+
+// ListTags prints the semantic version tags, newest first. The "subject" format
+// adds the subject line of each tagged commit; a positive limit keeps only the
+// newest tags so large histories are not walked in full. With stripPrefix the
+// tags are printed without the tag prefix.
+func (s *BumpService) ListTags(format string, limit int, stripPrefix bool) ([]TagEntry, error) {
+	if format != "" && format != "name" && format != "subject" {
+		return nil, fmt.Errorf("unknown format %q (expected name or subject)", format)
+	}
+
+	names, err := s.tagNames()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := s.repo.TagScheme().SortTagNames(names)
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return s.printTagList(tags, format == "subject", stripPrefix)
+}
+
+// ListHeadTags prints the semantic version tags that point at the commit HEAD is
+// on, newest first, answering whether this exact commit is already a release.
+// It returns ErrHeadNotTagged when there are none.
+func (s *BumpService) ListHeadTags(format string, stripPrefix bool) ([]TagEntry, error) {
+	if format != "" && format != "name" && format != "subject" {
+		return nil, fmt.Errorf("unknown format %q (expected name or subject)", format)
+	}
+
+	tags, err := s.headVersionTags()
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, ErrHeadNotTagged
+	}
+	return s.printTagList(tags, format == "subject", stripPrefix)
+}
+
+// printTagList prints the given tags, with the subject of each tagged commit when
+// withSubjects is set and without the tag prefix when stripPrefix is set, and
+// returns them as entries. The entries keep the full tag names.
+func (s *BumpService) printTagList(tags []string, withSubjects, stripPrefix bool) ([]TagEntry, error) {
+	var err error
+	entries := make([]TagEntry, len(tags))
+	shown := make([]TagEntry, len(tags))
+	for i, tag := range tags {
+		entries[i].Tag = tag
+		if withSubjects {
+			if entries[i].Subject, err = s.repo.TagSubject(tag); err != nil {
+				return nil, fmt.Errorf("failed to resolve tag %s: %w", tag, err)
+			}
+		}
+		shown[i] = TagEntry{Tag: displayVersion(s.repo.TagScheme(), tag, stripPrefix), Subject: entries[i].Subject}
+	}
+
+	if _, err := fmt.Fprint(s.output, formatTagList(shown, withSubjects)); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	return entries, nil
+}
+
+// ListReleaseLines prints the newest tag of each major.minor release line, newest
+// line first. A positive limit shows only that many lines. With stripPrefix the
+// tags are printed without the tag prefix.
+func (s *BumpService) ListReleaseLines(limit int, stripPrefix bool) ([]bump.ReleaseLine, error) {
+	names, err := s.tagNames()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := s.repo.TagScheme().LatestPerReleaseLine(names)
+	if limit > 0 && len(lines) > limit {
+		lines = lines[:limit]
+	}
+
+	shown := make([]bump.ReleaseLine, len(lines))
+	for i, line := range lines {
+		shown[i] = line
+		shown[i].Latest = displayVersion(s.repo.TagScheme(), line.Latest, stripPrefix)
+	}
+	if _, err := fmt.Fprint(s.output, formatReleaseLines(shown)); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	return lines, nil
+}
+
+// scanTags adds the local tags to a new bump.TagScanner as the references are read,
+// without collecting their names. exclude is passed to NewTagScanner; the
+// caller calls Done.
+func (s *BumpService) scanTags(exclude func(string) bool) (*bump.TagScanner, error) {
+	tagRefs, err := s.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	defer tagRefs.Close()
+
+	scanner := s.repo.TagScheme().NewTagScanner(exclude)
+	if err := tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		scanner.Add(ref.Name().Short())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+	return scanner, nil
+}
+
+// latestBaseTag returns the latest local tag that is not a bump dev snapshot: the
+// tag a patch, minor or major bump starts from, as Bump picks it. Snapshots sit
+// between releases, so commands that report or compare against the latest release
+// use this to agree with bump about it.
+func (s *BumpService) latestBaseTag() (string, error) {
+	scanner, err := s.scanTags(isDevTagIn(s.repo.TagScheme()))
+	if err != nil {
+		return "", err
+	}
+	scanner.Done()
+	return scanner.LatestIncluded(), nil
+}
+
+// tagNames returns the short names of all local tags.
+func (s *BumpService) tagNames() ([]string, error) {
+	tagRefs, err := s.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	defer tagRefs.Close()
+
+	var names []string
+	if err := tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+	return names, nil
+}
+
+// Dev tags HEAD with a development snapshot version counted from the latest tag
+// that is not itself a snapshot (see nextDevTag), so snapshots never advance the
+// base they are computed from. Merge commits are not counted, matching the
+// changelog. With push, only the new tag is pushed; with dryRun, the tag is printed
+// without being created. It returns ErrNoChanges when HEAD is the base tag's commit.
+func (s *BumpService) Dev(remote string, push, dryRun bool) (string, error) {
+	baseTag, err := s.latestBaseTag()
+	if err != nil {
+		return "", err
+	}
+
+	commits, err := s.repo.CommitsSince(baseTag)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commits since %s: %w", baseTag, err)
+	}
+	if len(commits) == 0 {
+		return "", ErrNoChanges
+	}
+	devTag, err := nextDevTag(s.repo.TagScheme(), baseTag, len(withoutMerges(commits)))
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate dev tag: %w", err)
+	}
+
+	if push {
+		if remote, err = s.resolvePushRemote(remote); err != nil {
+			return "", err
+		}
+	}
+	if dryRun {
+		if _, err := fmt.Fprintf(s.output, "Would create tag: %s\n", devTag); err != nil {
+			return "", fmt.Errorf("failed to write output: %w", err)
+		}
+		return devTag, nil
+	}
+
+	if err := s.repo.CreateTag(devTag, bump.TagOptions{}); err != nil {
+		return "", fmt.Errorf("failed to create tag: %w", err)
+	}
+	if _, err := fmt.Fprintf(s.output, "Created dev tag %s\n", devTag); err != nil {
+		return "", fmt.Errorf("failed to write output: %w", err)
+	}
+	if push {
+		if err := s.repo.PushTag(remote, devTag); err != nil {
+			return "", fmt.Errorf("failed to push tag: %w", err)
+		}
+		if _, err := fmt.Fprintf(s.output, "Pushed %s to %s\n", devTag, remote); err != nil {
+			return "", fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return devTag, nil
+}
+
+// Latest prints the latest semantic version tag, either from local tags or, when
+// remote is set, from the tags published on that remote. With asJSON, the tag is
+// printed as a LatestVersion document with its parsed components instead.
+func (s *BumpService) Latest(remote string, stripPrefix, asJSON bool) (string, error) {
+	scheme := s.repo.TagScheme()
+	var scanner *bump.TagScanner
+	if remote != "" {
+		names, err := s.repo.RemoteTags(remote)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch tags from %s: %w", remote, err)
+		}
+		scanner = scheme.NewTagScanner(isDevTagIn(scheme))
+		for _, name := range names {
+			scanner.Add(name)
+		}
+	} else {
+		var err error
+		if scanner, err = s.scanTags(isDevTagIn(scheme)); err != nil {
+			return "", err
+		}
+	}
+	scan := scanner.Done()
+
+	latestTag := scanner.LatestIncluded()
+	if latestTag == "" {
+		return "", fmt.Errorf("no semantic version tags found")
+	}
+
+	if asJSON {
+		latest, err := newLatestVersion(scheme, latestTag, scan)
+		if err != nil {
+			return "", err
+		}
+		return latestTag, writeJSONLatest(s.output, latest)
+	}
+	if _, err := fmt.Fprintln(s.output, displayVersion(scheme, latestTag, stripPrefix)); err != nil {
+		return "", fmt.Errorf("failed to write output: %w", err)
+	}
+	return latestTag, nil
+}
+
+// Status prints a read-only overview of the repository's release state: the latest
+// tag, the candidate next versions, uncommitted changes, the non-merge commits since
+// the latest tag, and the given settings.
+func (s *BumpService) Status(settings []ConfigSetting) (*RepoStatus, error) {
+	latestTag, err := s.latestBaseTag()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := previewVersions(s.repo.TagScheme(), latestTag, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next versions: %w", err)
+	}
+
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	worktreeStatus, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worktree status: %w", err)
+	}
+
+	commits, err := s.repo.CommitsSince(latestTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits since %s: %w", latestTag, err)
+	}
+
+	status := &RepoStatus{
+		LatestTag:    latestTag,
+		Candidates:   candidates,
+		Changed:      changedPaths(worktreeStatus, false),
+		CommitsSince: len(withoutMerges(commits)),
+		Settings:     settings,
+	}
+	if _, err := fmt.Fprint(s.output, formatStatus(*status)); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	return status, nil
+}
+
+// Preview prints the version each bump type would create from the latest local tag,
+// without creating anything. A suffix adds a prerelease candidate in that series.
+// With stripPrefix the versions are printed without the tag prefix; the returned
+// candidates keep it.
+func (s *BumpService) Preview(suffix string, stripPrefix bool) ([]VersionCandidate, error) {
+	latestTag, err := s.latestBaseTag()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := s.repo.TagScheme()
+	candidates, err := previewVersions(scheme, latestTag, suffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next versions: %w", err)
+	}
+	shown := make([]VersionCandidate, len(candidates))
+	for i, candidate := range candidates {
+		shown[i] = VersionCandidate{BumpType: candidate.BumpType, Version: displayVersion(scheme, candidate.Version, stripPrefix)}
+	}
+	if _, err := fmt.Fprint(s.output, formatPreview(displayVersion(scheme, latestTag, stripPrefix), shown)); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	return candidates, nil
+}
+
+// Normalize creates canonical "v"-prefixed tags for version tags written in other
+// forms, pointing at the same commits. Without apply it only prints the plan; with
+// deleteOld the original tags are removed once their canonical tag exists.
+func (s *BumpService) Normalize(apply, deleteOld bool) ([]NormalizeOp, error) {
+	tagRefs, err := s.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	defer tagRefs.Close()
+
+	var tags []string
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		tags = append(tags, ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	ops := planNormalization(s.repo.TagScheme(), tags)
+	if !apply {
+		if _, err := fmt.Fprint(s.output, formatNormalizePreview(ops, deleteOld)); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+		return ops, nil
+	}
+
+	for _, op := range ops {
+		if op.Exists {
+			if _, err := fmt.Fprintf(s.output, "Skipping %s: %s already exists\n", op.OldTag, op.NewTag); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+			continue
+		}
+
+		// Peel to the commit so annotated tags are not nested inside the new tag
+		if err := s.repo.CreateTag(op.NewTag, bump.TagOptions{Target: op.OldTag + "^{commit}"}); err != nil {
+			return nil, fmt.Errorf("failed to create tag %s: %w", op.NewTag, err)
+		}
+		if _, err := fmt.Fprintf(s.output, "Created tag %s at %s\n", op.NewTag, op.OldTag); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+
+		if deleteOld {
+			if err := s.repo.DeleteTag(op.OldTag); err != nil {
+				return nil, fmt.Errorf("failed to delete tag %s: %w", op.OldTag, err)
+			}
+			if _, err := fmt.Fprintf(s.output, "Deleted tag %s\n", op.OldTag); err != nil {
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+	}
+
+	return ops, nil
+}
+
+// LintTags reports every tag that looks like a version but violates strict SemVer,
+// such as v01.2.3, v1.2, or v1.2.3_beta, without changing anything. It fails when
+// any are found, so the check can gate automated releases.
+func (s *BumpService) LintTags() ([]bump.TagLint, error) {
+	names, err := s.tagNames()
+	if err != nil {
+		return nil, err
+	}
+
+	lints := bump.LintTagNames(names)
+	if _, err := fmt.Fprint(s.output, formatTagLints(lints)); err != nil {
+		return nil, fmt.Errorf("failed to write output: %w", err)
+	}
+	if len(lints) > 0 {
+		return lints, fmt.Errorf("found %d malformed version tag(s)", len(lints))
+	}
+	return nil, nil
+}
+
+// Retag moves an existing version tag to HEAD, deleting and recreating it locally
+// and, when remote is set, force-pushing it to that remote. Because this rewrites
+// a tag that may already be published, force must be set.
+func (s *BumpService) Retag(tag, remote string, force bool) error {
+	tagRefs, err := s.repo.Tags()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	defer tagRefs.Close()
+
+	exists := false
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Name().Short() == tag {
+			exists = true
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("tag %s does not exist", tag)
+	}
+
+	if !force {
+		return fmt.Errorf("retagging %s rewrites an existing tag; use --force to proceed", tag)
+	}
+
+	// Recreate the tag as it was, so moving it keeps its type, message and signature
+	tagOpts, err := s.repo.ExistingTagOptions(tag)
+	if err != nil {
+		return err
+	}
+	if tagOpts.Sign {
+		if _, err := s.repo.SigningFormat(); err != nil {
+			return fmt.Errorf("cannot re-sign tag %s: %w", tag, err)
+		}
+	}
+
+	if err := s.repo.ReplaceTag(tag, tagOpts); err != nil {
+		return fmt.Errorf("failed to move tag %s: %w", tag, err)
+	}
+	if _, err := fmt.Fprintf(s.output, "Moved tag %s to HEAD\n", tag); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if remote != "" {
+		if err := s.repo.ForcePushTag(remote, tag); err != nil {
+			return fmt.Errorf("failed to push tag %s: %w", tag, err)
+		}
+		if _, err := fmt.Fprintf(s.output, "Force-pushed tag %s to %s\n", tag, remote); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// printTimings writes the phase durations to the output when timings were requested.
+func (s *BumpService) printTimings(opts BumpOptions, timings BumpTimings) error {
+	if !opts.Timings {
+		return nil
+	}
+	if _, err := fmt.Fprint(s.output, formatTimings(timings)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// buildTrailers parses the requested trailers and, for signoff, adds a
+// Signed-off-by trailer using the repository's git user identity.
+func (s *BumpService) buildTrailers(opts BumpOptions) ([]string, error) {
+	var trailers []string
+	for _, trailer := range opts.Trailers {
+		line, err := parseTrailer(trailer)
+		if err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, line)
+	}
+
+	if opts.Signoff {
+		name, email, err := s.repo.UserIdentity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read git identity: %w", err)
+		}
+		if name == "" || email == "" {
+			return nil, fmt.Errorf("--signoff requires user.name and user.email to be set in git config")
+		}
+		trailers = append(trailers, fmt.Sprintf("Signed-off-by: %s <%s>", name, email))
+	}
+
+	return trailers, nil
+}
+
+// readTagMessageFile reads the annotation for a new tag from the given file.
+// Relative paths are resolved against the repository root and must stay within it;
+// absolute paths are allowed since the file is only read.
+func (s *BumpService) readTagMessageFile(filePath string) (string, error) {
+	absPath := filePath
+	if !filepath.IsAbs(filePath) {
+		repoPath := s.repo.Path()
+		if err := validateFilePath(filePath, repoPath); err != nil {
+			return "", fmt.Errorf("invalid file path: %w", err)
+		}
+		absPath = filepath.Join(repoPath, filepath.Clean(filePath))
+	}
+
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(string(content)) == "" {
+		return "", fmt.Errorf("file is empty: %s", filePath)
+	}
+
+	return string(content), nil
+}
+
+// RollbackDev undoes the newest commit since the latest tag whose subject matches
+// pattern, normally the development version commit made by --update-file. By
+// default the commit is reverted with a new commit; with reset, the branch is
+// moved back to its parent, which requires the commit to be HEAD. With dryRun,
+// only the planned action is printed.
+func (s *BumpService) RollbackDev(pattern *regexp.Regexp, reset, dryRun bool) error {
+	latestTag, err := s.latestBaseTag()
+	if err != nil {
+		return err
+	}
+	commits, err := s.repo.CommitsSince(latestTag)
+	if err != nil {
+		return fmt.Errorf("failed to read commits since %s: %w", latestTag, err)
+	}
+
+	i := findDevCommit(commits, pattern)
+	if i < 0 {
+		since := latestTag
+		if since == "" {
+			since = "the start of history"
+		}
+		return fmt.Errorf("no commit matching %q since %s", pattern, since)
+	}
+	commit := commits[i]
+	if commit.IsMerge {
+		return fmt.Errorf("cannot roll back %s %s: it is a merge commit", shortHash(commit.Hash), commit.Subject)
+	}
+	if reset && i != 0 {
+		return fmt.Errorf("cannot reset: %s %s is not HEAD; revert it instead", shortHash(commit.Hash), commit.Subject)
+	}
+
+	if dryRun {
+		action := "revert"
+		if reset {
+			action = "reset HEAD to the parent of"
+		}
+		if _, err := fmt.Fprintf(s.output, "Would %s %s %s\n", action, shortHash(commit.Hash), commit.Subject); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
+
+	// Both actions overwrite files, so uncommitted changes would be lost
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	if paths := changedPaths(status, false); len(paths) > 0 {
+		return fmt.Errorf("working tree has uncommitted changes: %s (commit or stash them first)", strings.Join(paths, ", "))
+	}
+
+	if !reset {
+		message := fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.\n", commit.Subject, commit.Hash)
+		if err := s.repo.RevertCommit(commit.Hash, message); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(s.output, "Reverted %s %s\n", shortHash(commit.Hash), commit.Subject); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
+
+	head, err := s.repo.HeadCommit()
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	if len(head.ParentHashes) == 0 {
+		return fmt.Errorf("cannot reset: HEAD has no parent")
+	}
+	pushed, err := s.repo.HeadPushed()
+	if err != nil {
+		return err
+	}
+	if pushed {
+		if _, err := fmt.Fprintln(s.output, "Warning: HEAD is already on a remote branch; resetting it rewrites published history"); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	parent := head.ParentHashes[0].String()
+	if err := s.repo.ResetHead(parent); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.output, "Reset HEAD to %s, dropping %s %s\n", shortHash(parent), shortHash(commit.Hash), commit.Subject); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// readFileVersion returns the version held in a Go source file, package.json, or
+// TOML manifest.
+func (s *BumpService) readFileVersion(absPath string) (string, error) {
+	if isPackageJSON(absPath) {
+		content, err := os.ReadFile(absPath)
+		if err != nil {
+			return "", err
+		}
+		return readPackageJSONVersion(content)
+	}
+	if isTOMLFile(absPath) {
+		paths, err := tomlKeyPaths(absPath, s.updater.tomlPath)
+		if err != nil {
+			return "", err
+		}
+		content, err := os.ReadFile(absPath)
+		if err != nil {
+			return "", err
+		}
+		return readTOMLVersion(content, paths)
+	}
+
+	node, _, err := s.updater.ParseGoFile(absPath)
+	if err != nil {
+		return "", err
+	}
+	return s.updater.ReadVersionConstant(node)
+}
+
+// UpdateVersionFile updates a Go source file, package.json, or TOML manifest with a new development version.
+// With amend, the change is folded into the HEAD commit instead of a new commit.
+func (s *BumpService) UpdateVersionFile(filePath, nextTag string, amend bool) error {
+	devVersion, err := s.devVersion(nextTag, "")
+	if err != nil {
+		return err
+	}
+	return s.writeVersionFile(filePath, devVersion, nil, amend)
+}
+
+// constantValues resolves the --constant specs in opts to the released and
+// development versions that follow nextTag.
+func (s *BumpService) constantValues(opts BumpOptions, nextTag string) (map[string]string, error) {
+	if len(opts.Constants) == 0 {
+		return nil, nil
+	}
+	dev, err := s.devVersion(nextTag, opts.DirtySuffix)
+	if err != nil {
+		return nil, err
+	}
+	return constantValues(opts.Constants, s.repo.TagScheme().StripPrefix(nextTag), dev)
+}
+
+// devVersion returns the development version that follows nextTag. When
+// dirtySuffix is set and the worktree has uncommitted changes to tracked files,
+// it is appended as a final identifier so builds identify their uncommitted state.
+func (s *BumpService) devVersion(nextTag, dirtySuffix string) (string, error) {
+	// Calculate development version (pure function)
+	devVersion, err := calculateDevVersion(s.repo.TagScheme(), nextTag)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate dev version: %w", err)
+	}
+	if dirtySuffix == "" {
+		return devVersion, nil
+	}
+
+	wt, err := s.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	if len(changedPaths(status, false)) > 0 {
+		devVersion += "." + dirtySuffix
+	}
+	return devVersion, nil
+}
+
+// writeVersionFile sets the version held in a Go source file, package.json, or TOML manifest and
+// commits the change, amending HEAD with amend. constants sets further string
+// constants in a Go file in the same pass, e.g. BuildVersion to the released version.
+// This method handles path validation, file operations, and git operations.
+func (s *BumpService) writeVersionFile(filePath, version string, constants map[string]string, amend bool) error {
+	repoPath := s.repo.Path()
+
+	// Validate file path to prevent security issues
+	if err := validateFilePath(filePath, repoPath); err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+
+	// Clean the path
+	cleanPath := filepath.Clean(filePath)
+
+	// Resolve to absolute path for file operations
+	absPath := filepath.Join(repoPath, cleanPath)
+
+	commitMsg := fmt.Sprintf("Bump version to %s", version)
+	if isPackageJSON(cleanPath) {
+		if len(constants) > 0 {
+			return fmt.Errorf("--constant requires a Go --update-file, not %s", cleanPath)
+		}
+		paths, err := writePackageJSONVersion(absPath, version)
+		if err != nil {
+			return err
+		}
+		return s.commitFiles(paths, commitMsg, amend)
+	}
+	if isTOMLFile(cleanPath) {
+		if len(constants) > 0 {
+			return fmt.Errorf("--constant requires a Go --update-file, not %s", cleanPath)
+		}
+		paths, err := tomlKeyPaths(cleanPath, s.updater.tomlPath)
+		if err != nil {
+			return err
+		}
+		if err := writeTOMLVersion(absPath, paths, version); err != nil {
+			return err
+		}
+		return s.commitFiles([]string{absPath}, commitMsg, amend)
+	}
+
+	// Parse, update, and write the file (using absolute path)
+	node, fset, err := s.updater.ParseGoFile(absPath)
+	if err != nil {
+		return err
+	}
+
+	// A version field lives in a variable, so only the extra constants share the walk
+	values := maps.Clone(constants)
+	if len(s.updater.field) > 0 {
+		if err := s.updater.UpdateVersionConstant(node, version); err != nil {
+			return err
+		}
+	} else {
+		if values == nil {
+			values = make(map[string]string, 1)
+		}
+		values["Version"] = version
+	}
+	if len(values) > 0 {
+		if err := s.updater.UpdateVersionConstants(node, values); err != nil {
+			return err
+		}
+	}
+
+	if err := s.updater.WriteFormattedFile(absPath, fset, node); err != nil {
+		return err
+	}
+
+	return s.commitFiles([]string{absPath}, commitMsg, amend)
+}
+
+// readBaseVersion reads the version held in a VERSION file and returns it as a tag.
+func (s *BumpService) readBaseVersion(filePath string) (string, error) {
+	repoPath := s.repo.Path()
+
+	// Validate file path to prevent security issues
+	if err := validateFilePath(filePath, repoPath); err != nil {
+		return "", fmt.Errorf("invalid file path: %w", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(repoPath, filepath.Clean(filePath)))
+	if err != nil {
+		return "", err
+	}
+	return parseVersionFile(s.repo.TagScheme(), string(content))
+}
+
+// WriteBaseVersion writes the released version (not the -dev variant) to a VERSION
+// file and commits it. A "v" prefix is written only if the file already used one.
+func (s *BumpService) WriteBaseVersion(filePath, nextTag string) error {
+	repoPath := s.repo.Path()
+
+	// Validate file path to prevent security issues
+	if err := validateFilePath(filePath, repoPath); err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	absPath := filepath.Join(repoPath, filepath.Clean(filePath))
+
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return err
+	}
+	version := formatVersionFile(s.repo.TagScheme(), nextTag, string(content))
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(absPath, []byte(version), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return s.commitFiles([]string{absPath}, fmt.Sprintf("Bump version to %s", strings.TrimSpace(version)), false)
+}
+
+// commitFiles stages the files at absPaths and commits them with the given message.
+// With amend, the HEAD commit is rewritten to include the files, keeping its
+// message and author; a warning is printed if HEAD is already on a remote branch.
+func (s *BumpService) commitFiles(absPaths []string, commitMsg string, amend bool) error {
+	repoPath := s.repo.Path()
+
+	// Stage and commit the file
+	worktree, err := s.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get working tree: %w", err)
+	}
+
+	for _, absPath := range absPaths {
+		// Get relative path for git operations
+		relPath, err := filepath.Rel(repoPath, absPath)
+		if err != nil {
+			return fmt.Errorf("failed to determine relative path: %w", err)
+		}
+
+		// Stage the file
+		if _, err := worktree.Add(relPath); err != nil {
+			return fmt.Errorf("failed to stage file: %w", err)
+		}
+	}
+
+	// Commit the change
+	author, err := s.commitAuthor()
+	if err != nil {
+		return err
+	}
+	commitOpts := &git.CommitOptions{Author: author}
+	if amend {
+		head, err := s.repo.HeadCommit()
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD commit: %w", err)
+		}
+		pushed, err := s.repo.HeadPushed()
+		if err != nil {
+			return err
+		}
+		if pushed {
+			if _, err := fmt.Fprintln(s.output, "Warning: HEAD is already on a remote branch; amending it rewrites published history"); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		commitMsg = head.Message
+		commitOpts.Committer = commitOpts.Author
+		commitOpts.Author = &head.Author
+		commitOpts.Amend = true
+	}
+	_, err = worktree.Commit(commitMsg, commitOpts)
 	if err != nil {
 		return fmt.Errorf("failed to commit file: %w", err)
 	}
@@ -2,11 +2,22 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/klauern/bump"
 )
 
 // TestNewBumpService tests the service constructor
@@ -117,6 +128,16 @@ func TestBump_Success(t *testing.T) {
 			expectedTag:  "v2.0.1-beta",
 			expectedPush: false,
 		},
+		{
+			name:         "Patch bump preserving suffix",
+			existingTags: []string{"v1.0.0-rc.1"},
+			opts: BumpOptions{
+				BumpType:       "patch",
+				PreserveSuffix: true,
+			},
+			expectedTag:  "v1.0.1-rc.1",
+			expectedPush: false,
+		},
 		{
 			name:         "First tag (no existing tags)",
 			existingTags: []string{},
@@ -163,6 +184,40 @@ func TestBump_Success(t *testing.T) {
 	}
 }
 
+// TestBump_ZeroVersion tests that an existing v0.0.0 tag is used as the base
+// rather than being treated like a repository with no tags
+func TestBump_ZeroVersion(t *testing.T) {
+	tests := []struct {
+		bumpType    string
+		expectedTag string
+	}{
+		{bumpType: "patch", expectedTag: "v0.0.1"},
+		{bumpType: "minor", expectedTag: "v0.1.0"},
+		{bumpType: "major", expectedTag: "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bumpType, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			svc := NewBumpService(NewMockRepoWithTags([]string{"v0.0.0"}), nil, output)
+
+			result, err := svc.Bump(BumpOptions{BumpType: tt.bumpType})
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if result.NextTag != tt.expectedTag {
+				t.Errorf("NextTag = %v, expected %v", result.NextTag, tt.expectedTag)
+			}
+			if result.PreviousTag != "v0.0.0" {
+				t.Errorf("PreviousTag = %v, expected v0.0.0", result.PreviousTag)
+			}
+			if strings.Contains(output.String(), "No tags found") {
+				t.Errorf("v0.0.0 should not be reported as no tags, got: %v", output.String())
+			}
+		})
+	}
+}
+
 // TestBump_DryRun tests dry-run mode
 func TestBump_DryRun(t *testing.T) {
 	tests := []struct {
@@ -209,7 +264,22 @@ func TestBump_DryRun(t *testing.T) {
 			expectedTag: "v1.1.0",
 			expectOutput: []string{
 				"Would create tag: v1.1.0",
-				"Would update file: version.go",
+				"Would update file version.go: Version -> 1.1.1-dev",
+			},
+		},
+		{
+			name:         "Dry run with file update before tag",
+			existingTags: []string{"v1.0.0"},
+			opts: BumpOptions{
+				BumpType:        "minor",
+				UpdateFile:      "version.go",
+				UpdateBeforeTag: true,
+				DryRun:          true,
+			},
+			expectedTag: "v1.1.0",
+			expectOutput: []string{
+				"Would create tag: v1.1.0",
+				"Would update file version.go before tagging: Version -> 1.1.0",
 			},
 		},
 		{
@@ -271,6 +341,40 @@ func TestBump_DryRun(t *testing.T) {
 	}
 }
 
+// TestBump_DryRunLeavesFileUnchanged tests that previewing a file update does not write it
+func TestBump_DryRunLeavesFileUnchanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	versionFile := filepath.Join(tmpDir, "version.go")
+	content := "package main\n\nconst Version = \"1.0.1-dev\"\n"
+	if err := os.WriteFile(versionFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.PathFunc = func() string { return tmpDir }
+	repo.WorktreeFunc = func() (GitWorktree, error) {
+		t.Error("dry-run should not access the worktree")
+		return &MockGitWorktree{}, nil
+	}
+	output := &bytes.Buffer{}
+	svc := NewBumpService(repo, nil, output)
+
+	if _, err := svc.Bump(BumpOptions{BumpType: "patch", UpdateFile: "version.go", DryRun: true}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+
+	if !strings.Contains(output.String(), "Would update file version.go: Version -> 1.0.2-dev") {
+		t.Errorf("Output missing dev version preview:\n%v", output.String())
+	}
+	after, err := os.ReadFile(versionFile)
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+	if string(after) != content {
+		t.Errorf("dry-run modified the file:\n%s", after)
+	}
+}
+
 // TestBump_Errors tests error handling
 func TestBump_Errors(t *testing.T) {
 	tests := []struct {
@@ -318,6 +422,2936 @@ func TestBump_Errors(t *testing.T) {
 	}
 }
 
+// TestBump_Timings tests that phase durations are recorded and optionally printed
+func TestBump_Timings(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        BumpOptions
+		expectPrint bool
+	}{
+		{
+			name:        "Timings recorded but not printed",
+			opts:        BumpOptions{BumpType: "patch", Push: true},
+			expectPrint: false,
+		},
+		{
+			name:        "Timings printed when requested",
+			opts:        BumpOptions{BumpType: "patch", Push: true, Timings: true},
+			expectPrint: true,
+		},
+		{
+			name:        "Timings printed in dry-run",
+			opts:        BumpOptions{BumpType: "minor", DryRun: true, Timings: true},
+			expectPrint: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			svc := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0"}), nil, output)
+
+			result, err := svc.Bump(tt.opts)
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+
+			durations := map[string]time.Duration{
+				"TagEnumeration": result.Timings.TagEnumeration,
+				"LatestTag":      result.Timings.LatestTag,
+				"TagCreation":    result.Timings.TagCreation,
+				"Push":           result.Timings.Push,
+				"FileUpdate":     result.Timings.FileUpdate,
+			}
+			for name, d := range durations {
+				if d < 0 {
+					t.Errorf("Timings.%s = %v, expected non-negative", name, d)
+				}
+			}
+
+			printed := strings.Contains(output.String(), "tag_enumeration=")
+			if printed != tt.expectPrint {
+				t.Errorf("timings printed = %v, expected %v\nGot: %v", printed, tt.expectPrint, output.String())
+			}
+		})
+	}
+}
+
+// slowTagIterator delays ForEach to stand in for reading many tag references.
+type slowTagIterator struct {
+	*MockTagIterator
+	delay time.Duration
+}
+
+// ForEach sleeps for the delay before iterating.
+func (it *slowTagIterator) ForEach(fn func(*plumbing.Reference) error) error {
+	time.Sleep(it.delay)
+	return it.MockTagIterator.ForEach(fn)
+}
+
+// TestBump_TimingsTagEnumeration tests that reading the lazy tag iterator counts
+// as tag enumeration rather than finding the latest tag
+func TestBump_TimingsTagEnumeration(t *testing.T) {
+	const delay = 20 * time.Millisecond
+	repo := &MockGitRepository{
+		TagsFunc: func() (storer.ReferenceIter, error) {
+			return &slowTagIterator{MockTagIterator: NewMockTagIterator([]string{"v1.0.0"}), delay: delay}, nil
+		},
+	}
+	result, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", DryRun: true})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if result.Timings.TagEnumeration < delay {
+		t.Errorf("Timings.TagEnumeration = %v, expected at least %v", result.Timings.TagEnumeration, delay)
+	}
+	if result.Timings.LatestTag >= delay {
+		t.Errorf("Timings.LatestTag = %v, expected the iteration not to be counted", result.Timings.LatestTag)
+	}
+}
+
+// TestBump_TagMessageFile tests reading the tag annotation from a file
+func TestBump_TagMessageFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	notes := "Release v1.0.1\n\n- Fixed a bug\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "NOTES.md"), []byte(notes), 0o644); err != nil {
+		t.Fatalf("failed to write notes file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "empty.md"), []byte("  \n"), 0o644); err != nil {
+		t.Fatalf("failed to write empty file: %v", err)
+	}
+	outsideDir := t.TempDir()
+	absNotes := filepath.Join(outsideDir, "notes.txt")
+	if err := os.WriteFile(absNotes, []byte(notes), 0o644); err != nil {
+		t.Fatalf("failed to write absolute notes file: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		messageFile     string
+		expectError     string
+		expectedMessage string
+	}{
+		{
+			name:            "Relative path within repo",
+			messageFile:     "NOTES.md",
+			expectedMessage: notes,
+		},
+		{
+			name:            "Absolute path outside repo",
+			messageFile:     absNotes,
+			expectedMessage: notes,
+		},
+		{
+			name:        "Missing file",
+			messageFile: "missing.md",
+			expectError: "failed to read tag message file",
+		},
+		{
+			name:        "Empty file",
+			messageFile: "empty.md",
+			expectError: "file is empty",
+		},
+		{
+			name:        "Path traversal",
+			messageFile: "../secret.txt",
+			expectError: "invalid file path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMessage string
+			created := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.PathFunc = func() string { return tmpDir }
+			repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+				created = true
+				gotMessage = opts.Message
+				return nil
+			}
+
+			svc := NewBumpService(repo, nil, &bytes.Buffer{})
+			_, err := svc.Bump(BumpOptions{BumpType: "patch", TagMessageFile: tt.messageFile})
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				if created {
+					t.Error("tag should not be created when the message file is invalid")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if gotMessage != tt.expectedMessage {
+				t.Errorf("tag message = %q, expected %q", gotMessage, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// TestCheck tests verifying a version file against the latest tag
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileVersion  string
+		existingTags []string
+		expectError  string
+	}{
+		{
+			name:         "Dev version after tag",
+			fileVersion:  "1.0.1-dev",
+			existingTags: []string{"v0.9.0", "v1.0.0"},
+		},
+		{
+			name:         "Exact tag version",
+			fileVersion:  "1.0.0",
+			existingTags: []string{"v1.0.0"},
+		},
+		{
+			name:         "File not bumped after release",
+			fileVersion:  "0.9.1-dev",
+			existingTags: []string{"v0.9.0", "v1.0.0"},
+			expectError:  "out of date",
+		},
+		{
+			name:         "No tags",
+			fileVersion:  "0.1.0",
+			existingTags: []string{},
+			expectError:  "no semantic version tags found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			content := fmt.Sprintf("package main\n\nconst Version = %q\n", tt.fileVersion)
+			if err := os.WriteFile(filepath.Join(tmpDir, "version.go"), []byte(content), 0o644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+
+			repo := NewMockRepoWithTags(tt.existingTags)
+			repo.PathFunc = func() string { return tmpDir }
+			output := &bytes.Buffer{}
+			svc := NewBumpService(repo, nil, output)
+
+			err := svc.Check("version.go")
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Check() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check() unexpected error = %v", err)
+			}
+			if !strings.Contains(output.String(), "is consistent with latest tag") {
+				t.Errorf("unexpected output: %v", output.String())
+			}
+
+			// The file must not be modified by a check
+			after, err := os.ReadFile(filepath.Join(tmpDir, "version.go"))
+			if err != nil {
+				t.Fatalf("failed to read test file: %v", err)
+			}
+			if string(after) != content {
+				t.Errorf("Check() modified the file:\n%s", after)
+			}
+		})
+	}
+}
+
+// TestBump_Recursive tests applying a bump to submodules
+func TestBump_Recursive(t *testing.T) {
+	superDir := t.TempDir()
+	modules := `[submodule "core"]
+	path = core
+[submodule "docs"]
+	path = docs
+[submodule "missing"]
+	path = missing
+`
+	if err := os.WriteFile(filepath.Join(superDir, ".gitmodules"), []byte(modules), 0o644); err != nil {
+		t.Fatalf("failed to write .gitmodules: %v", err)
+	}
+
+	var coreCreated []string
+	subRepos := map[string]*MockGitRepository{
+		filepath.Join(superDir, "core"): NewMockRepoWithTags([]string{"v2.3.0"}),
+		filepath.Join(superDir, "docs"): NewMockRepoWithTags([]string{"release"}),
+	}
+	subRepos[filepath.Join(superDir, "core")].CreateTagFunc = func(name string, _ bump.TagOptions) error {
+		coreCreated = append(coreCreated, name)
+		return nil
+	}
+
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.PathFunc = func() string { return superDir }
+	output := &bytes.Buffer{}
+	svc := NewBumpService(repo, nil, output)
+	svc.openRepo = func(path string) (GitRepository, error) {
+		if r, ok := subRepos[path]; ok {
+			return r, nil
+		}
+		return nil, fmt.Errorf("repository does not exist")
+	}
+
+	result, err := svc.Bump(BumpOptions{BumpType: "minor", Recursive: true})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+
+	if result.NextTag != "v1.1.0" {
+		t.Errorf("NextTag = %v, expected v1.1.0", result.NextTag)
+	}
+	if len(result.Submodules) != 1 {
+		t.Fatalf("expected 1 bumped submodule, got %d", len(result.Submodules))
+	}
+	if result.Submodules[0].Path != "core" || result.Submodules[0].Result.NextTag != "v2.4.0" {
+		t.Errorf("unexpected submodule result: %+v", result.Submodules[0])
+	}
+	if len(coreCreated) != 1 || coreCreated[0] != "v2.4.0" {
+		t.Errorf("core submodule tags created = %v, expected [v2.4.0]", coreCreated)
+	}
+
+	outputStr := output.String()
+	for _, expected := range []string{
+		"Submodule core:",
+		"Skipping submodule docs: no version tags",
+		"Skipping submodule missing: not a git repository",
+	} {
+		if !strings.Contains(outputStr, expected) {
+			t.Errorf("Output missing expected string: %v\nGot: %v", expected, outputStr)
+		}
+	}
+}
+
+// TestBump_RecursiveRejectsOutsidePath tests that a .gitmodules path leading out
+// of the work tree fails before the superproject or the other repository is tagged
+func TestBump_RecursiveRejectsOutsidePath(t *testing.T) {
+	outsideDir, runOutside := newGitRepoWithCommits(t)
+	commitFile(t, outsideDir, runOutside, "README.md", "Initial commit")
+	runOutside("tag", "v2.0.0")
+	commitFile(t, outsideDir, runOutside, "main.go", "Add main")
+
+	superDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, superDir, runGit, "README.md", "Initial commit")
+	runGit("tag", "v1.0.0")
+	rel, err := filepath.Rel(superDir, outsideDir)
+	if err != nil {
+		t.Fatalf("filepath.Rel() error = %v", err)
+	}
+	commitFile(t, superDir, runGit, ".gitmodules", "[submodule \"evil\"]\n\tpath = "+filepath.ToSlash(rel)+"\n")
+
+	repo, err := NewGoGitRepository(superDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	_, err = NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "minor", Recursive: true})
+	if err == nil || !strings.Contains(err.Error(), "invalid submodule path") {
+		t.Fatalf("Bump() error = %v, expected an invalid submodule path", err)
+	}
+	if tags := strings.Fields(runOutside("tag")); !slices.Equal(tags, []string{"v2.0.0"}) {
+		t.Errorf("outside repository tags = %v, expected [v2.0.0]", tags)
+	}
+	if tags := strings.Fields(runGit("tag")); !slices.Equal(tags, []string{"v1.0.0"}) {
+		t.Errorf("superproject tags = %v, expected [v1.0.0]", tags)
+	}
+}
+
+// TestBump_PrintChangelog tests printing the changelog after a bump
+func TestBump_PrintChangelog(t *testing.T) {
+	var sinceTag string
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.CommitsSinceFunc = func(tag string) ([]CommitInfo, error) {
+		sinceTag = tag
+		return []CommitInfo{{Hash: "abc", Subject: "Add widgets"}, {Hash: "def", Subject: "Fix gadgets"}}, nil
+	}
+	output := &bytes.Buffer{}
+	svc := NewBumpService(repo, nil, output)
+
+	if _, err := svc.Bump(BumpOptions{BumpType: "minor", PrintChangelog: true}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+
+	if sinceTag != "v1.0.0" {
+		t.Errorf("changelog range started at %q, expected v1.0.0", sinceTag)
+	}
+
+	outputStr := output.String()
+	successIdx := strings.Index(outputStr, "Successfully created tag v1.1.0")
+	changelogIdx := strings.Index(outputStr, "Changes in v1.1.0 since v1.0.0:\n- Add widgets\n- Fix gadgets\n")
+	if successIdx < 0 || changelogIdx < 0 {
+		t.Fatalf("Output missing success message or changelog:\n%v", outputStr)
+	}
+	if changelogIdx < successIdx {
+		t.Errorf("changelog should appear after the success message:\n%v", outputStr)
+	}
+
+	// Changelog is off by default
+	output.Reset()
+	if _, err := svc.Bump(BumpOptions{BumpType: "minor"}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if strings.Contains(output.String(), "Changes in") {
+		t.Errorf("changelog should not be printed by default:\n%v", output.String())
+	}
+}
+
+// TestLatest tests printing the latest local and remote tags
+func TestLatest(t *testing.T) {
+	repo := NewMockRepoWithTags([]string{"v1.0.0", "v1.1.0"})
+	var gotRemote string
+	repo.RemoteTagsFunc = func(remote string) ([]string, error) {
+		gotRemote = remote
+		return []string{"v1.0.0", "v1.2.0", "v2.0.0-beta"}, nil
+	}
+
+	output := &bytes.Buffer{}
+	latest, err := NewBumpService(repo, nil, output).Latest("", false, false)
+	if err != nil {
+		t.Fatalf("Latest() unexpected error = %v", err)
+	}
+	if latest != "v1.1.0" || output.String() != "v1.1.0\n" {
+		t.Errorf("Latest() = %q, output %q, expected v1.1.0", latest, output.String())
+	}
+
+	output.Reset()
+	latest, err = NewBumpService(repo, nil, output).Latest("origin", false, false)
+	if err != nil {
+		t.Fatalf("Latest(origin) unexpected error = %v", err)
+	}
+	if gotRemote != "origin" {
+		t.Errorf("RemoteTags called with %q, expected origin", gotRemote)
+	}
+	if latest != "v2.0.0-beta" {
+		t.Errorf("Latest(origin) = %q, expected v2.0.0-beta", latest)
+	}
+
+	output.Reset()
+	latest, err = NewBumpService(repo, nil, output).Latest("", true, false)
+	if err != nil {
+		t.Fatalf("Latest() with strip prefix unexpected error = %v", err)
+	}
+	if latest != "v1.1.0" || output.String() != "1.1.0\n" {
+		t.Errorf("Latest() with strip prefix = %q, output %q, expected tag v1.1.0 printed as 1.1.0", latest, output.String())
+	}
+
+	if _, err := NewBumpService(NewMockRepoWithTags(nil), nil, &bytes.Buffer{}).Latest("", false, false); err == nil {
+		t.Error("Latest() should error when there are no version tags")
+	}
+}
+
+// TestNormalize tests previewing and applying tag normalization
+func TestNormalize(t *testing.T) {
+	tags := []string{"v1.0.0", "1.1.0", "release-1.2.0"}
+
+	t.Run("Preview makes no changes", func(t *testing.T) {
+		repo := NewMockRepoWithTags(tags)
+		repo.CreateTagFunc = func(string, bump.TagOptions) error {
+			t.Error("preview should not create tags")
+			return nil
+		}
+		repo.DeleteTagFunc = func(string) error {
+			t.Error("preview should not delete tags")
+			return nil
+		}
+		output := &bytes.Buffer{}
+
+		ops, err := NewBumpService(repo, nil, output).Normalize(false, true)
+		if err != nil {
+			t.Fatalf("Normalize() unexpected error = %v", err)
+		}
+		if len(ops) != 2 {
+			t.Errorf("expected 2 planned operations, got %+v", ops)
+		}
+		if !strings.Contains(output.String(), "Would create tag v1.2.0 at release-1.2.0") {
+			t.Errorf("unexpected preview output: %v", output.String())
+		}
+	})
+
+	t.Run("Apply creates and deletes tags", func(t *testing.T) {
+		created := map[string]string{}
+		var deleted []string
+		repo := NewMockRepoWithTags(tags)
+		repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+			created[name] = opts.Target
+			return nil
+		}
+		repo.DeleteTagFunc = func(name string) error {
+			deleted = append(deleted, name)
+			return nil
+		}
+
+		_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Normalize(true, true)
+		if err != nil {
+			t.Fatalf("Normalize() unexpected error = %v", err)
+		}
+
+		expectedCreated := map[string]string{
+			"v1.1.0": "1.1.0^{commit}",
+			"v1.2.0": "release-1.2.0^{commit}",
+		}
+		if len(created) != len(expectedCreated) {
+			t.Errorf("created = %v, expected %v", created, expectedCreated)
+		}
+		for name, target := range expectedCreated {
+			if created[name] != target {
+				t.Errorf("tag %s target = %q, expected %q", name, created[name], target)
+			}
+		}
+		if strings.Join(deleted, ",") != "1.1.0,release-1.2.0" {
+			t.Errorf("deleted = %v, expected [1.1.0 release-1.2.0]", deleted)
+		}
+	})
+}
+
+// TestBump_SkipPrereleasePush tests keeping pre-release tags local under the push policy
+func TestBump_SkipPrereleasePush(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         BumpOptions
+		expectPushed bool
+	}{
+		{
+			name:         "Pre-release is not pushed",
+			opts:         BumpOptions{BumpType: "minor", Suffix: "rc.1", Push: true, SkipPrereleasePush: true},
+			expectPushed: false,
+		},
+		{
+			name:         "Stable release is pushed",
+			opts:         BumpOptions{BumpType: "minor", Push: true, SkipPrereleasePush: true},
+			expectPushed: true,
+		},
+		{
+			name:         "Pre-release is pushed without the policy",
+			opts:         BumpOptions{BumpType: "minor", Suffix: "rc.1", Push: true},
+			expectPushed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pushed := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.PushTagsFunc = func(string) error {
+				pushed = true
+				return nil
+			}
+			output := &bytes.Buffer{}
+
+			result, err := NewBumpService(repo, nil, output).Bump(tt.opts)
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if pushed != tt.expectPushed || result.Pushed != tt.expectPushed {
+				t.Errorf("pushed = %v (result %v), expected %v", pushed, result.Pushed, tt.expectPushed)
+			}
+			if !tt.expectPushed && !strings.Contains(output.String(), "Not pushing pre-release v1.1.0-rc.1") {
+				t.Errorf("Output missing policy message:\n%v", output.String())
+			}
+		})
+	}
+}
+
+// TestBump_BaseFromFile tests a full bump driven by a VERSION file instead of tags
+func TestBump_BaseFromFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	versionFile := filepath.Join(tmpDir, "VERSION")
+	if err := os.WriteFile(versionFile, []byte("1.4.2\n"), 0o644); err != nil {
+		t.Fatalf("failed to create VERSION: %v", err)
+	}
+
+	var events []string
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.PathFunc = func() string { return tmpDir }
+	repo.CreateTagFunc = func(name string, _ bump.TagOptions) error {
+		events = append(events, "tag "+name)
+		return nil
+	}
+	repo.WorktreeFunc = func() (GitWorktree, error) {
+		return &MockGitWorktree{
+			CommitFunc: func(msg string, _ *git.CommitOptions) (plumbing.Hash, error) {
+				events = append(events, "commit "+msg)
+				return plumbing.ZeroHash, nil
+			},
+		}, nil
+	}
+
+	result, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "minor", BaseFromFile: "VERSION"})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+
+	if result.NextTag != "v1.5.0" {
+		t.Errorf("NextTag = %q, expected v1.5.0 from the VERSION file", result.NextTag)
+	}
+	content, err := os.ReadFile(versionFile)
+	if err != nil {
+		t.Fatalf("failed to read VERSION: %v", err)
+	}
+	if string(content) != "1.5.0\n" {
+		t.Errorf("VERSION = %q, expected %q", content, "1.5.0\n")
+	}
+	expectedEvents := []string{"commit Bump version to 1.5.0", "tag v1.5.0"}
+	if strings.Join(events, ",") != strings.Join(expectedEvents, ",") {
+		t.Errorf("events = %v, expected %v", events, expectedEvents)
+	}
+
+	// Dry run leaves the file untouched
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", BaseFromFile: "VERSION", DryRun: true}); err != nil {
+		t.Fatalf("Bump() dry run unexpected error = %v", err)
+	}
+	if content, _ := os.ReadFile(versionFile); string(content) != "1.5.0\n" {
+		t.Errorf("dry run changed VERSION to %q", content)
+	}
+
+	// Paths outside the repository are rejected
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", BaseFromFile: "../VERSION"}); err == nil {
+		t.Error("Bump() expected error for a path outside the repository")
+	}
+}
+
+// TestBump_RemoteResolution tests choosing the push remote when --remote is not given
+func TestBump_RemoteResolution(t *testing.T) {
+	tests := []struct {
+		name         string
+		remotes      []string
+		remote       string
+		expectRemote string
+		expectError  string
+	}{
+		{name: "Single remote", remotes: []string{"upstream"}, expectRemote: "upstream"},
+		{name: "Origin present", remotes: []string{"fork", "origin"}, expectRemote: "origin"},
+		{name: "Explicit remote", remotes: []string{"fork", "upstream"}, remote: "fork", expectRemote: "fork"},
+		{name: "Ambiguous", remotes: []string{"fork", "upstream"}, expectError: "specify one with --remote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pushedTo string
+			tagCreated := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.RemotesFunc = func() ([]string, error) { return tt.remotes, nil }
+			repo.CreateTagFunc = func(string, bump.TagOptions) error {
+				tagCreated = true
+				return nil
+			}
+			repo.PushTagsFunc = func(remote string) error {
+				pushedTo = remote
+				return nil
+			}
+
+			_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", Push: true, Remote: tt.remote})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				if tagCreated {
+					t.Error("tag should not be created when the remote is ambiguous")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if pushedTo != tt.expectRemote {
+				t.Errorf("pushed to %q, expected %q", pushedTo, tt.expectRemote)
+			}
+		})
+	}
+}
+
+// TestPush tests pushing tags to the resolved remote
+func TestPush(t *testing.T) {
+	var pushedTo string
+	repo := NewMockRepoWithTags(nil)
+	repo.RemotesFunc = func() ([]string, error) { return []string{"origin", "upstream"}, nil }
+	repo.PushTagsFunc = func(remote string) error {
+		pushedTo = remote
+		return nil
+	}
+	output := &bytes.Buffer{}
+
+	if err := NewBumpService(repo, nil, output).Push(""); err != nil {
+		t.Fatalf("Push() unexpected error = %v", err)
+	}
+	if pushedTo != "origin" {
+		t.Errorf("pushed to %q, expected origin", pushedTo)
+	}
+	if !strings.Contains(output.String(), "Successfully pushed tags to origin.") {
+		t.Errorf("unexpected output: %v", output.String())
+	}
+}
+
+// TestBump_SuffixTemplate tests expanding date and commit tokens in --suffix
+func TestBump_SuffixTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		suffix      string
+		expectedTag string
+		expectError string
+	}{
+		{name: "Date token", suffix: "nightly.{{.Date}}", expectedTag: "v1.0.1-nightly.20240601"},
+		{name: "SHA token", suffix: "snapshot.{{.ShortSHA}}", expectedTag: "v1.0.1-snapshot.deadbee"},
+		{name: "Invalid rendered suffix", suffix: "{{.Date}}/{{.ShortSHA}}", expectError: "invalid suffix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.HeadHashFunc = func() (string, error) {
+				return "deadbeefcafe0000000000000000000000000000", nil
+			}
+			svc := NewBumpService(repo, nil, &bytes.Buffer{})
+			svc.now = func() time.Time { return time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC) }
+
+			result, err := svc.Bump(BumpOptions{BumpType: "patch", Suffix: tt.suffix})
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if result.NextTag != tt.expectedTag {
+				t.Errorf("NextTag = %q, expected %q", result.NextTag, tt.expectedTag)
+			}
+			if err := validateSuffix(strings.SplitN(result.NextTag, "-", 2)[1], defaultSuffixLimits); err != nil {
+				t.Errorf("rendered suffix is not SemVer-valid: %v", err)
+			}
+		})
+	}
+}
+
+// TestBump_Idempotent tests that re-running a bump on an already tagged HEAD is a no-op
+func TestBump_Idempotent(t *testing.T) {
+	const head = "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name          string
+		tagCommit     string
+		expectNoOp    bool
+		expectedTag   string
+		expectCreated bool
+	}{
+		{name: "Latest tag at HEAD", tagCommit: head, expectNoOp: true, expectedTag: "v1.0.0"},
+		{name: "Latest tag behind HEAD", tagCommit: "fedcba9876543210fedcba9876543210fedcba98", expectedTag: "v1.0.1", expectCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.HeadHashFunc = func() (string, error) { return head, nil }
+			repo.TagCommitFunc = func(tag string) (string, error) { return tt.tagCommit, nil }
+			repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+				created = true
+				return nil
+			}
+			output := &bytes.Buffer{}
+
+			result, err := NewBumpService(repo, nil, output).Bump(BumpOptions{BumpType: "patch", Idempotent: true})
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if result.NoOp != tt.expectNoOp {
+				t.Errorf("NoOp = %v, expected %v", result.NoOp, tt.expectNoOp)
+			}
+			if result.NextTag != tt.expectedTag {
+				t.Errorf("NextTag = %q, expected %q", result.NextTag, tt.expectedTag)
+			}
+			if created != tt.expectCreated {
+				t.Errorf("tag created = %v, expected %v", created, tt.expectCreated)
+			}
+			if tt.expectNoOp && !strings.Contains(output.String(), "Tag v1.0.0 already exists at HEAD — nothing to do") {
+				t.Errorf("output = %q, expected no-op message", output.String())
+			}
+		})
+	}
+
+	t.Run("JSON output reports no-op", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeJSONResult(&buf, &BumpResult{NextTag: "v1.0.0", PreviousTag: "v1.0.0", NoOp: true}); err != nil {
+			t.Fatalf("writeJSONResult() unexpected error = %v", err)
+		}
+		if !strings.Contains(buf.String(), `"noOp": true`) {
+			t.Errorf("JSON output = %s, expected noOp to be true", buf.String())
+		}
+	})
+}
+
+// TestBump_OnlyNew tests pushing just the created tag instead of all tags
+func TestBump_OnlyNew(t *testing.T) {
+	var pushedTag, pushedAll string
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.PushTagFunc = func(remote, name string) error {
+		pushedTag = remote + " " + name
+		return nil
+	}
+	repo.PushTagsFunc = func(remote string) error {
+		pushedAll = remote
+		return nil
+	}
+
+	result, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "minor", Push: true, OnlyNew: true})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if !result.Pushed {
+		t.Error("Pushed = false, expected true")
+	}
+	if pushedTag != "origin v1.1.0" {
+		t.Errorf("PushTag called with %q, expected %q", pushedTag, "origin v1.1.0")
+	}
+	if pushedAll != "" {
+		t.Errorf("PushTags called with %q, expected it not to be called", pushedAll)
+	}
+}
+
+// TestBump_Prerelease tests the prerelease bump type through the service
+func TestBump_Prerelease(t *testing.T) {
+	result, err := NewBumpService(NewMockRepoWithTags([]string{"v1.1.0", "v1.2.0-rc.1"}), nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "prerelease"})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.2.0-rc.2" {
+		t.Errorf("NextTag = %q, expected v1.2.0-rc.2", result.NextTag)
+	}
+
+	_, err = NewBumpService(NewMockRepoWithTags([]string{"v1.2.0"}), nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "prerelease"})
+	if err == nil || !strings.Contains(err.Error(), "no pre-release suffix") {
+		t.Errorf("Bump() error = %v, expected no pre-release suffix error", err)
+	}
+}
+
+// TestBump_DetachedHead tests warning about, or rejecting, a detached HEAD
+func TestBump_DetachedHead(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          BumpOptions
+		expectWarning bool
+		expectError   bool
+	}{
+		{name: "Tag only", opts: BumpOptions{BumpType: "patch"}},
+		{name: "Update file warns", opts: BumpOptions{BumpType: "patch", UpdateFile: "version.go", DryRun: true}, expectWarning: true},
+		{name: "Strict fails", opts: BumpOptions{BumpType: "patch", Strict: true}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.DetachedHeadFunc = func() (bool, error) { return true, nil }
+			output := &bytes.Buffer{}
+
+			_, err := NewBumpService(repo, nil, output).Bump(tt.opts)
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "HEAD is detached") {
+					t.Fatalf("Bump() error = %v, expected detached HEAD error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if warned := strings.Contains(output.String(), "Warning: HEAD is detached"); warned != tt.expectWarning {
+				t.Errorf("output = %q, expected warning %v", output.String(), tt.expectWarning)
+			}
+		})
+	}
+}
+
+// TestBump_HeadAlreadyTagged tests warning about, or rejecting, a second version tag on HEAD
+func TestBump_HeadAlreadyTagged(t *testing.T) {
+	const head = "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name          string
+		taggedAtHead  string
+		strict        bool
+		expectWarning bool
+		expectError   bool
+	}{
+		{name: "HEAD untagged"},
+		{name: "HEAD tagged warns", taggedAtHead: "v1.1.0", expectWarning: true},
+		{name: "HEAD tagged with strict fails", taggedAtHead: "v1.1.0", strict: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0", "v1.1.0", "nightly"})
+			repo.HeadHashFunc = func() (string, error) { return head, nil }
+			repo.TagCommitFunc = func(tag string) (string, error) {
+				if tag == tt.taggedAtHead {
+					return head, nil
+				}
+				return "fedcba9876543210fedcba9876543210fedcba98", nil
+			}
+			repo.CreateTagFunc = func(string, bump.TagOptions) error {
+				created = true
+				return nil
+			}
+			output := &bytes.Buffer{}
+
+			_, err := NewBumpService(repo, nil, output).Bump(BumpOptions{BumpType: "patch", AllowEmpty: true, Strict: tt.strict})
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "HEAD is already tagged v1.1.0") {
+					t.Fatalf("Bump() error = %v, expected already tagged error", err)
+				}
+				if created {
+					t.Error("tag should not be created when HEAD is already tagged under --strict")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			warning := "Warning: HEAD is already tagged v1.1.0; v1.1.1 will point at the same commit"
+			if warned := strings.Contains(output.String(), warning); warned != tt.expectWarning {
+				t.Errorf("output = %q, expected warning %v", output.String(), tt.expectWarning)
+			}
+			if !created {
+				t.Error("expected the tag to be created")
+			}
+		})
+	}
+}
+
+// TestBump_RequireClean tests staged-only and modified-only changes against each clean-tree flag
+func TestBump_RequireClean(t *testing.T) {
+	staged := git.Status{"version.go": {Staging: git.Modified, Worktree: git.Unmodified}}
+	modified := git.Status{"main.go": {Staging: git.Unmodified, Worktree: git.Modified}}
+	untracked := git.Status{"notes.txt": {Staging: git.Untracked, Worktree: git.Untracked}}
+	tests := []struct {
+		name        string
+		status      git.Status
+		opts        BumpOptions
+		expectError string
+	}{
+		{name: "Index flag rejects staged change", status: staged, opts: BumpOptions{RequireCleanIndex: true}, expectError: "index has staged changes: version.go"},
+		{name: "Index flag allows unstaged modification", status: modified, opts: BumpOptions{RequireCleanIndex: true}},
+		{name: "Worktree flag rejects staged change", status: staged, opts: BumpOptions{RequireCleanTree: true}, expectError: "working tree has uncommitted changes: version.go"},
+		{name: "Worktree flag rejects unstaged modification", status: modified, opts: BumpOptions{RequireCleanTree: true}, expectError: "working tree has uncommitted changes: main.go"},
+		{name: "Untracked files are ignored", status: untracked, opts: BumpOptions{RequireCleanIndex: true, RequireCleanTree: true}},
+		{name: "No flags allow changes", status: staged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.WorktreeFunc = func() (GitWorktree, error) {
+				return &MockGitWorktree{StatusFunc: func() (git.Status, error) { return tt.status, nil }}, nil
+			}
+			repo.CreateTagFunc = func(string, bump.TagOptions) error {
+				created = true
+				return nil
+			}
+
+			tt.opts.BumpType = "patch"
+			_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(tt.opts)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				if created {
+					t.Error("tag should not be created with uncommitted changes")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if !created {
+				t.Error("expected the tag to be created")
+			}
+		})
+	}
+}
+
+// TestBump_CheckRemote tests warning when the remote has a newer tag than the local repository
+func TestBump_CheckRemote(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteTags    []string
+		expectWarning bool
+	}{
+		{name: "Remote ahead", remoteTags: []string{"v1.0.0", "v1.1.0"}, expectWarning: true},
+		{name: "Remote in sync", remoteTags: []string{"v1.0.0"}},
+		{name: "Remote behind", remoteTags: []string{"v0.9.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queried string
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.RemoteTagsFunc = func(remote string) ([]string, error) {
+				queried = remote
+				return tt.remoteTags, nil
+			}
+			output := &bytes.Buffer{}
+
+			result, err := NewBumpService(repo, nil, output).Bump(BumpOptions{BumpType: "patch", CheckRemote: true, DryRun: true})
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if queried != "origin" {
+				t.Errorf("RemoteTags called with %q, expected origin", queried)
+			}
+			if result.NextTag != "v1.0.1" {
+				t.Errorf("NextTag = %q, expected v1.0.1", result.NextTag)
+			}
+			warning := "Warning: origin has v1.1.0 but the latest local tag is v1.0.0; run 'git fetch --tags origin'"
+			if warned := strings.Contains(output.String(), warning); warned != tt.expectWarning {
+				t.Errorf("output = %q, expected warning %v", output.String(), tt.expectWarning)
+			}
+		})
+	}
+}
+
+// TestRetagFromRemote tests reporting the tags a remote has beyond the latest
+// local tag, and fetching them only when asked
+func TestRetagFromRemote(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteTags    []string
+		fetch         bool
+		expectedNewer []string
+		expectFetch   bool
+		output        string
+	}{
+		{
+			name:          "Remote ahead",
+			remoteTags:    []string{"v1.0.0", "v1.0.1", "v1.1.0", "v1.1.0-rc.1", "not-a-version"},
+			expectedNewer: []string{"v1.1.0", "v1.1.0-rc.1", "v1.0.1"},
+			output: "origin is ahead of the latest local tag v1.0.0 by 3 tag(s): v1.1.0, v1.1.0-rc.1, v1.0.1\n" +
+				"Pass --fetch (or run 'git fetch --tags origin') so the next bump starts from v1.1.0\n",
+		},
+		{
+			name:          "Remote ahead with fetch",
+			remoteTags:    []string{"v1.0.0", "v1.1.0"},
+			fetch:         true,
+			expectedNewer: []string{"v1.1.0"},
+			expectFetch:   true,
+			output: "origin is ahead of the latest local tag v1.0.0 by 1 tag(s): v1.1.0\n" +
+				"Fetched tags from origin; the next bump starts from v1.1.0\n",
+		},
+		{
+			name:       "Remote in sync",
+			remoteTags: []string{"v0.9.0", "v1.0.0"},
+			fetch:      true,
+			output:     "Local tags are up to date with origin (latest local tag: v1.0.0)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched := false
+			repo := NewMockRepoWithTags([]string{"v0.9.0", "v1.0.0"})
+			repo.RemoteTagsFunc = func(remote string) ([]string, error) {
+				return tt.remoteTags, nil
+			}
+			repo.FetchTagsFunc = func(remote string) error {
+				fetched = remote == "origin"
+				return nil
+			}
+			output := &bytes.Buffer{}
+
+			newer, err := NewBumpService(repo, nil, output).RetagFromRemote("", tt.fetch)
+			if err != nil {
+				t.Fatalf("RetagFromRemote() unexpected error = %v", err)
+			}
+			if strings.Join(newer, ",") != strings.Join(tt.expectedNewer, ",") {
+				t.Errorf("RetagFromRemote() = %v, expected %v", newer, tt.expectedNewer)
+			}
+			if fetched != tt.expectFetch {
+				t.Errorf("fetched = %v, expected %v", fetched, tt.expectFetch)
+			}
+			if output.String() != tt.output {
+				t.Errorf("output = %q, expected %q", output.String(), tt.output)
+			}
+		})
+	}
+}
+
+// TestBump_SuffixPolicy tests that the suffix policy checks the computed tag,
+// however its suffix came about
+func TestBump_SuffixPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		opts        BumpOptions
+		expectError string
+	}{
+		{name: "Explicit suffix", tags: []string{"v1.0.0"}, opts: BumpOptions{BumpType: "major", Suffix: "rc"}, expectError: `suffix "rc" is not allowed on major bumps`},
+		{name: "Channel", tags: []string{"v1.0.0"}, opts: BumpOptions{BumpType: "major", Channel: "beta"}, expectError: `suffix "beta.1" is not allowed`},
+		{name: "Increment pre-release", tags: []string{"v2.0.0-rc.1"}, opts: BumpOptions{BumpType: "major", IncrementPrerelease: true}, expectError: `suffix "rc.2" is not allowed`},
+		{name: "Preserved suffix", tags: []string{"v1.0.0-rc.1"}, opts: BumpOptions{BumpType: "major", PreserveSuffix: true}, expectError: `suffix "rc.1" is not allowed`},
+		{name: "Stable release", tags: []string{"v1.0.0-rc.1"}, opts: BumpOptions{BumpType: "major"}},
+		{name: "Other bump type", tags: []string{"v1.0.0"}, opts: BumpOptions{BumpType: "minor", Suffix: "rc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.SuffixPolicy = "major=stable"
+			tt.opts.DryRun = true
+			_, err := NewBumpService(NewMockRepoWithTags(tt.tags), nil, &bytes.Buffer{}).Bump(tt.opts)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Bump() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestBump_Sign tests passing the sign option to the tag and failing early on bad setup
+func TestBump_Sign(t *testing.T) {
+	var gotOpts bump.TagOptions
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+		gotOpts = opts
+		return nil
+	}
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", Sign: true}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if !gotOpts.Sign {
+		t.Error("expected the tag to be created with Sign set")
+	}
+
+	created := false
+	repo = NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.SigningFormatFunc = func() (string, error) {
+		return "", errors.New("gpg.format is ssh but user.signingkey is not set")
+	}
+	repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+		created = true
+		return nil
+	}
+	_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", Sign: true})
+	if err == nil || !strings.Contains(err.Error(), "user.signingkey is not set") {
+		t.Errorf("Bump() error = %v, expected missing signing key error", err)
+	}
+	if created {
+		t.Error("tag should not be created when the signing setup is invalid")
+	}
+}
+
+// TestBump_Lightweight tests passing the tag type through and rejecting annotations on lightweight tags
+func TestBump_Lightweight(t *testing.T) {
+	var gotOpts bump.TagOptions
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+		gotOpts = opts
+		return nil
+	}
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", Lightweight: true}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if !gotOpts.Lightweight {
+		t.Error("expected the tag to be created with Lightweight set")
+	}
+
+	_, err := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0"}), nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", Lightweight: true, Signoff: true})
+	if err == nil || !strings.Contains(err.Error(), "--annotated") {
+		t.Errorf("Bump() error = %v, expected lightweight annotation error", err)
+	}
+}
+
+// TestBump_Webhook tests the webhook payload and that failures only fail the bump when required
+func TestBump_Webhook(t *testing.T) {
+	t.Run("Payload", func(t *testing.T) {
+		var got WebhookPayload
+		server := newWebhookStub(t, http.StatusOK, &got)
+		svc := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0"}), nil, &bytes.Buffer{})
+		svc.webhook = NewWebhookClient(server.Client())
+		svc.now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+		if _, err := svc.Bump(BumpOptions{BumpType: "minor", Webhook: server.URL}); err != nil {
+			t.Fatalf("Bump() unexpected error = %v", err)
+		}
+		expected := WebhookPayload{Tag: "v1.1.0", PreviousTag: "v1.0.0", RepoPath: "/mock/repo", Timestamp: "2024-06-01T12:00:00Z"}
+		if got != expected {
+			t.Errorf("payload = %+v, expected %+v", got, expected)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		required    bool
+		expectError bool
+	}{
+		{name: "Failure warns"},
+		{name: "Failure is fatal when required", required: true, expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got WebhookPayload
+			server := newWebhookStub(t, http.StatusBadGateway, &got)
+			output := &bytes.Buffer{}
+			svc := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0"}), nil, output)
+			svc.webhook = NewWebhookClient(server.Client())
+
+			result, err := svc.Bump(BumpOptions{BumpType: "patch", Webhook: server.URL, WebhookRequired: tt.required})
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "failed to notify webhook") {
+					t.Fatalf("Bump() error = %v, expected webhook error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if result.NextTag != "v1.0.1" {
+				t.Errorf("NextTag = %q, expected v1.0.1", result.NextTag)
+			}
+			if !strings.Contains(output.String(), "Warning: failed to notify webhook") {
+				t.Errorf("output = %q, expected webhook warning", output.String())
+			}
+		})
+	}
+}
+
+// TestBump_AuditLog tests appending a JSON line per bump to the audit log
+func TestBump_AuditLog(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	svc := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0"}), nil, &bytes.Buffer{})
+	svc.now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	if _, err := svc.Bump(BumpOptions{BumpType: "minor", AuditLog: auditLog}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if _, err := svc.Bump(BumpOptions{BumpType: "patch", AuditLog: auditLog, DryRun: true}); err != nil {
+		t.Fatalf("Bump() dry run unexpected error = %v", err)
+	}
+
+	content, err := os.ReadFile(auditLog)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("audit log has %d lines, expected 1 (dry runs are not recorded): %q", len(lines), content)
+	}
+	var got AuditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("failed to decode audit entry %q: %v", lines[0], err)
+	}
+	expected := AuditEntry{
+		Who:      "Mock User <mock@example.com>",
+		When:     "2024-06-01T12:00:00Z",
+		From:     "v1.0.0",
+		To:       "v1.1.0",
+		RepoPath: "/mock/repo",
+	}
+	if got != expected {
+		t.Errorf("audit entry = %+v, expected %+v", got, expected)
+	}
+}
+
+// TestPreview tests printing the candidate versions from the latest tag
+func TestPreview(t *testing.T) {
+	output := &bytes.Buffer{}
+	svc := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0", "v1.2.3"}), nil, output)
+	if _, err := svc.Preview("", false); err != nil {
+		t.Fatalf("Preview() unexpected error = %v", err)
+	}
+	expected := "current  v1.2.3\npatch    v1.2.4\nminor    v1.3.0\nmajor    v2.0.0\n"
+	if output.String() != expected {
+		t.Errorf("Preview() output = %q, expected %q", output.String(), expected)
+	}
+
+	output.Reset()
+	candidates, err := svc.Preview("", true)
+	if err != nil {
+		t.Fatalf("Preview() with stripPrefix unexpected error = %v", err)
+	}
+	expected = "current  1.2.3\npatch    1.2.4\nminor    1.3.0\nmajor    2.0.0\n"
+	if output.String() != expected {
+		t.Errorf("Preview() with stripPrefix output = %q, expected %q", output.String(), expected)
+	}
+	if candidates[0].Version != "v1.2.4" {
+		t.Errorf("Preview() with stripPrefix returned %s, expected the tag v1.2.4", candidates[0].Version)
+	}
+}
+
+// TestRetag tests moving an existing tag to HEAD
+func TestRetag(t *testing.T) {
+	t.Run("Local and remote retag", func(t *testing.T) {
+		var replaced, pushed string
+		repo := NewMockRepoWithTags([]string{"v1.0.0", "v1.1.0"})
+		repo.ReplaceTagFunc = func(name string, opts bump.TagOptions) error {
+			replaced = name
+			return nil
+		}
+		repo.ForcePushTagFunc = func(remote, name string) error {
+			pushed = remote + " " + name
+			return nil
+		}
+		output := &bytes.Buffer{}
+
+		if err := NewBumpService(repo, nil, output).Retag("v1.1.0", "origin", true); err != nil {
+			t.Fatalf("Retag() unexpected error = %v", err)
+		}
+		if replaced != "v1.1.0" {
+			t.Errorf("replaced tag = %q, expected v1.1.0", replaced)
+		}
+		if pushed != "origin v1.1.0" {
+			t.Errorf("pushed = %q, expected origin v1.1.0", pushed)
+		}
+		if !strings.Contains(output.String(), "Moved tag v1.1.0 to HEAD") {
+			t.Errorf("unexpected output: %v", output.String())
+		}
+	})
+
+	t.Run("Local only", func(t *testing.T) {
+		repo := NewMockRepoWithTags([]string{"v1.0.0"})
+		repo.ForcePushTagFunc = func(string, string) error {
+			t.Error("tag should not be pushed without --remote")
+			return nil
+		}
+		if err := NewBumpService(repo, nil, &bytes.Buffer{}).Retag("v1.0.0", "", true); err != nil {
+			t.Fatalf("Retag() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("Signed tag is signed again", func(t *testing.T) {
+		var got bump.TagOptions
+		repo := NewMockRepoWithTags([]string{"v1.0.0"})
+		repo.ExistingTagOptionsFunc = func(string) (bump.TagOptions, error) {
+			return bump.TagOptions{Message: "Release 1.0.0\n", Sign: true}, nil
+		}
+		repo.ReplaceTagFunc = func(name string, opts bump.TagOptions) error {
+			got = opts
+			return nil
+		}
+		if err := NewBumpService(repo, nil, &bytes.Buffer{}).Retag("v1.0.0", "", true); err != nil {
+			t.Fatalf("Retag() unexpected error = %v", err)
+		}
+		if !got.Sign || got.Message != "Release 1.0.0\n" {
+			t.Errorf("ReplaceTag() options = %+v, expected the signed annotation", got)
+		}
+
+		repo.SigningFormatFunc = func() (string, error) { return "", errors.New("no signing key") }
+		if err := NewBumpService(repo, nil, &bytes.Buffer{}).Retag("v1.0.0", "", true); err == nil || !strings.Contains(err.Error(), "cannot re-sign") {
+			t.Errorf("Retag() without a signing key error = %v, expected to contain %q", err, "cannot re-sign")
+		}
+	})
+
+	t.Run("Type and message are kept", func(t *testing.T) {
+		repoDir, runGit := newGitRepoWithCommits(t)
+		commitFile(t, repoDir, runGit, "a.txt", "First commit")
+		runGit("tag", "-a", "-m", "Release notes for 1.0.0", "v1.0.0")
+		runGit("tag", "v1.1.0")
+		commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+
+		repo, err := NewGoGitRepository(repoDir)
+		if err != nil {
+			t.Fatalf("NewGoGitRepository() error = %v", err)
+		}
+		svc := NewBumpService(repo, nil, &bytes.Buffer{})
+		for _, tag := range []string{"v1.0.0", "v1.1.0"} {
+			if err := svc.Retag(tag, "", true); err != nil {
+				t.Fatalf("Retag(%s) unexpected error = %v", tag, err)
+			}
+		}
+
+		head := runGit("rev-parse", "HEAD")
+		for _, tag := range []string{"v1.0.0", "v1.1.0"} {
+			if got := runGit("rev-parse", tag+"^{commit}"); got != head {
+				t.Errorf("%s points at %s, expected HEAD %s", tag, got, head)
+			}
+		}
+		if got := strings.TrimSpace(runGit("cat-file", "-t", "v1.0.0")); got != "tag" {
+			t.Errorf("v1.0.0 type = %s, expected an annotated tag", got)
+		}
+		if got := runGit("tag", "-l", "--format=%(contents)", "v1.0.0"); strings.TrimSpace(got) != "Release notes for 1.0.0" {
+			t.Errorf("v1.0.0 message = %q, expected the original annotation", got)
+		}
+		if got := strings.TrimSpace(runGit("cat-file", "-t", "v1.1.0")); got != "commit" {
+			t.Errorf("v1.1.0 type = %s, expected a lightweight tag", got)
+		}
+	})
+
+	for _, tt := range []struct {
+		name        string
+		tag         string
+		force       bool
+		expectError string
+	}{
+		{name: "Requires force", tag: "v1.0.0", expectError: "use --force"},
+		{name: "Missing tag", tag: "v2.0.0", force: true, expectError: "does not exist"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.ReplaceTagFunc = func(string, bump.TagOptions) error {
+				t.Error("tag should not be replaced")
+				return nil
+			}
+			err := NewBumpService(repo, nil, &bytes.Buffer{}).Retag(tt.tag, "origin", tt.force)
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Retag() error = %v, expected to contain %q", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestUpdateVersionFile_Amend tests amending the version change into HEAD versus a new commit
+func TestUpdateVersionFile_Amend(t *testing.T) {
+	for _, amend := range []bool{false, true} {
+		t.Run(fmt.Sprintf("amend=%v", amend), func(t *testing.T) {
+			repoDir, runGit := newGitRepoWithCommits(t)
+			if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+				t.Fatalf("failed to write version.go: %v", err)
+			}
+			runGit("add", "version.go")
+			runGit("commit", "-m", "Release work")
+
+			repo, err := NewGoGitRepository(repoDir)
+			if err != nil {
+				t.Fatalf("NewGoGitRepository() error = %v", err)
+			}
+			if err := NewBumpService(repo, nil, &bytes.Buffer{}).UpdateVersionFile("version.go", "v1.0.1", amend); err != nil {
+				t.Fatalf("UpdateVersionFile() unexpected error = %v", err)
+			}
+
+			count := strings.TrimSpace(runGit("rev-list", "--count", "HEAD"))
+			subject := strings.TrimSpace(runGit("log", "-1", "--format=%s"))
+			expectedCount, expectedSubject := "2", "Bump version to 1.0.2-dev"
+			if amend {
+				expectedCount, expectedSubject = "1", "Release work"
+			}
+			if count != expectedCount || subject != expectedSubject {
+				t.Errorf("history = %s commits, HEAD %q; expected %s commits, HEAD %q", count, subject, expectedCount, expectedSubject)
+			}
+			if status := runGit("status", "--porcelain"); status != "" {
+				t.Errorf("working tree should be clean, got: %s", status)
+			}
+		})
+	}
+}
+
+// TestBump_Amend tests that --amend folds the version into the commit that gets
+// tagged, and is refused when it would run after tagging
+func TestBump_Amend(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Release work")
+	runGit("tag", "v1.0.0")
+	commitFile(t, repoDir, runGit, "main.go", "Add main")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	service := NewBumpService(repo, nil, &bytes.Buffer{})
+	if _, err := service.Bump(BumpOptions{BumpType: "patch", UpdateFile: "version.go", Amend: true}); err == nil || !strings.Contains(err.Error(), "--update-before-tag") {
+		t.Fatalf("Bump() --amend after tagging error = %v, expected it to require --update-before-tag", err)
+	}
+	if tags := strings.TrimSpace(runGit("tag", "--points-at", "HEAD")); tags != "" {
+		t.Fatalf("tags at HEAD = %q, expected none after the refused bump", tags)
+	}
+
+	if _, err := service.Bump(BumpOptions{BumpType: "patch", UpdateFile: "version.go", UpdateBeforeTag: true, Amend: true}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	head := strings.TrimSpace(runGit("rev-parse", "HEAD"))
+	if tagged := strings.TrimSpace(runGit("rev-parse", "v1.0.1^{commit}")); tagged != head {
+		t.Errorf("v1.0.1 points at %s, expected HEAD %s", tagged, head)
+	}
+	if subject := strings.TrimSpace(runGit("log", "-1", "--format=%s")); subject != "Add main" {
+		t.Errorf("HEAD subject = %q, expected the amended Add main", subject)
+	}
+	if content := runGit("show", "v1.0.1:version.go"); !strings.Contains(content, `"1.0.1"`) {
+		t.Errorf("tagged version.go = %q, expected 1.0.1", content)
+	}
+}
+
+// TestDev tests creating dev snapshot tags counted from the latest release
+func TestDev(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "README.md", "Initial commit")
+	runGit("tag", "v1.2.3")
+	commitFile(t, repoDir, runGit, "a.go", "Add a")
+	runGit("tag", "v1.2.4-dev.1")
+	commitFile(t, repoDir, runGit, "b.go", "Add b")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	var out bytes.Buffer
+	service := NewBumpService(repo, nil, &out)
+
+	// An earlier snapshot does not become the base of the next one
+	tag, err := service.Dev("", false, true)
+	if err != nil || tag != "v1.2.4-dev.2" {
+		t.Fatalf("Dev() dry run = %q, %v; expected v1.2.4-dev.2", tag, err)
+	}
+	if out.String() != "Would create tag: v1.2.4-dev.2\n" {
+		t.Errorf("Dev() dry run output = %q", out.String())
+	}
+	if _, err := service.Dev("", true, false); !errors.Is(err, ErrNoRemote) {
+		t.Errorf("Dev() push without a remote error = %v, expected ErrNoRemote", err)
+	}
+
+	if _, err := service.Dev("", false, false); err != nil {
+		t.Fatalf("Dev() unexpected error = %v", err)
+	}
+	if tags := strings.TrimSpace(runGit("tag", "--points-at", "HEAD")); tags != "v1.2.4-dev.2" {
+		t.Errorf("tags at HEAD = %q, expected v1.2.4-dev.2", tags)
+	}
+
+	// Snapshots never outrank the next release
+	next, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", DryRun: true})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if next.NextTag != "v1.2.4" || next.PreviousTag != "v1.2.3" {
+		t.Errorf("Bump() = %s from %s, expected v1.2.4 from v1.2.3", next.NextTag, next.PreviousTag)
+	}
+
+	runGit("checkout", "-q", "v1.2.3")
+	if _, err := service.Dev("", false, false); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("Dev() on the release commit error = %v, expected ErrNoChanges", err)
+	}
+}
+
+// TestDevSnapshotsIgnored tests that every command picks the base tag a core bump
+// starts from, leaving a dev snapshot at HEAD out
+func TestDevSnapshotsIgnored(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	versionFile := filepath.Join(repoDir, "version.go")
+	if err := os.WriteFile(versionFile, []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Release work")
+	runGit("tag", "-a", "-m", "v1.0.0", "v1.0.0")
+	if err := os.WriteFile(versionFile, []byte("package main\n\nconst Version = \"1.0.1-dev\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("commit", "-am", "Bump version to 1.0.1-dev")
+	runGit("tag", "v1.0.1-dev.1")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	var out bytes.Buffer
+	svc := NewBumpService(repo, nil, &out)
+
+	candidates, err := svc.Preview("", false)
+	if err != nil {
+		t.Fatalf("Preview() unexpected error = %v", err)
+	}
+	if candidates[0].Version != "v1.0.1" {
+		t.Errorf("Preview() patch = %s, expected v1.0.1", candidates[0].Version)
+	}
+	status, err := svc.Status(nil)
+	if err != nil {
+		t.Fatalf("Status() unexpected error = %v", err)
+	}
+	if status.LatestTag != "v1.0.0" || status.Candidates[0].Version != "v1.0.1" {
+		t.Errorf("Status() = %s, next patch %s; expected v1.0.0, next patch v1.0.1", status.LatestTag, status.Candidates[0].Version)
+	}
+	if latest, err := svc.Latest("", false, false); err != nil || latest != "v1.0.0" {
+		t.Errorf("Latest() = %q, %v; expected v1.0.0", latest, err)
+	}
+	if err := svc.Check("version.go"); err != nil {
+		t.Errorf("Check() unexpected error = %v", err)
+	}
+	if err := svc.RollbackDev(regexp.MustCompile(defaultDevCommitPattern), false, true); err != nil {
+		t.Errorf("RollbackDev() unexpected error = %v", err)
+	}
+
+	out.Reset()
+	result, err := svc.Bump(BumpOptions{BumpType: "patch", Strict: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Bump() with a dev snapshot at HEAD unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.0.1" {
+		t.Errorf("Bump() = %s, expected v1.0.1", result.NextTag)
+	}
+	if strings.Contains(out.String(), "already tagged") {
+		t.Errorf("Bump() warned about the dev snapshot at HEAD: %s", out.String())
+	}
+}
+
+// TestDevAndStatus_SkipMerges tests that merge commits are left out of the dev tag
+// number and the commit count of status
+func TestDevAndStatus_SkipMerges(t *testing.T) {
+	repo := NewMockRepoWithTags([]string{"v1.2.3"})
+	repo.CommitsSinceFunc = func(string) ([]CommitInfo, error) {
+		return []CommitInfo{
+			{Hash: "c3", Subject: "Merge branch 'feature'", IsMerge: true},
+			{Hash: "c2", Subject: "Add b"},
+			{Hash: "c1", Subject: "Add a"},
+		}, nil
+	}
+	service := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	if tag, err := service.Dev("", false, true); err != nil || tag != "v1.2.4-dev.2" {
+		t.Errorf("Dev() = %q, %v; expected v1.2.4-dev.2", tag, err)
+	}
+	status, err := service.Status(nil)
+	if err != nil {
+		t.Fatalf("Status() unexpected error = %v", err)
+	}
+	if status.CommitsSince != 2 {
+		t.Errorf("Status() CommitsSince = %d, expected 2", status.CommitsSince)
+	}
+}
+
+// TestBump_NoRemote tests that pushing from a repository without remotes fails
+// with a hint before any tag is created
+func TestBump_NoRemote(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "README.md", "Initial commit")
+	runGit("tag", "v1.0.0")
+	commitFile(t, repoDir, runGit, "main.go", "Add main")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	service := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	_, err = service.Bump(BumpOptions{BumpType: "patch", Push: true})
+	if !errors.Is(err, ErrNoRemote) || !strings.Contains(err.Error(), "git remote add") {
+		t.Errorf("Bump() error = %v, expected ErrNoRemote with a git remote add hint", err)
+	}
+	if tags := strings.TrimSpace(runGit("tag", "--list")); tags != "v1.0.0" {
+		t.Errorf("tags = %q, expected no new tag", tags)
+	}
+
+	if err := service.Push(""); !errors.Is(err, ErrNoRemote) || !strings.Contains(err.Error(), "git remote add") {
+		t.Errorf("Push() error = %v, expected ErrNoRemote with a git remote add hint", err)
+	}
+}
+
+// TestDevVersion_DirtySuffix tests that the dirty marker is only appended when tracked files have changes
+func TestDevVersion_DirtySuffix(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		modify   bool
+		expected string
+	}{
+		{name: "Clean worktree", expected: "1.0.2-dev"},
+		{name: "Dirty worktree", modify: true, expected: "1.0.2-dev.dirty"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir, runGit := newGitRepoWithCommits(t)
+			if err := os.WriteFile(filepath.Join(repoDir, "notes.txt"), []byte("notes\n"), 0o644); err != nil {
+				t.Fatalf("failed to write notes.txt: %v", err)
+			}
+			runGit("add", "notes.txt")
+			runGit("commit", "-m", "Add notes")
+			// Untracked files do not make the worktree dirty
+			if err := os.WriteFile(filepath.Join(repoDir, "scratch.txt"), []byte("scratch\n"), 0o644); err != nil {
+				t.Fatalf("failed to write scratch.txt: %v", err)
+			}
+			if tt.modify {
+				if err := os.WriteFile(filepath.Join(repoDir, "notes.txt"), []byte("edited\n"), 0o644); err != nil {
+					t.Fatalf("failed to modify notes.txt: %v", err)
+				}
+			}
+
+			repo, err := NewGoGitRepository(repoDir)
+			if err != nil {
+				t.Fatalf("NewGoGitRepository() error = %v", err)
+			}
+			got, err := NewBumpService(repo, nil, &bytes.Buffer{}).devVersion("v1.0.1", "dirty")
+			if err != nil {
+				t.Fatalf("devVersion() unexpected error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("devVersion() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSyncFile tests detecting a version file that drifted from the latest tag and healing it
+func TestSyncFile(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		fileVersion string
+		expected    string
+		updated     bool
+	}{
+		{name: "Drifted dev version", fileVersion: "1.2.4-dev", expected: "1.3.1-dev", updated: true},
+		{name: "Dev version in sync", fileVersion: "1.3.1-dev", expected: "1.3.1-dev"},
+		{name: "Released version in sync", fileVersion: "1.3.0", expected: "1.3.0"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir, runGit := newGitRepoWithCommits(t)
+			content := fmt.Sprintf("package main\n\nconst Version = %q\n", tt.fileVersion)
+			if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte(content), 0o644); err != nil {
+				t.Fatalf("failed to write version.go: %v", err)
+			}
+			runGit("add", "version.go")
+			runGit("commit", "-m", "Release work")
+			runGit("tag", "v1.3.0")
+
+			repo, err := NewGoGitRepository(repoDir)
+			if err != nil {
+				t.Fatalf("NewGoGitRepository() error = %v", err)
+			}
+			updater := NewVersionFileUpdater()
+			updated, err := NewBumpService(repo, updater, &bytes.Buffer{}).SyncFile("version.go", false)
+			if err != nil {
+				t.Fatalf("SyncFile() unexpected error = %v", err)
+			}
+			if updated != tt.updated {
+				t.Errorf("SyncFile() updated = %v, expected %v", updated, tt.updated)
+			}
+
+			node, _, err := updater.ParseGoFile(filepath.Join(repoDir, "version.go"))
+			if err != nil {
+				t.Fatalf("ParseGoFile() error = %v", err)
+			}
+			if got, err := updater.ReadVersionConstant(node); err != nil || got != tt.expected {
+				t.Errorf("Version = %q, %v; expected %q", got, err, tt.expected)
+			}
+			count := strings.TrimSpace(runGit("rev-list", "--count", "HEAD"))
+			if expectedCount := map[bool]string{false: "1", true: "2"}[tt.updated]; count != expectedCount {
+				t.Errorf("history has %s commits, expected %s", count, expectedCount)
+			}
+			if status := runGit("status", "--porcelain"); status != "" {
+				t.Errorf("working tree should be clean, got: %s", status)
+			}
+		})
+	}
+}
+
+// TestUpdateVersionFile_PackageJSON tests updating package.json and its lockfile in one commit
+func TestUpdateVersionFile_PackageJSON(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	manifest := "{\n    \"name\": \"widgets\",\n    \"version\": \"1.0.0\"\n}\n"
+	lock := "{\n  \"version\": \"1.0.0\",\n  \"packages\": {\n    \"\": {\"version\": \"1.0.0\"}\n  }\n}\n"
+	for name, content := range map[string]string{"package.json": manifest, "package-lock.json": lock} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	runGit("add", ".")
+	runGit("commit", "-m", "Add package")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	if err := NewBumpService(repo, nil, &bytes.Buffer{}).UpdateVersionFile("package.json", "v1.0.1", false); err != nil {
+		t.Fatalf("UpdateVersionFile() unexpected error = %v", err)
+	}
+
+	changed := strings.Fields(runGit("show", "--name-only", "--format=", "HEAD"))
+	if !slices.Equal(changed, []string{"package-lock.json", "package.json"}) {
+		t.Errorf("HEAD changed %v, expected package.json and package-lock.json", changed)
+	}
+	got, err := os.ReadFile(filepath.Join(repoDir, "package.json"))
+	if err != nil {
+		t.Fatalf("failed to read package.json: %v", err)
+	}
+	if expected := strings.Replace(manifest, "1.0.0", "1.0.2-dev", 1); string(got) != expected {
+		t.Errorf("package.json =\n%s\nexpected\n%s", got, expected)
+	}
+	if status := runGit("status", "--porcelain"); status != "" {
+		t.Errorf("working tree should be clean, got: %s", status)
+	}
+}
+
+// TestUpdateVersionFile_TOML tests updating and checking the version of a Cargo.toml
+func TestUpdateVersionFile_TOML(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	manifest := "[package]\nname = \"widgets\"\nversion = \"1.0.0\"\n\n[dependencies]\nrand = { version = \"0.8\" }\n"
+	if err := os.WriteFile(filepath.Join(repoDir, "Cargo.toml"), []byte(manifest), 0o644); err != nil {
+		t.Fatalf("failed to write Cargo.toml: %v", err)
+	}
+	runGit("add", ".")
+	runGit("commit", "-m", "Add crate")
+	runGit("tag", "v1.0.1")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	service := NewBumpService(repo, nil, &bytes.Buffer{})
+	if err := service.UpdateVersionFile("Cargo.toml", "v1.0.1", false); err != nil {
+		t.Fatalf("UpdateVersionFile() unexpected error = %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(repoDir, "Cargo.toml"))
+	if err != nil {
+		t.Fatalf("failed to read Cargo.toml: %v", err)
+	}
+	if expected := strings.Replace(manifest, "1.0.0", "1.0.2-dev", 1); string(got) != expected {
+		t.Errorf("Cargo.toml =\n%s\nexpected\n%s", got, expected)
+	}
+	if subject := strings.TrimSpace(runGit("log", "-1", "--format=%s")); subject != "Bump version to 1.0.2-dev" {
+		t.Errorf("HEAD subject = %q, expected the version bump commit", subject)
+	}
+	if err := service.Check("Cargo.toml"); err != nil {
+		t.Errorf("Check() unexpected error = %v", err)
+	}
+}
+
+// TestBump_UpdateBeforeTag tests whether the version file is committed before or after tagging
+func TestBump_UpdateBeforeTag(t *testing.T) {
+	tests := []struct {
+		name            string
+		updateBeforeTag bool
+		expectedEvents  []string
+	}{
+		{
+			name:           "Tag first",
+			expectedEvents: []string{"tag v1.0.1", "commit Bump version to 1.0.2-dev"},
+		},
+		{
+			name:            "Update before tag",
+			updateBeforeTag: true,
+			expectedEvents:  []string{"commit Bump version to 1.0.1", "tag v1.0.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "version.go"), []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			var events []string
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.PathFunc = func() string { return tmpDir }
+			repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+				events = append(events, "tag "+name)
+				return nil
+			}
+			repo.WorktreeFunc = func() (GitWorktree, error) {
+				return &MockGitWorktree{CommitFunc: func(msg string, opts *git.CommitOptions) (plumbing.Hash, error) {
+					events = append(events, "commit "+msg)
+					return plumbing.ZeroHash, nil
+				}}, nil
+			}
+
+			opts := BumpOptions{BumpType: "patch", UpdateFile: "version.go", UpdateBeforeTag: tt.updateBeforeTag}
+			if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts); err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if !slices.Equal(events, tt.expectedEvents) {
+				t.Errorf("events = %q, expected %q", events, tt.expectedEvents)
+			}
+		})
+	}
+}
+
+// TestBump_FileVersion tests writing the development version after tagging, or the
+// released version before tagging so the tag includes it
+func TestBump_FileVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		fileVersion     string
+		expectedVersion string
+		beforeTag       bool
+		expectError     bool
+	}{
+		{name: "Default", fileVersion: "", expectedVersion: "1.0.2-dev"},
+		{name: "Dev", fileVersion: "dev", expectedVersion: "1.0.2-dev"},
+		{name: "Release", fileVersion: "release", expectedVersion: "1.0.1", beforeTag: true},
+		{name: "Invalid", fileVersion: "stable", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			versionPath := filepath.Join(tmpDir, "version.go")
+			if err := os.WriteFile(versionPath, []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			var events []string
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.PathFunc = func() string { return tmpDir }
+			repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+				events = append(events, "tag "+name)
+				return nil
+			}
+			repo.WorktreeFunc = func() (GitWorktree, error) {
+				return &MockGitWorktree{CommitFunc: func(msg string, opts *git.CommitOptions) (plumbing.Hash, error) {
+					events = append(events, "commit "+msg)
+					return plumbing.ZeroHash, nil
+				}}, nil
+			}
+
+			opts := BumpOptions{BumpType: "patch", UpdateFile: "version.go", FileVersion: tt.fileVersion}
+			_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts)
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "--file-version") {
+					t.Errorf("Bump() error = %v, expected an invalid --file-version error", err)
+				}
+				if len(events) > 0 {
+					t.Errorf("events = %q, expected nothing to happen", events)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+
+			expectedEvents := []string{"tag v1.0.1", "commit Bump version to " + tt.expectedVersion}
+			if tt.beforeTag {
+				expectedEvents = []string{"commit Bump version to " + tt.expectedVersion, "tag v1.0.1"}
+			}
+			if !slices.Equal(events, expectedEvents) {
+				t.Errorf("events = %q, expected %q", events, expectedEvents)
+			}
+			content, err := os.ReadFile(versionPath)
+			if err != nil {
+				t.Fatalf("failed to read version.go: %v", err)
+			}
+			if !strings.Contains(string(content), `Version = "`+tt.expectedVersion+`"`) {
+				t.Errorf("version.go = %q, expected Version %s", content, tt.expectedVersion)
+			}
+		})
+	}
+}
+
+// TestRollbackDev tests reverting or resetting the development version commit
+func TestRollbackDev(t *testing.T) {
+	tests := []struct {
+		name         string
+		reset        bool
+		dryRun       bool
+		laterFile    string // file committed after the dev bump, if any
+		expectError  string
+		expectOutput string
+		expectHead   string // subject of HEAD afterwards
+	}{
+		{
+			name:         "Revert",
+			laterFile:    "notes.txt",
+			expectOutput: "Reverted",
+			expectHead:   `Revert "Bump version to 1.0.1-dev"`,
+		},
+		{
+			name:         "Reset",
+			reset:        true,
+			expectOutput: "Reset HEAD to",
+			expectHead:   "Release work",
+		},
+		{
+			name:         "Dry run",
+			dryRun:       true,
+			expectOutput: "Would revert",
+			expectHead:   "Bump version to 1.0.1-dev",
+		},
+		{
+			name:        "Reset requires the commit at HEAD",
+			reset:       true,
+			laterFile:   "notes.txt",
+			expectError: "is not HEAD",
+		},
+		{
+			name:        "File changed after the commit",
+			laterFile:   "version.go",
+			expectError: "version.go was changed by a later commit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir, runGit := newGitRepoWithCommits(t)
+			versionFile := filepath.Join(repoDir, "version.go")
+			release := "package main\n\nconst Version = \"1.0.0\"\n"
+			if err := os.WriteFile(versionFile, []byte(release), 0o644); err != nil {
+				t.Fatalf("failed to write version.go: %v", err)
+			}
+			runGit("add", "version.go")
+			runGit("commit", "-m", "Release work")
+			runGit("tag", "-a", "-m", "v1.0.0", "v1.0.0")
+			if err := os.WriteFile(versionFile, []byte(strings.Replace(release, "1.0.0", "1.0.1-dev", 1)), 0o644); err != nil {
+				t.Fatalf("failed to write version.go: %v", err)
+			}
+			runGit("commit", "-am", "Bump version to 1.0.1-dev")
+			if tt.laterFile != "" {
+				commitFile(t, repoDir, runGit, tt.laterFile, "Later work")
+			}
+			before := runGit("rev-parse", "HEAD")
+
+			repo, err := NewGoGitRepository(repoDir)
+			if err != nil {
+				t.Fatalf("NewGoGitRepository() error = %v", err)
+			}
+			output := &bytes.Buffer{}
+			err = NewBumpService(repo, nil, output).RollbackDev(regexp.MustCompile(defaultDevCommitPattern), tt.reset, tt.dryRun)
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("RollbackDev() error = %v, expected %q", err, tt.expectError)
+				}
+				if after := runGit("rev-parse", "HEAD"); after != before {
+					t.Error("HEAD should not move when the rollback fails")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RollbackDev() unexpected error = %v", err)
+			}
+			if !strings.Contains(output.String(), tt.expectOutput) {
+				t.Errorf("output = %q, expected to contain %q", output.String(), tt.expectOutput)
+			}
+			if subject := strings.TrimSpace(runGit("log", "-1", "--format=%s")); subject != tt.expectHead {
+				t.Errorf("HEAD subject = %q, expected %q", subject, tt.expectHead)
+			}
+			if tt.dryRun {
+				return
+			}
+			content, err := os.ReadFile(versionFile)
+			if err != nil {
+				t.Fatalf("failed to read version.go: %v", err)
+			}
+			if string(content) != release {
+				t.Errorf("version.go = %q, expected the released version restored", content)
+			}
+			if status := runGit("status", "--porcelain"); status != "" {
+				t.Errorf("working tree should be clean, got: %s", status)
+			}
+		})
+	}
+}
+
+// TestBump_TagName tests that --tag-name is tagged literally and validated first
+func TestBump_TagName(t *testing.T) {
+	var created []string
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+		created = append(created, name)
+		return nil
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	result, err := svc.Bump(BumpOptions{BumpType: "patch", TagName: "2024-spring-release"})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if result.NextTag != "2024-spring-release" || !slices.Equal(created, []string{"2024-spring-release"}) {
+		t.Errorf("NextTag = %q, created %q; expected the literal tag name", result.NextTag, created)
+	}
+
+	created = nil
+	if _, err := svc.Bump(BumpOptions{BumpType: "patch", TagName: "bad name"}); err == nil || !strings.Contains(err.Error(), "invalid --tag-name") {
+		t.Errorf("Bump() error = %v, expected invalid --tag-name", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("created %q, expected no tag for an invalid name", created)
+	}
+}
+
+// TestBump_Explain tests that --explain reports the tags considered and the inputs to the next version
+func TestBump_Explain(t *testing.T) {
+	output := &bytes.Buffer{}
+	repo := NewMockRepoWithTags([]string{"v1.2.3", "v1.2.0", "nightly", "release-candidate"})
+
+	result, err := NewBumpService(repo, nil, output).Bump(BumpOptions{BumpType: "minor", DryRun: true, Explain: true})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	expected := "Latest tag: v1.2.3 (from 4 tags; skipped as non-semver: nightly, release-candidate). Bump type: minor. Suffix: none. Result: v1.3.0.\n"
+	if result.NextTag != "v1.3.0" || !strings.Contains(output.String(), expected) {
+		t.Errorf("output = %q, expected to contain %q", output.String(), expected)
+	}
+	if result.TagScan != (TagScanCounts{Scanned: 4, Versions: 2, Skipped: 2}) {
+		t.Errorf("TagScan = %+v, expected 4 scanned, 2 versions, 2 skipped", result.TagScan)
+	}
+}
+
+// TestBump_NoPrereleaseBase tests that the policy rejects bumping off an rc unless the base is explicit
+func TestBump_NoPrereleaseBase(t *testing.T) {
+	repo := NewMockRepoWithTags([]string{"v1.2.0-rc.1"})
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	_, err := svc.Bump(BumpOptions{BumpType: "minor", NoPrereleaseBase: true})
+	if err == nil || !strings.Contains(err.Error(), "--allow-prerelease-as-base") {
+		t.Errorf("Bump() error = %v, expected a pre-release base error", err)
+	}
+
+	result, err := svc.Bump(BumpOptions{BumpType: "minor"})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.3.0" {
+		t.Errorf("NextTag = %q, expected v1.3.0 when pre-release bases are allowed", result.NextTag)
+	}
+}
+
+// TestBump_AlsoTag tests passing aliases to the tag, force-pushing them, and rejecting invalid names
+func TestBump_AlsoTag(t *testing.T) {
+	var gotOpts bump.TagOptions
+	var forcePushed []string
+	repo := NewMockRepoWithTags([]string{"v1.2.2"})
+	repo.CreateTagFunc = func(_ string, opts bump.TagOptions) error {
+		gotOpts = opts
+		return nil
+	}
+	repo.ForcePushTagFunc = func(_, name string) error {
+		forcePushed = append(forcePushed, name)
+		return nil
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	if _, err := svc.Bump(BumpOptions{BumpType: "patch", Push: true, Remote: "origin", AlsoTag: []string{"v1", "latest"}}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if !slices.Equal(gotOpts.Aliases, []string{"v1", "latest"}) || !slices.Equal(forcePushed, []string{"v1", "latest"}) {
+		t.Errorf("aliases = %v, force-pushed %v; expected v1 and latest", gotOpts.Aliases, forcePushed)
+	}
+
+	for _, aliases := range [][]string{{"bad name"}, {"v1.2.3"}, {"v1", "v1"}} {
+		if _, err := svc.Bump(BumpOptions{BumpType: "patch", AlsoTag: aliases}); err == nil || !strings.Contains(err.Error(), "--also-tag") {
+			t.Errorf("Bump() with aliases %q error = %v, expected an --also-tag error", aliases, err)
+		}
+	}
+}
+
+// TestBump_Constants tests setting extra constants in the update file alongside Version
+func TestBump_Constants(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	content := "package main\n\nconst (\n\tVersion      = \"1.1.1-dev\"\n\tBuildVersion = \"1.1.0\"\n)\n"
+	if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Release work")
+	runGit("tag", "v1.1.0")
+	commitFile(t, repoDir, runGit, "feature.txt", "Add feature")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	if _, err := svc.Bump(BumpOptions{BumpType: "minor", Constants: []string{"BuildVersion=release"}}); err == nil || !strings.Contains(err.Error(), "--update-file") {
+		t.Errorf("Bump() without --update-file error = %v, expected to require it", err)
+	}
+
+	if _, err := svc.Bump(BumpOptions{BumpType: "minor", UpdateFile: "version.go", Constants: []string{"BuildVersion=release"}}); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	updated, err := os.ReadFile(filepath.Join(repoDir, "version.go"))
+	if err != nil {
+		t.Fatalf("failed to read version.go: %v", err)
+	}
+	for _, want := range []string{`Version      = "1.2.1-dev"`, `BuildVersion = "1.2.0"`} {
+		if !strings.Contains(string(updated), want) {
+			t.Errorf("version.go missing %s:\n%s", want, updated)
+		}
+	}
+}
+
+// TestBump_ShowCommands tests printing the git commands a dry run would execute without running them
+func TestBump_ShowCommands(t *testing.T) {
+	repo := NewMockRepoWithTags([]string{"v1.2.2"})
+	repo.CreateTagFunc = func(string, bump.TagOptions) error {
+		t.Error("CreateTag() should not be called in a dry run")
+		return nil
+	}
+	output := &bytes.Buffer{}
+	svc := NewBumpService(repo, nil, output)
+
+	opts := BumpOptions{BumpType: "patch", DryRun: true, ShowCommands: true, Push: true, Remote: "origin", OnlyNew: true, AlsoTag: []string{"v1"}}
+	if _, err := svc.Bump(opts); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	expected := "Would run:\n" +
+		"  git tag -m v1.2.3 v1.2.3\n" +
+		"  git tag -f -m v1 v1 'v1.2.3^{commit}'\n" +
+		"  git push --force origin refs/tags/v1:refs/tags/v1\n" +
+		"  git push origin refs/tags/v1.2.3:refs/tags/v1.2.3\n"
+	if !strings.Contains(output.String(), expected) {
+		t.Errorf("output = %q, expected it to contain %q", output.String(), expected)
+	}
+}
+
+// TestBump_ReleaseBranch tests cutting release/<tag> so the version commit and tag land on it once confirmed
+func TestBump_ReleaseBranch(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte("package main\n\nconst Version = \"1.1.1-dev\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Release work")
+	runGit("tag", "v1.1.0")
+	commitFile(t, repoDir, runGit, "feature.txt", "Add feature")
+	mainBranch := strings.TrimSpace(runGit("branch", "--show-current"))
+	mainHead := strings.TrimSpace(runGit("rev-parse", "HEAD"))
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+	opts := BumpOptions{BumpType: "minor", UpdateFile: "version.go", UpdateBeforeTag: true, ReleaseBranch: true}
+
+	if _, err := svc.Bump(opts); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("Bump() without confirmation error = %v, expected to require --yes", err)
+	}
+	var asked string
+	opts.Confirm = func(question string) (bool, error) {
+		asked = question
+		return false, nil
+	}
+	if _, err := svc.Bump(opts); err == nil || !strings.Contains(err.Error(), "release/v1.2.0") {
+		t.Fatalf("Bump() with a declined prompt error = %v, expected a release/v1.2.0 error", err)
+	}
+	if asked != "Create and switch to release/v1.2.0?" {
+		t.Errorf("question = %q, expected to ask about release/v1.2.0", asked)
+	}
+	if branch := strings.TrimSpace(runGit("branch", "--show-current")); branch != mainBranch {
+		t.Fatalf("branch = %s after refused bump, expected %s", branch, mainBranch)
+	}
+
+	opts.Confirm = confirmYes
+	if _, err := svc.Bump(opts); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if branch := strings.TrimSpace(runGit("branch", "--show-current")); branch != "release/v1.2.0" {
+		t.Errorf("branch = %s, expected release/v1.2.0", branch)
+	}
+	if branches := strings.TrimSpace(runGit("branch", "--format=%(refname:short)", "--contains", "v1.2.0")); branches != "release/v1.2.0" {
+		t.Errorf("branches containing v1.2.0 = %q, expected only release/v1.2.0", branches)
+	}
+	if head := strings.TrimSpace(runGit("rev-parse", mainBranch)); head != mainHead {
+		t.Errorf("%s moved to %s, expected it to stay at %s", mainBranch, head, mainHead)
+	}
+}
+
+// confirmYes answers yes to every confirmation.
+func confirmYes(string) (bool, error) { return true, nil }
+
+// failingTagRepo is a real repository whose tag creation always fails.
+type failingTagRepo struct {
+	*GoGitRepository
+}
+
+// CreateTag fails without creating the tag.
+func (r failingTagRepo) CreateTag(string, bump.TagOptions) error {
+	return errors.New("tag refused")
+}
+
+// TestBump_ReleaseBranchTagFailure tests that a failed tag switches back from the
+// release branch and deletes it, leaving the repository as it was
+func TestBump_ReleaseBranchTagFailure(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	if err := os.WriteFile(filepath.Join(repoDir, "version.go"), []byte("package main\n\nconst Version = \"1.1.0\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write version.go: %v", err)
+	}
+	runGit("add", "version.go")
+	runGit("commit", "-m", "Release work")
+	runGit("tag", "v1.1.0")
+	commitFile(t, repoDir, runGit, "feature.txt", "Add feature")
+	mainBranch := strings.TrimSpace(runGit("branch", "--show-current"))
+	mainHead := strings.TrimSpace(runGit("rev-parse", "HEAD"))
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	opts := BumpOptions{BumpType: "minor", UpdateFile: "version.go", UpdateBeforeTag: true, ReleaseBranch: true, Confirm: confirmYes}
+	if _, err := NewBumpService(failingTagRepo{repo}, nil, &bytes.Buffer{}).Bump(opts); err == nil {
+		t.Fatal("Bump() expected an error when the tag fails")
+	}
+
+	if branch := strings.TrimSpace(runGit("branch", "--show-current")); branch != mainBranch {
+		t.Errorf("branch = %s after failed bump, expected %s", branch, mainBranch)
+	}
+	if branches := strings.TrimSpace(runGit("branch", "--list", "release/*")); branches != "" {
+		t.Errorf("release branches = %q, expected none", branches)
+	}
+	if head := strings.TrimSpace(runGit("rev-parse", "HEAD")); head != mainHead {
+		t.Errorf("HEAD = %s, expected %s", head, mainHead)
+	}
+	if status := strings.TrimSpace(runGit("status", "--porcelain")); status != "" {
+		t.Errorf("working tree not clean after failed bump: %s", status)
+	}
+}
+
+// TestBump_ChangelogMerges tests that merge commits are left out of the changelog unless included
+func TestBump_ChangelogMerges(t *testing.T) {
+	for _, includeMerges := range []bool{false, true} {
+		t.Run(fmt.Sprintf("includeMerges=%v", includeMerges), func(t *testing.T) {
+			repoDir, runGit := newGitRepoWithCommits(t)
+			commitFile(t, repoDir, runGit, "a.txt", "Initial commit")
+			runGit("tag", "v1.0.0")
+			mainBranch := strings.TrimSpace(runGit("branch", "--show-current"))
+			runGit("checkout", "-b", "feature")
+			commitFile(t, repoDir, runGit, "feature.txt", "Add feature")
+			runGit("checkout", mainBranch)
+			commitFile(t, repoDir, runGit, "fix.txt", "Fix bug")
+			runGit("merge", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+
+			repo, err := NewGoGitRepository(repoDir)
+			if err != nil {
+				t.Fatalf("NewGoGitRepository() error = %v", err)
+			}
+			out := &bytes.Buffer{}
+			opts := BumpOptions{BumpType: "minor", PrintChangelog: true, IncludeMerges: includeMerges}
+			if _, err := NewBumpService(repo, nil, out).Bump(opts); err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if got := strings.Contains(out.String(), "- Merge branch 'feature'"); got != includeMerges {
+				t.Errorf("changelog lists merge commit = %v, expected %v; output:\n%s", got, includeMerges, out.String())
+			}
+			for _, subject := range []string{"- Add feature", "- Fix bug"} {
+				if !strings.Contains(out.String(), subject) {
+					t.Errorf("changelog missing %q; output:\n%s", subject, out.String())
+				}
+			}
+		})
+	}
+}
+
+// TestBump_ReleaseCommit tests that the tag points at a new empty release commit
+// and that a dirty working tree is refused
+func TestBump_ReleaseCommit(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "Initial commit")
+	runGit("tag", "v1.0.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Add feature")
+	before := strings.TrimSpace(runGit("rev-parse", "HEAD"))
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	opts := BumpOptions{BumpType: "minor", ReleaseCommit: true}
+
+	// Staged changes would end up in the release commit
+	if err := os.WriteFile(filepath.Join(repoDir, "b.txt"), []byte("changed"), 0o644); err != nil {
+		t.Fatalf("failed to write b.txt: %v", err)
+	}
+	runGit("add", "b.txt")
+	_, err = NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts)
+	if err == nil || !strings.Contains(err.Error(), "b.txt") {
+		t.Fatalf("Bump() error = %v, expected a dirty tree error naming b.txt", err)
+	}
+	runGit("reset", "--hard")
+
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts); err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if subject := strings.TrimSpace(runGit("log", "-1", "--format=%s", "v1.1.0")); subject != "Release v1.1.0" {
+		t.Errorf("tagged commit subject = %q, expected %q", subject, "Release v1.1.0")
+	}
+	if parent := strings.TrimSpace(runGit("rev-parse", "v1.1.0^{commit}^")); parent != before {
+		t.Errorf("release commit parent = %s, expected previous HEAD %s", parent, before)
+	}
+	if diff := strings.TrimSpace(runGit("diff", "--name-only", before, "v1.1.0")); diff != "" {
+		t.Errorf("release commit changed files: %s", diff)
+	}
+}
+
+// TestBump_SingleWriter tests that a busy lock is a no-op only in single-writer mode
+func TestBump_SingleWriter(t *testing.T) {
+	for _, singleWriter := range []bool{false, true} {
+		t.Run(fmt.Sprintf("singleWriter=%v", singleWriter), func(t *testing.T) {
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.CreateTagFunc = func(string, bump.TagOptions) error {
+				return fmt.Errorf("failed to acquire git lock: %w", bump.ErrLockBusy)
+			}
+			output := &bytes.Buffer{}
+
+			result, err := NewBumpService(repo, nil, output).Bump(BumpOptions{BumpType: "patch", Push: true, SingleWriter: singleWriter})
+			if !singleWriter {
+				if !errors.Is(err, bump.ErrLockBusy) {
+					t.Errorf("Bump() error = %v, expected ErrLockBusy", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if !result.NoOp || result.Pushed || result.NextTag != "v1.0.1" {
+				t.Errorf("result = %+v, expected an unpushed no-op for v1.0.1", result)
+			}
+			if !strings.Contains(output.String(), "nothing to do") {
+				t.Errorf("output = %q, expected no-op message", output.String())
+			}
+		})
+	}
+}
+
+// TestBump_SingleWriterProbe tests that a single-writer job that loses the lock
+// stops before creating the release branch, the release commit or the tag
+func TestBump_SingleWriterProbe(t *testing.T) {
+	var changes []string
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.ProbeLockFunc = func() error {
+		return fmt.Errorf("failed to acquire git lock: %w", bump.ErrLockBusy)
+	}
+	repo.CreateBranchFunc = func(name string) error {
+		changes = append(changes, "branch "+name)
+		return nil
+	}
+	repo.CreateTagFunc = func(name string, opts bump.TagOptions) error {
+		changes = append(changes, "tag "+name)
+		return nil
+	}
+	repo.WorktreeFunc = func() (GitWorktree, error) {
+		return &MockGitWorktree{CommitFunc: func(msg string, opts *git.CommitOptions) (plumbing.Hash, error) {
+			changes = append(changes, "commit "+msg)
+			return plumbing.ZeroHash, nil
+		}}, nil
+	}
+	output := &bytes.Buffer{}
+
+	opts := BumpOptions{BumpType: "patch", SingleWriter: true, ReleaseBranch: true, Confirm: confirmYes, ReleaseCommit: true}
+	result, err := NewBumpService(repo, nil, output).Bump(opts)
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if !result.NoOp || result.NextTag != "v1.0.1" {
+		t.Errorf("result = %+v, expected a no-op for v1.0.1", result)
+	}
+	if len(changes) > 0 {
+		t.Errorf("losing job changed the repository: %v", changes)
+	}
+	if !strings.Contains(output.String(), "nothing to do") {
+		t.Errorf("output = %q, expected no-op message", output.String())
+	}
+}
+
+// TestUpdateVersionFile_AmendPushedWarning tests the warning when amending a pushed HEAD
+func TestUpdateVersionFile_AmendPushedWarning(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "version.go"), []byte("package main\n\nconst Version = \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	var amended bool
+	repo := &MockGitRepository{
+		PathFunc:       func() string { return tmpDir },
+		HeadPushedFunc: func() (bool, error) { return true, nil },
+		WorktreeFunc: func() (GitWorktree, error) {
+			return &MockGitWorktree{CommitFunc: func(msg string, opts *git.CommitOptions) (plumbing.Hash, error) {
+				amended = opts.Amend && msg == "Mock commit\n"
+				return plumbing.ZeroHash, nil
+			}}, nil
+		},
+	}
+	output := &bytes.Buffer{}
+
+	if err := NewBumpService(repo, nil, output).UpdateVersionFile("version.go", "v1.0.1", true); err != nil {
+		t.Fatalf("UpdateVersionFile() unexpected error = %v", err)
+	}
+	if !amended {
+		t.Error("expected an amend commit keeping the HEAD message")
+	}
+	if !strings.Contains(output.String(), "amending it rewrites published history") {
+		t.Errorf("output = %q, expected pushed HEAD warning", output.String())
+	}
+}
+
+// TestListReleaseLines tests printing the newest tag of each release line
+func TestListReleaseLines(t *testing.T) {
+	output := &bytes.Buffer{}
+	svc := NewBumpService(NewMockRepoWithTags([]string{"v1.1.0", "v1.1.9", "v1.2.3", "v1.2.7", "v0.4.0"}), nil, output)
+	if _, err := svc.ListReleaseLines(2, false); err != nil {
+		t.Fatalf("ListReleaseLines() unexpected error = %v", err)
+	}
+	expected := "1.2.x: v1.2.7\n1.1.x: v1.1.9\n"
+	if output.String() != expected {
+		t.Errorf("ListReleaseLines() output = %q, expected %q", output.String(), expected)
+	}
+
+	output.Reset()
+	if _, err := svc.ListReleaseLines(1, true); err != nil {
+		t.Fatalf("ListReleaseLines() with stripPrefix unexpected error = %v", err)
+	}
+	if output.String() != "1.2.x: 1.2.7\n" {
+		t.Errorf("ListReleaseLines() with stripPrefix output = %q, expected %q", output.String(), "1.2.x: 1.2.7\n")
+	}
+}
+
+// TestListTags tests listing tags with the subjects of their commits over a real repository
+func TestListTags(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "Initial release")
+	runGit("tag", "-a", "-m", "v1.0.0", "v1.0.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Fix login bug\n\nLonger description")
+	runGit("tag", "v1.0.1")
+	commitFile(t, repoDir, runGit, "c.txt", "Add reports")
+	runGit("tag", "-a", "-m", "v1.1.0-rc.1", "v1.1.0-rc.1")
+	runGit("tag", "not-a-version")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	if _, err := NewBumpService(repo, nil, output).ListTags("subject", 0, false); err != nil {
+		t.Fatalf("ListTags() unexpected error = %v", err)
+	}
+	expected := "v1.1.0-rc.1  Add reports\nv1.0.1       Fix login bug\nv1.0.0       Initial release\n"
+	if output.String() != expected {
+		t.Errorf("ListTags() output = %q, expected %q", output.String(), expected)
+	}
+
+	output.Reset()
+	if _, err := NewBumpService(repo, nil, output).ListTags("name", 2, false); err != nil {
+		t.Fatalf("ListTags() with limit unexpected error = %v", err)
+	}
+	if output.String() != "v1.1.0-rc.1\nv1.0.1\n" {
+		t.Errorf("ListTags() with limit output = %q", output.String())
+	}
+
+	output.Reset()
+	entries, err := NewBumpService(repo, nil, output).ListTags("subject", 0, true)
+	if err != nil {
+		t.Fatalf("ListTags() with stripPrefix unexpected error = %v", err)
+	}
+	expected = "1.1.0-rc.1  Add reports\n1.0.1       Fix login bug\n1.0.0       Initial release\n"
+	if output.String() != expected {
+		t.Errorf("ListTags() with stripPrefix output = %q, expected %q", output.String(), expected)
+	}
+	if entries[2].Tag != "v1.0.0" {
+		t.Errorf("ListTags() with stripPrefix returned %s, expected the tag v1.0.0", entries[2].Tag)
+	}
+
+	if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).ListTags("json", 0, false); err == nil {
+		t.Error("ListTags() should reject unknown formats")
+	}
+}
+
+// TestBump_FailOnDowngrade tests the downgrade guard from each way of choosing the next tag
+func TestBump_FailOnDowngrade(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "VERSION"), []byte("1.0.0\n"), 0o644); err != nil {
+		t.Fatalf("failed to write VERSION: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		tags        []string
+		opts        BumpOptions
+		expectError bool
+	}{
+		{name: "Normal bump", tags: []string{"v1.5.0"}, opts: BumpOptions{BumpType: "patch"}},
+		{name: "First tag", tags: nil, opts: BumpOptions{BumpType: "minor"}},
+		{name: "Pre-release channel", tags: []string{"v1.5.0"}, opts: BumpOptions{BumpType: "minor", Channel: "rc"}},
+		{name: "Increment build metadata", tags: []string{"v1.5.0+build.1"}, opts: BumpOptions{BumpType: "patch", IncrementBuild: true}},
+		{name: "Tag name newer", tags: []string{"v1.5.0"}, opts: BumpOptions{TagName: "v2.0.0"}},
+		{name: "Tag name older", tags: []string{"v1.5.0"}, opts: BumpOptions{TagName: "v1.4.9"}, expectError: true},
+		{name: "Tag name equal", tags: []string{"v1.5.0"}, opts: BumpOptions{TagName: "v1.5.0"}, expectError: true},
+		{name: "Tag name pre-release of latest", tags: []string{"v1.5.0"}, opts: BumpOptions{TagName: "v1.5.0-rc.1"}, expectError: true},
+		{name: "Tag name not a version", tags: []string{"v1.5.0"}, opts: BumpOptions{TagName: "nightly"}, expectError: true},
+		{name: "Base file behind latest", tags: []string{"v1.5.0"}, opts: BumpOptions{BumpType: "patch", BaseFromFile: "VERSION"}, expectError: true},
+		{name: "Base file ahead of latest", tags: []string{"v0.9.0"}, opts: BumpOptions{BumpType: "patch", BaseFromFile: "VERSION"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepoWithTags(tt.tags)
+			repo.PathFunc = func() string { return tmpDir }
+
+			opts := tt.opts
+			opts.DryRun = true
+			if _, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts); err != nil {
+				t.Fatalf("Bump() without the guard unexpected error = %v", err)
+			}
+
+			opts.FailOnDowngrade = true
+			_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(opts)
+			if (err != nil) != tt.expectError {
+				t.Errorf("Bump() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestLintTags tests that lint-tags reports malformed version tags and fails only when there are any
+func TestLintTags(t *testing.T) {
+	output := &bytes.Buffer{}
+	repo := NewMockRepoWithTags([]string{"v1.0.0", "v1.1", "v01.2.0", "nightly", "v1.2.3-rc.01"})
+
+	lints, err := NewBumpService(repo, nil, output).LintTags()
+	if err == nil || !strings.Contains(err.Error(), "found 3 malformed") {
+		t.Errorf("LintTags() error = %v, expected 3 malformed tags", err)
+	}
+	expected := "v01.2.0: leading zero in major version \"01\"\n" +
+		"v1.1: missing components: \"1.1\" is not MAJOR.MINOR.PATCH\n" +
+		"v1.2.3-rc.01: leading zero in pre-release identifier \"01\"\n"
+	if len(lints) != 3 || output.String() != expected {
+		t.Errorf("LintTags() output = %q, expected %q", output.String(), expected)
+	}
+
+	output.Reset()
+	if _, err := NewBumpService(NewMockRepoWithTags([]string{"v1.0.0", "nightly"}), nil, output).LintTags(); err != nil {
+		t.Errorf("LintTags() on valid tags error = %v", err)
+	}
+	if output.String() != "All version tags are valid SemVer\n" {
+		t.Errorf("LintTags() on valid tags output = %q", output.String())
+	}
+}
+
+// TestListHeadTags tests listing only the tags on HEAD, with HEAD tagged and untagged
+func TestListHeadTags(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "Initial release")
+	runGit("tag", "v1.0.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Add reports")
+	runGit("tag", "-a", "-m", "v1.1.0-rc.1", "v1.1.0-rc.1")
+	runGit("tag", "-a", "-m", "v1.1.0", "v1.1.0")
+	runGit("tag", "deployed")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	if _, err := NewBumpService(repo, nil, output).ListHeadTags("subject", false); err != nil {
+		t.Fatalf("ListHeadTags() unexpected error = %v", err)
+	}
+	expected := "v1.1.0       Add reports\nv1.1.0-rc.1  Add reports\n"
+	if output.String() != expected {
+		t.Errorf("ListHeadTags() output = %q, expected %q", output.String(), expected)
+	}
+
+	output.Reset()
+	if _, err := NewBumpService(repo, nil, output).ListHeadTags("name", true); err != nil {
+		t.Fatalf("ListHeadTags() with stripPrefix unexpected error = %v", err)
+	}
+	if output.String() != "1.1.0\n1.1.0-rc.1\n" {
+		t.Errorf("ListHeadTags() with stripPrefix output = %q", output.String())
+	}
+
+	commitFile(t, repoDir, runGit, "c.txt", "Unreleased work")
+	output.Reset()
+	if _, err := NewBumpService(repo, nil, output).ListHeadTags("name", false); !errors.Is(err, ErrHeadNotTagged) {
+		t.Errorf("ListHeadTags() on an untagged HEAD error = %v, expected ErrHeadNotTagged", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("ListHeadTags() on an untagged HEAD printed %q", output.String())
+	}
+}
+
+// TestBump_NoChanges tests refusing to tag when there are no commits since the latest tag
+func TestBump_NoChanges(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+	runGit("tag", "-a", "-m", "v1.0.0", "v1.0.0")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	_, err = svc.Bump(BumpOptions{BumpType: "patch"})
+	if !errors.Is(err, ErrNoChanges) {
+		t.Fatalf("Bump() error = %v, expected ErrNoChanges", err)
+	}
+	if tags := runGit("tag", "--list"); strings.Contains(tags, "v1.0.1") {
+		t.Errorf("no tag should be created without changes, got: %s", tags)
+	}
+
+	result, err := svc.Bump(BumpOptions{BumpType: "patch", AllowEmpty: true})
+	if err != nil {
+		t.Fatalf("Bump() with AllowEmpty unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.0.1" {
+		t.Errorf("NextTag = %v, expected v1.0.1", result.NextTag)
+	}
+	if tags := runGit("tag", "--list"); !strings.Contains(tags, "v1.0.1") {
+		t.Errorf("expected v1.0.1 to be created with AllowEmpty, got: %s", tags)
+	}
+
+	// New commits after the latest tag allow a normal bump
+	commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+	result, err = svc.Bump(BumpOptions{BumpType: "patch"})
+	if err != nil {
+		t.Fatalf("Bump() after new commit unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.0.2" {
+		t.Errorf("NextTag = %v, expected v1.0.2", result.NextTag)
+	}
+
+	// Counting from an explicit older tag
+	_, err = svc.Bump(BumpOptions{BumpType: "patch", Since: "v1.0.2"})
+	if !errors.Is(err, ErrNoChanges) {
+		t.Errorf("Bump() with Since at HEAD error = %v, expected ErrNoChanges", err)
+	}
+	if _, err := svc.Bump(BumpOptions{BumpType: "patch", Since: "v1.0.0", DryRun: true}); err != nil {
+		t.Errorf("Bump() with Since v1.0.0 unexpected error = %v", err)
+	}
+}
+
+// TestBump_NoChangesPromotion tests promoting a release candidate on the commit it was cut from
+func TestBump_NoChangesPromotion(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+	runGit("tag", "-a", "-m", "v1.2.0-rc.1", "v1.2.0-rc.1")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	result, err := svc.Bump(BumpOptions{BumpType: "patch", TagName: "v1.2.0"})
+	if err != nil {
+		t.Fatalf("Bump() promoting the release candidate unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.2.0" {
+		t.Errorf("NextTag = %v, expected v1.2.0", result.NextTag)
+	}
+
+	// Moving on to a new version still needs new commits
+	if _, err := svc.Bump(BumpOptions{BumpType: "patch"}); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("Bump() past the promoted release error = %v, expected ErrNoChanges", err)
+	}
+}
+
+// TestBump_TagScheme tests that a bump reads and writes tags with the repository's tag scheme
+func TestBump_TagScheme(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+	runGit("tag", "v1.1.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+	runGit("tag", "v1.2.0_rc.1")
+	commitFile(t, repoDir, runGit, "c.txt", "Third commit")
+
+	repo, err := NewGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewGoGitRepository() error = %v", err)
+	}
+	result, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "patch", DryRun: true})
+	if err != nil {
+		t.Fatalf("Bump() with the default scheme unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.1.1" {
+		t.Errorf("NextTag with the default scheme = %v, expected v1.1.1", result.NextTag)
+	}
+
+	if repo.scheme, err = bump.NewTagScheme(bump.TagSchemeOptions{SuffixSeparator: "_"}); err != nil {
+		t.Fatalf("NewTagScheme() error = %v", err)
+	}
+	result, err = NewBumpService(repo, nil, &bytes.Buffer{}).Bump(BumpOptions{BumpType: "prerelease", DryRun: true})
+	if err != nil {
+		t.Fatalf("Bump() with separator _ unexpected error = %v", err)
+	}
+	if result.NextTag != "v1.2.0_rc.2" {
+		t.Errorf("NextTag with separator _ = %v, expected v1.2.0_rc.2", result.NextTag)
+	}
+}
+
+// TestBump_TagPrefix tests bumping and snapshotting with the prefix setting
+func TestBump_TagPrefix(t *testing.T) {
+	repoDir, runGit := newGitRepoWithCommits(t)
+	commitFile(t, repoDir, runGit, "a.txt", "First commit")
+	runGit("tag", "v9.0.0")
+	runGit("tag", "api/v1.2.0")
+	commitFile(t, repoDir, runGit, "b.txt", "Second commit")
+	runGit("config", "bump.prefix", "api/v")
+
+	repo, err := openGoGitRepository(repoDir)
+	if err != nil {
+		t.Fatalf("openGoGitRepository() error = %v", err)
+	}
+	svc := NewBumpService(repo, nil, &bytes.Buffer{})
+
+	devTag, err := svc.Dev("", false, true)
+	if err != nil {
+		t.Fatalf("Dev() unexpected error = %v", err)
+	}
+	if devTag != "api/v1.2.1-dev.1" {
+		t.Errorf("Dev() = %s, expected api/v1.2.1-dev.1", devTag)
+	}
+
+	result, err := svc.Bump(BumpOptions{BumpType: "patch"})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+	if result.NextTag != "api/v1.2.1" {
+		t.Errorf("NextTag = %v, expected api/v1.2.1", result.NextTag)
+	}
+	if tags := strings.TrimSpace(runGit("tag", "--list", "api/*")); tags != "api/v1.2.0\napi/v1.2.1" {
+		t.Errorf("api tags = %q, expected api/v1.2.0 and api/v1.2.1", tags)
+	}
+}
+
+// TestBump_Trailers tests composing tag annotations with signoff and trailers
+func TestBump_Trailers(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            BumpOptions
+		identity        [2]string
+		expectedMessage string
+		expectError     string
+	}{
+		{
+			name:            "Signoff only",
+			opts:            BumpOptions{BumpType: "patch", Signoff: true},
+			identity:        [2]string{"Jane Doe", "jane@example.com"},
+			expectedMessage: "v1.0.1\n\nSigned-off-by: Jane Doe <jane@example.com>\n",
+		},
+		{
+			name: "Signoff with extra trailers",
+			opts: BumpOptions{
+				BumpType: "minor",
+				Signoff:  true,
+				Trailers: []string{"Co-authored-by=Bob <bob@example.com>"},
+			},
+			identity:        [2]string{"Jane Doe", "jane@example.com"},
+			expectedMessage: "v1.1.0\n\nCo-authored-by: Bob <bob@example.com>\nSigned-off-by: Jane Doe <jane@example.com>\n",
+		},
+		{
+			name:        "Signoff without identity",
+			opts:        BumpOptions{BumpType: "patch", Signoff: true},
+			expectError: "requires user.name and user.email",
+		},
+		{
+			name:        "Malformed trailer",
+			opts:        BumpOptions{BumpType: "patch", Trailers: []string{"oops"}},
+			expectError: "invalid trailer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMessage string
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.UserIdentityFunc = func() (string, string, error) {
+				return tt.identity[0], tt.identity[1], nil
+			}
+			repo.CreateTagFunc = func(_ string, opts bump.TagOptions) error {
+				gotMessage = opts.Message
+				return nil
+			}
+
+			_, err := NewBumpService(repo, nil, &bytes.Buffer{}).Bump(tt.opts)
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() unexpected error = %v", err)
+			}
+			if gotMessage != tt.expectedMessage {
+				t.Errorf("tag message = %q, expected %q", gotMessage, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// TestBump_GitHubRelease tests creating a GitHub release after pushing the tag
+func TestBump_GitHubRelease(t *testing.T) {
+	var got GitHubRelease
+	var gotPath, gotAuth string
+	server := newGitHubStub(t, http.StatusCreated, `{"html_url":"https://github.com/acme/widgets/releases/tag/v1.1.0-rc1"}`, &got, &gotPath, &gotAuth)
+
+	pushed := false
+	repo := NewMockRepoWithTags([]string{"v1.0.0"})
+	repo.PushTagsFunc = func(string) error {
+		pushed = true
+		return nil
+	}
+	repo.RemoteURLFunc = func(name string) (string, error) {
+		return "https://github.com/acme/widgets.git", nil
+	}
+	repo.CommitsSinceFunc = func(string) ([]CommitInfo, error) {
+		return []CommitInfo{{Hash: "abc", Subject: "Add widgets"}}, nil
+	}
+	output := &bytes.Buffer{}
+	svc := NewBumpService(repo, nil, output)
+	svc.github = NewGitHubClient("secret", server.Client())
+	svc.github.baseURL = server.URL
+
+	result, err := svc.Bump(BumpOptions{BumpType: "minor", Suffix: "rc1", Push: true, GitHubRelease: true})
+	if err != nil {
+		t.Fatalf("Bump() unexpected error = %v", err)
+	}
+
+	if !pushed {
+		t.Error("tag should be pushed before creating the release")
+	}
+	expected := GitHubRelease{
+		TagName:    "v1.1.0-rc1",
+		Name:       "v1.1.0-rc1",
+		Body:       "Changes in v1.1.0-rc1 since v1.0.0:\n- Add widgets\n",
+		Prerelease: true,
+	}
+	if got != expected {
+		t.Errorf("release payload = %+v, expected %+v", got, expected)
+	}
+	if gotPath != "POST /repos/acme/widgets/releases" {
+		t.Errorf("request = %q, expected POST /repos/acme/widgets/releases", gotPath)
+	}
+	if result.ReleaseURL != "https://github.com/acme/widgets/releases/tag/v1.1.0-rc1" {
+		t.Errorf("ReleaseURL = %q", result.ReleaseURL)
+	}
+	if !strings.Contains(output.String(), "Created GitHub release: https://github.com/acme/widgets/releases/tag/v1.1.0-rc1") {
+		t.Errorf("Output missing release URL:\n%v", output.String())
+	}
+}
+
+// TestBump_GitHubReleaseErrors tests that a misconfigured release fails before tagging
+func TestBump_GitHubReleaseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		remoteURL   string
+		push        bool
+		expectError string
+	}{
+		{name: "Without push", token: "secret", remoteURL: "git@github.com:acme/widgets.git", expectError: "requires the tag to be pushed"},
+		{name: "Without token", remoteURL: "git@github.com:acme/widgets.git", push: true, expectError: githubTokenEnv},
+		{name: "Non-GitHub origin", token: "secret", remoteURL: "git@gitlab.com:acme/widgets.git", push: true, expectError: "not a GitHub remote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagCreated := false
+			repo := NewMockRepoWithTags([]string{"v1.0.0"})
+			repo.CreateTagFunc = func(string, bump.TagOptions) error {
+				tagCreated = true
+				return nil
+			}
+			repo.RemoteURLFunc = func(string) (string, error) {
+				return tt.remoteURL, nil
+			}
+			svc := NewBumpService(repo, nil, &bytes.Buffer{})
+			svc.github = NewGitHubClient(tt.token, nil)
+
+			_, err := svc.Bump(BumpOptions{BumpType: "patch", Push: tt.push, GitHubRelease: true})
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Fatalf("Bump() error = %v, expected to contain %q", err, tt.expectError)
+			}
+			if tagCreated {
+				t.Error("tag should not be created when the release is misconfigured")
+			}
+		})
+	}
+}
+
 // TestUpdateVersionFile_Success tests successful file updates
 func TestUpdateVersionFile_Success(t *testing.T) {
 	// Create temp directory (this will be the repo root)
@@ -345,7 +3379,7 @@ const Version = "1.0.0"
 	svc := NewBumpService(repo, nil, &bytes.Buffer{})
 
 	// Execute with relative path (validateFilePath requires relative paths)
-	err := svc.UpdateVersionFile("version.go", "v1.0.1")
+	err := svc.UpdateVersionFile("version.go", "v1.0.1", false)
 	if err != nil {
 		t.Errorf("UpdateVersionFile() unexpected error = %v", err)
 		return
@@ -431,7 +3465,7 @@ func TestUpdateVersionFile_Errors(t *testing.T) {
 			filePath, nextTag, repo := tt.setup(t)
 			svc := NewBumpService(repo, nil, &bytes.Buffer{})
 
-			err := svc.UpdateVersionFile(filePath, nextTag)
+			err := svc.UpdateVersionFile(filePath, nextTag, false)
 
 			if err == nil {
 				t.Error("UpdateVersionFile() should return error")
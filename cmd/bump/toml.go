@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// cargoTOMLName is the Rust manifest, whose version lives in [package].
+	cargoTOMLName = "Cargo.toml"
+
+	// pyprojectTOMLName is the Python manifest, whose version lives in [project]
+	// or, for Poetry, in [tool.poetry].
+	pyprojectTOMLName = "pyproject.toml"
+)
+
+// errTOMLKeyNotFound reports a key path missing from a TOML document.
+var errTOMLKeyNotFound = errors.New("key not found")
+
+// isTOMLFile reports whether filePath names a TOML file such as Cargo.toml.
+func isTOMLFile(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".toml")
+}
+
+// tomlKeyPaths returns the key paths tried, in order, for the version in the TOML
+// file at filePath: the configured path (from --toml-path) if any, otherwise the
+// conventional one for Cargo.toml or pyproject.toml.
+func tomlKeyPaths(filePath string, configured []string) ([][]string, error) {
+	if len(configured) > 0 {
+		return [][]string{configured}, nil
+	}
+	switch filepath.Base(filePath) {
+	case cargoTOMLName:
+		return [][]string{{"package", "version"}}, nil
+	case pyprojectTOMLName:
+		return [][]string{{"project", "version"}, {"tool", "poetry", "version"}}, nil
+	}
+	return nil, fmt.Errorf("cannot tell where the version lives in %s; pass --toml-path, e.g. package.version", filepath.Base(filePath))
+}
+
+// parseTOMLPath splits a dotted key path such as tool.poetry.version, as given to
+// --toml-path, into its keys.
+func parseTOMLPath(path string) ([]string, error) {
+	keys := splitTOMLKey(path)
+	if slices.Contains(keys, "") {
+		return nil, fmt.Errorf("invalid TOML path %q: expected dotted keys, e.g. package.version", path)
+	}
+	return keys, nil
+}
+
+// readTOMLVersion returns the version at the first of paths present in a TOML document.
+func readTOMLVersion(content []byte, paths [][]string) (string, error) {
+	start, end, err := findTOMLVersion(content, paths)
+	if err != nil {
+		return "", err
+	}
+	return string(content[start+1 : end-1]), nil
+}
+
+// updateTOMLVersion sets the version at the first of paths present in a TOML
+// document. Only the characters inside the quotes change, so comments, key order,
+// and the quote style are preserved exactly.
+func updateTOMLVersion(content []byte, paths [][]string, version string) ([]byte, error) {
+	start, end, err := findTOMLVersion(content, paths)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	out.Write(content[:start+1])
+	out.WriteString(version)
+	out.Write(content[end-1:])
+	return out.Bytes(), nil
+}
+
+// findTOMLVersion returns the byte span, including quotes, of the string at the
+// first of paths present in content.
+func findTOMLVersion(content []byte, paths [][]string) (int, int, error) {
+	var tried []string
+	for _, path := range paths {
+		start, end, err := findTOMLString(content, path)
+		if errors.Is(err, errTOMLKeyNotFound) {
+			tried = append(tried, strings.Join(path, "."))
+			continue
+		}
+		return start, end, err
+	}
+	return 0, 0, fmt.Errorf("%w: %s", errTOMLKeyNotFound, strings.Join(tried, " or "))
+}
+
+// findTOMLString returns the byte span, including quotes, of the single-line
+// string value at the key path. Table headers are tracked so that a version key
+// in another table, such as [dependencies], is never taken for it, and lines
+// inside multi-line strings, arrays, and inline tables are not read as keys.
+func findTOMLString(content []byte, path []string) (int, int, error) {
+	keyPath := strings.Join(path, ".")
+	var table []string
+	arrayTable := false // Keys under [[array]] headers belong to an array element
+	var scanner tomlScanner
+
+	for offset := 0; offset < len(content); {
+		lineEnd := bytes.IndexByte(content[offset:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(content)
+		} else {
+			lineEnd += offset
+		}
+		line := string(content[offset:lineEnd])
+		lineStart := offset
+		offset = lineEnd + 1
+
+		if scanner.depth == 0 && scanner.multiline == "" {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(trimmed, "[["):
+				arrayTable = true
+			case strings.HasPrefix(trimmed, "["):
+				if end := strings.Index(trimmed, "]"); end > 0 {
+					table = splitTOMLKey(trimmed[1:end])
+					arrayTable = false
+				}
+			default:
+				key, valueStart, ok := tomlKeyValue(line)
+				if ok && !arrayTable && slices.Equal(append(slices.Clone(table), key...), path) {
+					end, ok := tomlStringEnd(line[valueStart:])
+					if !ok {
+						return 0, 0, fmt.Errorf("%s is not a single-line string", keyPath)
+					}
+					return lineStart + valueStart, lineStart + valueStart + end, nil
+				}
+			}
+		}
+		scanner.advance(line)
+	}
+	return 0, 0, fmt.Errorf("%w: %s", errTOMLKeyNotFound, keyPath)
+}
+
+// tomlScanner tracks the multi-line constructs a TOML document is inside of at
+// the end of each line.
+type tomlScanner struct {
+	depth     int    // Nesting of open arrays and inline tables
+	multiline string // Delimiter of the open multi-line string (""" or '''), if any
+}
+
+// advance updates the scanner state past line.
+func (sc *tomlScanner) advance(line string) {
+	for i := 0; i < len(line); i++ {
+		if sc.multiline != "" {
+			end := strings.Index(line[i:], sc.multiline)
+			if end < 0 {
+				return
+			}
+			i += end + len(sc.multiline) - 1
+			sc.multiline = ""
+			continue
+		}
+		switch c := line[i]; {
+		case c == '#':
+			return
+		case strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], "'''"):
+			sc.multiline = line[i : i+3]
+			i += 2
+		case c == '"' || c == '\'':
+			if end, ok := tomlStringEnd(line[i:]); ok {
+				i += end - 1
+			} else {
+				return
+			}
+		case c == '[' || c == '{':
+			sc.depth++
+		case c == ']' || c == '}':
+			sc.depth--
+		}
+	}
+}
+
+// tomlKeyValue splits a key/value line into the keys of its (possibly dotted)
+// key and the offset where the value starts.
+func tomlKeyValue(line string) ([]string, int, bool) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return nil, 0, false
+		case c == '=':
+			key := strings.TrimSpace(line[:i])
+			if key == "" {
+				return nil, 0, false
+			}
+			valueStart := i + 1
+			for valueStart < len(line) && (line[valueStart] == ' ' || line[valueStart] == '\t') {
+				valueStart++
+			}
+			return splitTOMLKey(key), valueStart, true
+		}
+	}
+	return nil, 0, false
+}
+
+// splitTOMLKey splits a dotted TOML key into its keys, removing the quotes of
+// quoted keys and the whitespace around the dots.
+func splitTOMLKey(key string) []string {
+	var keys []string
+	var current strings.Builder
+	var quote byte
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			keys = append(keys, current.String())
+			current.Reset()
+		case c != ' ' && c != '\t':
+			current.WriteByte(c)
+		}
+	}
+	return append(keys, current.String())
+}
+
+// tomlStringEnd returns the offset just past the closing quote of the basic
+// ("...") or literal ('...') string at the start of s. Multi-line strings are not
+// single-line values and are rejected.
+func tomlStringEnd(s string) (int, bool) {
+	if s == "" || strings.HasPrefix(s, `"""`) || strings.HasPrefix(s, "'''") {
+		return 0, false
+	}
+	quote := s[0]
+	if quote != '"' && quote != '\'' {
+		return 0, false
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote == '"' {
+				i++ // Skip the escaped character; literal strings have no escapes
+			}
+		case quote:
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// writeTOMLVersion sets the version at the first of paths present in the TOML
+// file at absPath.
+func writeTOMLVersion(absPath string, paths [][]string, version string) error {
+	if err := rewriteFile(absPath, func(content []byte) ([]byte, error) {
+		return updateTOMLVersion(content, paths, version)
+	}); err != nil {
+		return fmt.Errorf("failed to update %s: %w", filepath.Base(absPath), err)
+	}
+	return nil
+}
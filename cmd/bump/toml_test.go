@@ -0,0 +1,156 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUpdateTOMLVersion tests that only the version in the selected table changes
+func TestUpdateTOMLVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		tomlPath string
+		content  string
+		expected string
+		errMsg   string
+	}{
+		{
+			name: "Cargo.toml",
+			file: "Cargo.toml",
+			content: "# Widgets crate\n[package]\nname = \"widgets\"\nversion = \"1.0.0\" # bumped on release\n\n" +
+				"[dependencies]\nserde = { version = \"1.0\", features = [\"derive\"] }\n\n[dependencies.rand]\nversion = \"0.8\"\n",
+			expected: "# Widgets crate\n[package]\nname = \"widgets\"\nversion = \"1.0.1-dev\" # bumped on release\n\n" +
+				"[dependencies]\nserde = { version = \"1.0\", features = [\"derive\"] }\n\n[dependencies.rand]\nversion = \"0.8\"\n",
+		},
+		{
+			name:     "Cargo.toml with dependencies first",
+			file:     "Cargo.toml",
+			content:  "[dependencies.rand]\nversion = \"0.8\"\n\n[package]\nname = \"widgets\"\nversion='1.0.0'\n",
+			expected: "[dependencies.rand]\nversion = \"0.8\"\n\n[package]\nname = \"widgets\"\nversion='1.0.1-dev'\n",
+		},
+		{
+			name:     "pyproject.toml",
+			file:     "pyproject.toml",
+			content:  "[build-system]\nrequires = [\n  \"setuptools\",\n]\n\n[project]\nname = \"widgets\"\nversion = \"1.0.0\"\n",
+			expected: "[build-system]\nrequires = [\n  \"setuptools\",\n]\n\n[project]\nname = \"widgets\"\nversion = \"1.0.1-dev\"\n",
+		},
+		{
+			name:     "pyproject.toml with Poetry",
+			file:     "pyproject.toml",
+			content:  "[tool.black]\nline-length = 100\n\n[ tool . poetry ]\nname = \"widgets\"\nversion = \"1.0.0\"\n",
+			expected: "[tool.black]\nline-length = 100\n\n[ tool . poetry ]\nname = \"widgets\"\nversion = \"1.0.1-dev\"\n",
+		},
+		{
+			name:     "dotted key in the parent table",
+			file:     "pyproject.toml",
+			content:  "[tool]\npoetry.name = \"widgets\"\npoetry.version = \"1.0.0\"\n",
+			expected: "[tool]\npoetry.name = \"widgets\"\npoetry.version = \"1.0.1-dev\"\n",
+		},
+		{
+			name:     "custom path",
+			file:     "release.toml",
+			tomlPath: "release.\"app.version\"",
+			content:  "version = \"0.0.0\"\n[release]\n\"app.version\" = \"1.0.0\"\n",
+			expected: "version = \"0.0.0\"\n[release]\n\"app.version\" = \"1.0.1-dev\"\n",
+		},
+		{
+			name: "lookalikes in strings and array tables ignored",
+			file: "Cargo.toml",
+			content: "[package]\nname = \"widgets\"\ndescription = \"\"\"\n[workspace]\nversion = \"9.9.9\"\n\"\"\"\nversion = \"1.0.0\"\n\n" +
+				"[[bin]]\nversion = \"2.0.0\"\n",
+			expected: "[package]\nname = \"widgets\"\ndescription = \"\"\"\n[workspace]\nversion = \"9.9.9\"\n\"\"\"\nversion = \"1.0.1-dev\"\n\n" +
+				"[[bin]]\nversion = \"2.0.0\"\n",
+		},
+		{
+			name:    "workspace-inherited version",
+			file:    "Cargo.toml",
+			content: "[package]\nname = \"widgets\"\nversion.workspace = true\n",
+			errMsg:  "key not found: package.version",
+		},
+		{
+			name:    "version not a string",
+			file:    "Cargo.toml",
+			content: "[package]\nversion = 1\n",
+			errMsg:  "package.version is not a single-line string",
+		},
+		{
+			name:    "missing version",
+			file:    "pyproject.toml",
+			content: "[project]\nname = \"widgets\"\ndynamic = [\"version\"]\n",
+			errMsg:  "key not found: project.version or tool.poetry.version",
+		},
+		{
+			name:    "unknown file without a path",
+			file:    "release.toml",
+			content: "version = \"1.0.0\"\n",
+			errMsg:  "pass --toml-path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configured []string
+			if tt.tomlPath != "" {
+				var err error
+				if configured, err = parseTOMLPath(tt.tomlPath); err != nil {
+					t.Fatalf("parseTOMLPath() unexpected error = %v", err)
+				}
+			}
+			paths, err := tomlKeyPaths(tt.file, configured)
+			var got []byte
+			if err == nil {
+				got, err = updateTOMLVersion([]byte(tt.content), paths, "1.0.1-dev")
+			}
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("updateTOMLVersion() error = %v, expected %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("updateTOMLVersion() unexpected error = %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("updateTOMLVersion() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+			if version, err := readTOMLVersion(got, paths); err != nil || version != "1.0.1-dev" {
+				t.Errorf("readTOMLVersion() = %q, %v", version, err)
+			}
+		})
+	}
+}
+
+// TestParseTOMLPath tests splitting --toml-path into keys
+func TestParseTOMLPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+		errMsg   string
+	}{
+		{path: "package.version", expected: []string{"package", "version"}},
+		{path: "tool . poetry . version", expected: []string{"tool", "poetry", "version"}},
+		{path: `release."app.version"`, expected: []string{"release", "app.version"}},
+		{path: "version", expected: []string{"version"}},
+		{path: "package..version", errMsg: "invalid TOML path"},
+		{path: "package.", errMsg: "invalid TOML path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parseTOMLPath(tt.path)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("parseTOMLPath() error = %v, expected %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTOMLPath() unexpected error = %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("parseTOMLPath() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+)
+
+// Version is the version of the bump CLI itself. Releases keep it current by
+// running bump on its own repository with --update-file cmd/bump/version.go.
+const Version = "0.1.0-dev"
+
+// formatVersion renders the CLI version followed by the Go version and the VCS
+// commit recorded in the build info, when available. A modified working tree at
+// build time is marked "(dirty)".
+func formatVersion(version string, info *debug.BuildInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "bump %s\n", version)
+	if info == nil {
+		return b.String()
+	}
+	fmt.Fprintf(&b, "go: %s\n", info.GoVersion)
+
+	var revision string
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision != "" {
+		if modified {
+			revision += " (dirty)"
+		}
+		fmt.Fprintf(&b, "commit: %s\n", revision)
+	}
+	return b.String()
+}
+
+// printVersion writes the CLI version and the build info of the running binary.
+func printVersion(w io.Writer) error {
+	info, _ := debug.ReadBuildInfo() // nil when the binary carries no build info
+	if _, err := fmt.Fprint(w, formatVersion(Version, info)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// TestFormatVersion tests rendering the version with and without build info
+func TestFormatVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *debug.BuildInfo
+		expected string
+	}{
+		{
+			name:     "no build info",
+			info:     nil,
+			expected: "bump 1.2.3\n",
+		},
+		{
+			name:     "no vcs info",
+			info:     &debug.BuildInfo{GoVersion: "go1.25.0"},
+			expected: "bump 1.2.3\ngo: go1.25.0\n",
+		},
+		{
+			name: "clean commit",
+			info: &debug.BuildInfo{GoVersion: "go1.25.0", Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123abcd"},
+				{Key: "vcs.modified", Value: "false"},
+			}},
+			expected: "bump 1.2.3\ngo: go1.25.0\ncommit: 0123abcd\n",
+		},
+		{
+			name: "dirty commit",
+			info: &debug.BuildInfo{GoVersion: "go1.25.0", Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123abcd"},
+				{Key: "vcs.modified", Value: "true"},
+			}},
+			expected: "bump 1.2.3\ngo: go1.25.0\ncommit: 0123abcd (dirty)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatVersion("1.2.3", tt.info); got != tt.expected {
+				t.Errorf("formatVersion() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestVersionFlag tests that --version prints the CLI version
+func TestVersionFlag(t *testing.T) {
+	var out bytes.Buffer
+	app := &cli.App{Name: "bump", Version: Version, Writer: &out}
+	defer func(printer func(*cli.Context)) { cli.VersionPrinter = printer }(cli.VersionPrinter)
+	cli.VersionPrinter = func(c *cli.Context) {
+		if err := printVersion(c.App.Writer); err != nil {
+			t.Errorf("printVersion() unexpected error = %v", err)
+		}
+	}
+	if err := app.Run([]string{"bump", "--version"}); err != nil {
+		t.Fatalf("bump --version unexpected error = %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "bump "+Version+"\n") {
+		t.Errorf("bump --version output = %q, expected it to start with the version %s", out.String(), Version)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookPayload is the JSON document posted to --webhook after a successful bump.
+type WebhookPayload struct {
+	Tag         string `json:"tag"`
+	PreviousTag string `json:"previousTag"`
+	RepoPath    string `json:"repoPath"`
+	Timestamp   string `json:"timestamp"` // RFC 3339, UTC
+}
+
+// WebhookClient posts bump notifications to a webhook URL.
+// The HTTP client is injectable so tests can use a stub server.
+type WebhookClient struct {
+	httpClient *http.Client
+}
+
+// NewWebhookClient creates a WebhookClient. A nil httpClient uses a client with a
+// short timeout, so a slow endpoint cannot hold up a release.
+func NewWebhookClient(httpClient *http.Client) *WebhookClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookClient{httpClient: httpClient}
+}
+
+// Notify posts the payload to url as JSON. Any 2xx response is a success.
+func (c *WebhookClient) Notify(url string, payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
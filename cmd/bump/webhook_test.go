@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newWebhookStub starts a stub webhook endpoint that records the payload and
+// responds with the given status.
+func newWebhookStub(t *testing.T, status int, got *WebhookPayload) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s with Content-Type %q, expected JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("stub response"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestWebhookClientNotify tests posting the payload and reporting non-2xx responses
+func TestWebhookClientNotify(t *testing.T) {
+	payload := WebhookPayload{Tag: "v1.1.0", PreviousTag: "v1.0.0", RepoPath: "/repo", Timestamp: "2024-06-01T12:00:00Z"}
+
+	var got WebhookPayload
+	server := newWebhookStub(t, http.StatusNoContent, &got)
+	if err := NewWebhookClient(server.Client()).Notify(server.URL, payload); err != nil {
+		t.Fatalf("Notify() unexpected error = %v", err)
+	}
+	if got != payload {
+		t.Errorf("payload = %+v, expected %+v", got, payload)
+	}
+
+	server = newWebhookStub(t, http.StatusInternalServerError, &got)
+	err := NewWebhookClient(server.Client()).Notify(server.URL, payload)
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Notify() error = %v, expected status in error", err)
+	}
+}
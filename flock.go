@@ -0,0 +1,24 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package bump
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryFlock takes an exclusive advisory lock on f without blocking. It reports
+// false when another open file description already holds the lock.
+func tryFlock(f *os.File) (bool, error) {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// unflock releases an advisory lock taken with tryFlock.
+func unflock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package bump
+
+import "os"
+
+// tryFlock reports errFlockUnsupported on platforms without flock.
+func tryFlock(f *os.File) (bool, error) {
+	return false, errFlockUnsupported
+}
+
+// unflock reports errFlockUnsupported on platforms without flock.
+func unflock(f *os.File) error {
+	return errFlockUnsupported
+}
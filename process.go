@@ -0,0 +1,19 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package bump
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given PID is running. Signal 0
+// only checks for existence; EPERM means the process exists but belongs to
+// another user.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
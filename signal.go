@@ -0,0 +1,88 @@
+package bump
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/charmbracelet/log"
+)
+
+// exitFunc is a variable to hold the os.Exit function for easier testing and mocking.
+var exitFunc = os.Exit
+
+// heldLocks records the locks this process holds so an interrupt can release them
+// before exiting, instead of leaving a lock file behind.
+var heldLocks = make(map[*GitLock]struct{})
+
+// heldLocksMutex protects heldLocks and interrupts.
+var heldLocksMutex sync.Mutex
+
+// interrupts receives SIGINT and SIGTERM while any lock is held; nil otherwise.
+var interrupts chan os.Signal
+
+// trackLock records a newly acquired lock, installing the interrupt handler when
+// it is the first lock held.
+func trackLock(lock *GitLock) {
+	heldLocksMutex.Lock()
+	defer heldLocksMutex.Unlock()
+
+	if len(heldLocks) == 0 {
+		interrupts = make(chan os.Signal, 1)
+		signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+		go waitForInterrupt(interrupts)
+	}
+	heldLocks[lock] = struct{}{}
+}
+
+// untrackLock forgets a lock being released, removing the interrupt handler once
+// no lock is held. It reports whether the lock was still held, so a lock released
+// by both the interrupt handler and its owner is only released once.
+func untrackLock(lock *GitLock) bool {
+	heldLocksMutex.Lock()
+	defer heldLocksMutex.Unlock()
+
+	if _, held := heldLocks[lock]; !held {
+		return false
+	}
+	delete(heldLocks, lock)
+	if len(heldLocks) == 0 {
+		signal.Stop(interrupts)
+		close(interrupts)
+		interrupts = nil
+	}
+	return true
+}
+
+// waitForInterrupt handles the first signal received on ch. It returns without
+// action when ch is closed because the last lock was released.
+func waitForInterrupt(ch <-chan os.Signal) {
+	if sig, ok := <-ch; ok {
+		handleInterrupt(sig)
+	}
+}
+
+// handleInterrupt releases every held lock and exits with the shell convention
+// status for sig (130 for SIGINT, 143 for SIGTERM).
+func handleInterrupt(sig os.Signal) {
+	heldLocksMutex.Lock()
+	locks := make([]*GitLock, 0, len(heldLocks))
+	for lock := range heldLocks {
+		locks = append(locks, lock)
+	}
+	heldLocksMutex.Unlock()
+
+	log.Warn("interrupted, releasing locks", "signal", sig)
+	for _, lock := range locks {
+		if err := lock.Release(); err != nil {
+			log.Error("failed to release lock", "lockFile", lock.lockFile, "err", err)
+		}
+	}
+
+	code := 1
+	if s, ok := sig.(syscall.Signal); ok {
+		code = 128 + int(s)
+	}
+	exitFunc(code)
+}